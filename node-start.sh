@@ -94,7 +94,7 @@ startValidator(){
         :
     else
         tmux new-session -d -s node$i
-        tmux send-keys -t 0 "./node_src/build/bin/geth --datadir ./chaindata/node$i --networkid $CHAINID --bootnodes $BOOTNODE --mine --port 326$j --nat extip:$IP --miner.gaslimit=10000000000000 --unlock 0 --password ./chaindata/node$i/pass.txt --syncmode=full --gcmode=archive console" Enter
+        tmux send-keys -t 0 "./node_src/build/bin/geth --datadir ./chaindata/node$i --networkid $CHAINID --bootnodes $BOOTNODE --mine --port 326$j --nat extip:$IP --miner.gaslimit=10000000000000 --unlock 0 --password ./chaindata/node$i/pass.txt --syncmode=full --gcmode=archive ${LEASE_FILE:+--congress.lease.file $LEASE_FILE} console" Enter
     fi
 
     ((i += 1))
@@ -19,6 +19,7 @@ package backends
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"math/big"
@@ -31,6 +32,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus/congress"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/bloombits"
@@ -71,6 +73,7 @@ type SimulatedBackend struct {
 	events *filters.EventSystem // Event system for filtering log events live
 
 	config *params.ChainConfig
+	maker  *congress.ChainMaker // Sealer of the blocks of congress chains, nil on ethash ones
 }
 
 // NewSimulatedBackendWithDatabase creates a new binding backend based on the given database
@@ -98,6 +101,33 @@ func NewSimulatedBackend(alloc core.GenesisAlloc, gasLimit uint64) *SimulatedBac
 	return NewSimulatedBackendWithDatabase(rawdb.NewMemoryDatabase(), alloc, gasLimit)
 }
 
+// NewCongressSimulatedBackend creates a new binding backend simulating a congress
+// chain from the given genesis, e.g. assembled with congress.GenesisSpec. The
+// blocks are sealed in turn by the validators whose keys are given, and the system
+// contracts are set up like on the network.
+func NewCongressSimulatedBackend(genesis *core.Genesis, keys ...*ecdsa.PrivateKey) (*SimulatedBackend, error) {
+	database := rawdb.NewMemoryDatabase()
+	maker, err := congress.NewChainMaker(database, genesis, keys...)
+	if err != nil {
+		return nil, err
+	}
+	blockchain := maker.Chain()
+
+	backend := &SimulatedBackend{
+		database:   database,
+		blockchain: blockchain,
+		config:     genesis.Config,
+		maker:      maker,
+		events:     filters.NewEventSystem(&filterBackend{database, blockchain}, false),
+	}
+	// The first pending block fails to seal if the keys miss the validator in turn
+	if err := backend.resetPending(blockchain.CurrentBlock()); err != nil {
+		blockchain.Stop()
+		return nil, err
+	}
+	return backend, nil
+}
+
 // Close terminates the underlying blockchain's update loop.
 func (b *SimulatedBackend) Close() error {
 	b.blockchain.Stop()
@@ -127,10 +157,45 @@ func (b *SimulatedBackend) Rollback() {
 }
 
 func (b *SimulatedBackend) rollback(parent *types.Block) {
-	blocks, _ := core.GenerateChain(b.config, parent, ethash.NewFaker(), b.database, 1, func(int, *core.BlockGen) {})
+	if err := b.resetPending(parent); err != nil {
+		panic(err) // This cannot happen unless the simulator is wrong, fail in that case
+	}
+}
 
-	b.pendingBlock = blocks[0]
+// resetPending starts a fresh pending block on top of the parent.
+func (b *SimulatedBackend) resetPending(parent *types.Block) error {
+	block, err := b.generateBlock(parent, nil, 0)
+	if err != nil {
+		return err
+	}
+	b.pendingBlock = block
 	b.pendingState, _ = state.New(b.pendingBlock.Root(), b.blockchain.StateCache(), nil)
+	return nil
+}
+
+// generateBlock creates a block on top of the parent with the transactions, its
+// timestamp shifted by the given number of seconds. Congress blocks are sealed
+// by the validator in turn.
+func (b *SimulatedBackend) generateBlock(parent *types.Block, txs []*types.Transaction, offset int64) (*types.Block, error) {
+	if b.maker != nil {
+		if offset < 0 {
+			return nil, errors.New("negative time adjustment")
+		}
+		validator, err := b.maker.InTurn(parent.Header())
+		if err != nil {
+			return nil, err
+		}
+		return b.maker.Generate(parent, validator, uint64(offset), txs)
+	}
+	blocks, _ := core.GenerateChain(b.config, parent, ethash.NewFaker(), b.database, 1, func(number int, block *core.BlockGen) {
+		if offset != 0 {
+			block.OffsetTime(offset)
+		}
+		for _, tx := range txs {
+			block.AddTxWithChain(b.blockchain, tx)
+		}
+	})
+	return blocks[0], nil
 }
 
 // Fork creates a side-chain that can be used to simulate reorgs.
@@ -661,15 +726,14 @@ func (b *SimulatedBackend) SendTransaction(ctx context.Context, tx *types.Transa
 		panic(fmt.Errorf("invalid transaction nonce: got %d, want %d", tx.Nonce(), nonce))
 	}
 	// Include tx in chain
-	blocks, _ := core.GenerateChain(b.config, block, ethash.NewFaker(), b.database, 1, func(number int, block *core.BlockGen) {
-		for _, tx := range b.pendingBlock.Transactions() {
-			block.AddTxWithChain(b.blockchain, tx)
-		}
-		block.AddTxWithChain(b.blockchain, tx)
-	})
+	txs := append(append(types.Transactions{}, b.pendingBlock.Transactions()...), tx)
+	pending, err := b.generateBlock(block, txs, 0)
+	if err != nil {
+		return err
+	}
 	stateDB, _ := b.blockchain.State()
 
-	b.pendingBlock = blocks[0]
+	b.pendingBlock = pending
 	b.pendingState, _ = state.New(b.pendingBlock.Root(), stateDB.Database(), nil)
 	return nil
 }
@@ -779,12 +843,13 @@ func (b *SimulatedBackend) AdjustTime(adjustment time.Duration) error {
 		return errors.New("Could not adjust time on non-empty block")
 	}
 
-	blocks, _ := core.GenerateChain(b.config, b.blockchain.CurrentBlock(), ethash.NewFaker(), b.database, 1, func(number int, block *core.BlockGen) {
-		block.OffsetTime(int64(adjustment.Seconds()))
-	})
+	block, err := b.generateBlock(b.blockchain.CurrentBlock(), nil, int64(adjustment.Seconds()))
+	if err != nil {
+		return err
+	}
 	stateDB, _ := b.blockchain.State()
 
-	b.pendingBlock = blocks[0]
+	b.pendingBlock = block
 	b.pendingState, _ = state.New(b.pendingBlock.Root(), stateDB.Database(), nil)
 
 	return nil
@@ -20,6 +20,7 @@ package backends
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
 	"errors"
 	"math/big"
 	"math/rand"
@@ -32,6 +33,8 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/congress"
+	"github.com/ethereum/go-ethereum/consensus/congress/systemcontract"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -1337,3 +1340,82 @@ func TestForkResendTx(t *testing.T) {
 		t.Errorf("TX included in wrong block: %d", h)
 	}
 }
+
+func TestCongressSimulatedBackend(t *testing.T) {
+	testAddr := crypto.PubkeyToAddress(testKey.PublicKey)
+	keys := make([]*ecdsa.PrivateKey, 2)
+	spec := &congress.GenesisSpec{
+		ChainID:       big.NewInt(1337),
+		Period:        3,
+		Epoch:         100,
+		GasLimit:      10000000,
+		Alloc:         map[common.Address]*big.Int{testAddr: big.NewInt(params.Ether)},
+		RedCoastBlock: big.NewInt(2),
+		SophonBlock:   big.NewInt(3),
+	}
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		spec.Validators = append(spec.Validators, crypto.PubkeyToAddress(keys[i].PublicKey))
+	}
+	genesis, err := spec.Genesis()
+	if err != nil {
+		t.Fatalf("failed to assemble genesis: %v", err)
+	}
+	// The validator keys are required to seal the first block
+	if _, err := NewCongressSimulatedBackend(genesis, keys[:0]...); err == nil {
+		t.Fatalf("backend created without validator keys")
+	}
+	sim, err := NewCongressSimulatedBackend(genesis, keys...)
+	if err != nil {
+		t.Fatalf("failed to create backend: %v", err)
+	}
+	defer sim.Close()
+
+	// The system contracts are set up by the first blocks
+	for i := 0; i < 3; i++ {
+		sim.Commit()
+	}
+	code, err := sim.CodeAt(context.Background(), systemcontract.AddressListContractAddr, nil)
+	if err != nil || len(code) == 0 {
+		t.Fatalf("system contracts not set up: %v", err)
+	}
+	// Contracts are deployed and called like on ethash chains
+	parsed, _ := abi.JSON(strings.NewReader(abiJSON))
+	auth, _ := bind.NewKeyedTransactorWithChainID(testKey, big.NewInt(1337))
+	addr, _, contract, err := bind.DeployContract(auth, parsed, common.FromHex(abiBin), sim)
+	if err != nil {
+		t.Fatalf("failed to deploy contract: %v", err)
+	}
+	sim.Commit()
+
+	if code, _ := sim.CodeAt(context.Background(), addr, nil); !bytes.Equal(code, common.FromHex(deployedCode)) {
+		t.Errorf("deployed code mismatch")
+	}
+	var res []interface{}
+	if err := contract.Call(nil, &res, "receive", []byte("X")); err != nil {
+		t.Fatalf("failed to call contract: %v", err)
+	}
+	if res[0] != "hello world" {
+		t.Errorf("call result mismatch: have %v, want hello world", res[0])
+	}
+	// The validators seal in turn, in ascending order
+	validators := spec.Validators
+	if bytes.Compare(validators[0][:], validators[1][:]) > 0 {
+		validators[0], validators[1] = validators[1], validators[0]
+	}
+	for n := uint64(1); n <= sim.blockchain.CurrentBlock().NumberU64(); n++ {
+		header := sim.blockchain.GetHeaderByNumber(n)
+		if want := validators[n%2]; header.Coinbase != want {
+			t.Errorf("block %d: sealer mismatch: have %x, want %x", n, header.Coinbase, want)
+		}
+	}
+	// The clock moves with the blocks
+	prevTime := sim.pendingBlock.Time()
+	if err := sim.AdjustTime(time.Minute); err != nil {
+		t.Fatalf("failed to adjust time: %v", err)
+	}
+	if have := sim.pendingBlock.Time() - prevTime; have != 60 {
+		t.Errorf("adjusted time mismatch: have %d, want 60", have)
+	}
+	sim.Commit()
+}
@@ -41,6 +41,7 @@ const (
 	MimetypeTypedData         = "data/typed"
 	MimetypeClique            = "application/x-clique-header"
 	MimetypeCongress          = "application/x-congress-header"
+	MimetypeCongressVote      = "application/x-congress-vote"
 	MimetypeTextPlain         = "text/plain"
 )
 
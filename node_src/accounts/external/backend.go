@@ -168,7 +168,7 @@ func (api *ExternalSigner) SignData(account accounts.Account, mimeType string, d
 		return nil, err
 	}
 	// If V is on 27/28-form, convert to 0/1 for Clique/Congress
-	if (mimeType == accounts.MimetypeClique || mimeType == accounts.MimetypeCongress) && (res[64] == 27 || res[64] == 28) {
+	if (mimeType == accounts.MimetypeClique || mimeType == accounts.MimetypeCongress || mimeType == accounts.MimetypeCongressVote) && (res[64] == 27 || res[64] == 28) {
 		res[64] -= 27 // Transform V from 27/28 to 0/1 for Clique/Congress use
 	}
 	return res, nil
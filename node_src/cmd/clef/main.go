@@ -40,6 +40,7 @@ import (
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/congress/slashprotect"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
@@ -628,6 +629,14 @@ func signer(c *cli.Context) error {
 	am := core.StartClefAccountManager(ksLoc, nousb, lightKdf, scpath)
 	apiImpl := core.NewSignerAPI(am, chainId, nousb, ui, db, advanced, pwStorage)
 
+	// Record the congress headers signed, to refuse signing two at the same height
+	headers, err := slashprotect.Open(filepath.Join(configDir, slashprotect.DirName))
+	if err != nil {
+		utils.Fatalf("Failed to open slashing-protection database: %v", err)
+	}
+	defer headers.Close()
+	apiImpl.SetSlashingProtection(headers)
+
 	// Establish the bidirectional communication, by creating a new UI backend and registering
 	// it with the UI.
 	ui.RegisterUIServer(core.NewUIServerAPI(apiImpl))
@@ -0,0 +1,88 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+// congress-signer-stub runs a remote signer holding its keys in memory, to test
+// congress validators sealing with --congress.remotesigner. Never use it with
+// valuable keys.
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/consensus/congress/signerapi"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+func main() {
+	var (
+		listenAddr = flag.String("addr", "127.0.0.1:9000", "listen address")
+		keyFiles   = flag.String("keys", "", "comma separated private key files to sign with")
+		certFile   = flag.String("tls.cert", "", "TLS server certificate (empty = plain HTTP)")
+		keyFile    = flag.String("tls.key", "", "TLS server private key")
+		clientCA   = flag.String("tls.clientca", "", "CA certificates to require and verify client certificates with")
+		verbosity  = flag.Int("verbosity", int(log.LvlInfo), "log verbosity (0-5)")
+
+		keys []*ecdsa.PrivateKey
+	)
+	flag.Parse()
+
+	glogger := log.NewGlogHandler(log.StreamHandler(os.Stderr, log.TerminalFormat(false)))
+	glogger.Verbosity(log.Lvl(*verbosity))
+	log.Root().SetHandler(glogger)
+
+	if *keyFiles == "" {
+		utils.Fatalf("Use -keys to specify the private keys")
+	}
+	for _, file := range strings.Split(*keyFiles, ",") {
+		key, err := crypto.LoadECDSA(strings.TrimSpace(file))
+		if err != nil {
+			utils.Fatalf("Failed to load key %s: %v", file, err)
+		}
+		keys = append(keys, key)
+		log.Info("Loaded signing key", "address", crypto.PubkeyToAddress(key.PublicKey))
+	}
+	server := &http.Server{Addr: *listenAddr, Handler: signerapi.NewStub(keys...)}
+
+	if *certFile == "" {
+		if *keyFile != "" || *clientCA != "" {
+			utils.Fatalf("Use -tls.cert to serve over TLS")
+		}
+		log.Info("Stub signer listening", "addr", *listenAddr)
+		utils.Fatalf("%v", server.ListenAndServe())
+	}
+	if *clientCA != "" {
+		pem, err := ioutil.ReadFile(*clientCA)
+		if err != nil {
+			utils.Fatalf("Failed to read client CA certificates: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			utils.Fatalf("No CA certificate in %s", *clientCA)
+		}
+		server.TLSConfig = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: pool}
+	}
+	log.Info("Stub signer listening with TLS", "addr", *listenAddr, "clientauth", *clientCA != "")
+	utils.Fatalf("%v", server.ListenAndServeTLS(*certFile, *keyFile))
+}
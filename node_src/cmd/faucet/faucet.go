@@ -45,12 +45,15 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/ethstats"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/les"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/node"
@@ -246,21 +249,35 @@ func newFaucet(genesis *core.Genesis, port int, enodes []*enode.Node, network ui
 		return nil, err
 	}
 
-	// Assemble the Ethereum light client protocol
+	// Assemble the Ethereum light client protocol, or a full node on congress
+	// chains which light clients can't follow
 	cfg := ethconfig.Defaults
 	cfg.SyncMode = downloader.LightSync
 	cfg.NetworkId = network
 	cfg.Genesis = genesis
 	utils.SetDNSDiscoveryDefaults(&cfg, genesis.ToBlock(nil).Hash())
 
-	lesBackend, err := les.New(stack, &cfg)
-	if err != nil {
-		return nil, fmt.Errorf("Failed to register the Ethereum service: %w", err)
+	var (
+		backend ethapi.Backend
+		engine  consensus.Engine
+	)
+	if genesis.Config.Congress != nil {
+		cfg.SyncMode = downloader.FullSync
+		ethBackend, err := eth.New(stack, &cfg)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to register the Ethereum service: %w", err)
+		}
+		backend, engine = ethBackend.APIBackend, ethBackend.Engine()
+	} else {
+		lesBackend, err := les.New(stack, &cfg)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to register the Ethereum service: %w", err)
+		}
+		backend, engine = lesBackend.ApiBackend, lesBackend.Engine()
 	}
-
 	// Assemble the ethstats monitoring and reporting service'
 	if stats != "" {
-		if err := ethstats.New(stack, lesBackend.ApiBackend, lesBackend.Engine(), stats); err != nil {
+		if err := ethstats.New(stack, backend, engine, stats); err != nil {
 			return nil, err
 		}
 	}
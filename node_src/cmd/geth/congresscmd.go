@@ -0,0 +1,299 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus/congress"
+	"github.com/ethereum/go-ethereum/core"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+var (
+	genesisChainIDFlag = cli.Uint64Flag{
+		Name:  "chainid",
+		Usage: "Chain ID of the network",
+	}
+	genesisValidatorsFlag = cli.StringFlag{
+		Name:  "validators",
+		Usage: "Comma separated validators sealing the first epoch",
+	}
+	genesisPeriodFlag = cli.Uint64Flag{
+		Name:  "period",
+		Usage: "Number of seconds between blocks",
+		Value: 3,
+	}
+	genesisEpochFlag = cli.Uint64Flag{
+		Name:  "epoch",
+		Usage: "Number of blocks between checkpoints",
+		Value: 100,
+	}
+	genesisGasLimitFlag = cli.Uint64Flag{
+		Name:  "gaslimit",
+		Usage: "Gas limit of the genesis block",
+		Value: 30000000,
+	}
+	genesisTimestampFlag = cli.Uint64Flag{
+		Name:  "timestamp",
+		Usage: "Timestamp of the genesis block (0 = now)",
+	}
+	genesisPrefundFlag = cli.StringFlag{
+		Name:  "prefund",
+		Usage: "Comma separated prefunded accounts as address=wei",
+	}
+	genesisAdminFlag = cli.StringFlag{
+		Name:  "admin",
+		Usage: "Admin of the system contracts set up by the RedCoast fork (default = built-in admin of the network)",
+	}
+	genesisOutFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "File to write the genesis to (default = stdout)",
+	}
+	genesisCancunFlag = cli.Int64Flag{
+		Name:  "fork.cancun",
+		Usage: "Cancun fork block (negative = no fork)",
+		Value: -1,
+	}
+	genesisRedCoastFlag = cli.Int64Flag{
+		Name:  "fork.redcoast",
+		Usage: "RedCoast fork block, 2 or above (negative = no fork)",
+		Value: -1,
+	}
+	genesisSophonFlag = cli.Int64Flag{
+		Name:  "fork.sophon",
+		Usage: "Sophon fork block, after the RedCoast one (negative = no fork)",
+		Value: -1,
+	}
+	genesisSlashingFlag = cli.Int64Flag{
+		Name:  "fork.slashing",
+		Usage: "Double-sign slashing fork block (negative = no fork)",
+		Value: -1,
+	}
+	genesisFeeShareFlag = cli.Int64Flag{
+		Name:  "fork.feeshare",
+		Usage: "Fee share fork block (negative = no fork)",
+		Value: -1,
+	}
+	genesisCallFeeShareFlag = cli.Int64Flag{
+		Name:  "fork.callfeeshare",
+		Usage: "Call fee share fork block (negative = no fork)",
+		Value: -1,
+	}
+	genesisValidatorParamsFlag = cli.Int64Flag{
+		Name:  "fork.validatorparams",
+		Usage: "Validator cap and epoch overrides fork block (negative = no fork)",
+		Value: -1,
+	}
+	genesisMaxValidatorsFlag = cli.Uint64Flag{
+		Name:  "maxvalidators",
+		Usage: "Validator cap from the validator params fork (0 = unchanged)",
+	}
+	genesisEpochOverrideFlag = cli.Uint64Flag{
+		Name:  "epochoverride",
+		Usage: "Epoch length from the validator params fork (0 = unchanged)",
+	}
+	genesisRecentsFlag = cli.Int64Flag{
+		Name:  "fork.recents",
+		Usage: "Recents window fork block (negative = no fork)",
+		Value: -1,
+	}
+	genesisConsensusKeyFlag = cli.Int64Flag{
+		Name:  "fork.consensuskey",
+		Usage: "Consensus keys fork block, an epoch block upgrading the validators contract (negative = no fork)",
+		Value: -1,
+	}
+	genesisUpgradesFlag = cli.StringFlag{
+		Name:  "upgrades",
+		Usage: "JSON file listing the system contract upgrades to schedule",
+	}
+
+	congressCommand = cli.Command{
+		Name:      "congress",
+		Usage:     "Set up congress chains",
+		ArgsUsage: "",
+		Category:  "BLOCKCHAIN COMMANDS",
+		Subcommands: []cli.Command{
+			{
+				Name:      "genesis",
+				Usage:     "Generate or validate the genesis of a congress chain",
+				ArgsUsage: "[<genesisPath>]",
+				Action:    utils.MigrateFlags(congressGenesis),
+				Flags: []cli.Flag{
+					genesisChainIDFlag,
+					genesisValidatorsFlag,
+					genesisPeriodFlag,
+					genesisEpochFlag,
+					genesisGasLimitFlag,
+					genesisTimestampFlag,
+					genesisPrefundFlag,
+					genesisAdminFlag,
+					genesisCancunFlag,
+					genesisRedCoastFlag,
+					genesisSophonFlag,
+					genesisSlashingFlag,
+					genesisFeeShareFlag,
+					genesisCallFeeShareFlag,
+					genesisValidatorParamsFlag,
+					genesisMaxValidatorsFlag,
+					genesisEpochOverrideFlag,
+					genesisRecentsFlag,
+					genesisConsensusKeyFlag,
+					genesisUpgradesFlag,
+					genesisOutFlag,
+				},
+				Description: `
+geth congress genesis --chainid <id> --validators <addresses> [--prefund <accounts>] [--admin <address>] [--fork.<name> <block>]
+writes the genesis of a new congress chain, the Ethereum forks up to London
+being active from the genesis block. The validators are listed in its
+extra-data and the validators, punish and proposal system contracts are
+allocated at 0xf000 to 0xf002. The RedCoast fork must be at block 1 or
+above and the Sophon fork at or after it. The consensus key fork requires an
+upgrade of the validators contract keeping the keys, listed with --upgrades.
+
+geth congress genesis <genesisPath>
+validates an existing genesis file instead, and reports every problem found.`,
+			},
+		},
+	}
+)
+
+// congressGenesis generates a congress genesis from the command line flags, or
+// validates the genesis file given as argument.
+func congressGenesis(ctx *cli.Context) error {
+	if ctx.NArg() > 1 {
+		utils.Fatalf("This command takes at most one argument.")
+	}
+	if ctx.NArg() == 1 {
+		return verifyCongressGenesis(ctx.Args().First())
+	}
+	if !ctx.IsSet(genesisChainIDFlag.Name) || !ctx.IsSet(genesisValidatorsFlag.Name) {
+		utils.Fatalf("Use --%s and --%s to specify the chain", genesisChainIDFlag.Name, genesisValidatorsFlag.Name)
+	}
+	spec := &congress.GenesisSpec{
+		ChainID:           new(big.Int).SetUint64(ctx.Uint64(genesisChainIDFlag.Name)),
+		Period:            ctx.Uint64(genesisPeriodFlag.Name),
+		Epoch:             ctx.Uint64(genesisEpochFlag.Name),
+		GasLimit:          ctx.Uint64(genesisGasLimitFlag.Name),
+		Timestamp:         ctx.Uint64(genesisTimestampFlag.Name),
+		Alloc:             make(map[common.Address]*big.Int),
+		CancunBlock:       forkBlock(ctx, genesisCancunFlag),
+		RedCoastBlock:     forkBlock(ctx, genesisRedCoastFlag),
+		SophonBlock:       forkBlock(ctx, genesisSophonFlag),
+		SlashingBlock:     forkBlock(ctx, genesisSlashingFlag),
+		FeeShareBlock:     forkBlock(ctx, genesisFeeShareFlag),
+		CallFeeShareBlock: forkBlock(ctx, genesisCallFeeShareFlag),
+
+		ValidatorParamsBlock: forkBlock(ctx, genesisValidatorParamsFlag),
+		MaxValidators:        ctx.Uint64(genesisMaxValidatorsFlag.Name),
+		EpochOverride:        ctx.Uint64(genesisEpochOverrideFlag.Name),
+		RecentsBlock:         forkBlock(ctx, genesisRecentsFlag),
+		ConsensusKeyBlock:    forkBlock(ctx, genesisConsensusKeyFlag),
+	}
+	if ctx.IsSet(genesisAdminFlag.Name) {
+		admin := ctx.String(genesisAdminFlag.Name)
+		if !common.IsHexAddress(admin) {
+			utils.Fatalf("Invalid admin address %q", admin)
+		}
+		addr := common.HexToAddress(admin)
+		spec.Admin = &addr
+	}
+	if path := ctx.String(genesisUpgradesFlag.Name); path != "" {
+		blob, err := ioutil.ReadFile(path)
+		if err != nil {
+			utils.Fatalf("Failed to read upgrades file: %v", err)
+		}
+		if err := json.Unmarshal(blob, &spec.Upgrades); err != nil {
+			utils.Fatalf("Invalid upgrades file: %v", err)
+		}
+	}
+	if spec.Timestamp == 0 {
+		spec.Timestamp = uint64(time.Now().Unix())
+	}
+	for _, validator := range strings.Split(ctx.String(genesisValidatorsFlag.Name), ",") {
+		if validator = strings.TrimSpace(validator); !common.IsHexAddress(validator) {
+			utils.Fatalf("Invalid validator address %q", validator)
+		}
+		spec.Validators = append(spec.Validators, common.HexToAddress(validator))
+	}
+	if prefund := ctx.String(genesisPrefundFlag.Name); prefund != "" {
+		for _, account := range strings.Split(prefund, ",") {
+			parts := strings.Split(strings.TrimSpace(account), "=")
+			if len(parts) != 2 || !common.IsHexAddress(parts[0]) {
+				utils.Fatalf("Invalid prefunded account %q, want address=wei", account)
+			}
+			balance, ok := math.ParseBig256(parts[1])
+			if !ok {
+				utils.Fatalf("Invalid balance of prefunded account %q", account)
+			}
+			spec.Alloc[common.HexToAddress(parts[0])] = balance
+		}
+	}
+	genesis, err := spec.Genesis()
+	if err != nil {
+		utils.Fatalf("Invalid genesis: %v", err)
+	}
+	out, err := json.MarshalIndent(genesis, "", "  ")
+	if err != nil {
+		utils.Fatalf("Failed to encode genesis: %v", err)
+	}
+	if path := ctx.String(genesisOutFlag.Name); path != "" {
+		return ioutil.WriteFile(path, out, 0644)
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+// forkBlock returns the fork block set by the flag, nil if negative.
+func forkBlock(ctx *cli.Context, flag cli.Int64Flag) *big.Int {
+	if number := ctx.Int64(flag.Name); number >= 0 {
+		return big.NewInt(number)
+	}
+	return nil
+}
+
+// verifyCongressGenesis validates a genesis file, printing every problem found.
+func verifyCongressGenesis(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		utils.Fatalf("Failed to read genesis file: %v", err)
+	}
+	defer file.Close()
+
+	genesis := new(core.Genesis)
+	if err := json.NewDecoder(file).Decode(genesis); err != nil {
+		utils.Fatalf("Invalid genesis file: %v", err)
+	}
+	errs := congress.VerifyGenesis(genesis)
+	for _, err := range errs {
+		fmt.Println("Problem:", err)
+	}
+	if len(errs) > 0 {
+		utils.Fatalf("Genesis %s has %d problem(s)", path, len(errs))
+	}
+	fmt.Printf("Genesis %s of chain %v is valid\n", path, genesis.Config.ChainID)
+	return nil
+}
@@ -80,6 +80,17 @@ var (
 		utils.EthashDatasetsInMemoryFlag,
 		utils.EthashDatasetsOnDiskFlag,
 		utils.EthashDatasetsLockMmapFlag,
+		utils.CongressRewardIndexFlag,
+		utils.CongressLeaseFileFlag,
+		utils.CongressLeaseTTLFlag,
+		utils.CongressLeaseHostFlag,
+		utils.CongressConsensusKeyFlag,
+		utils.CongressRemoteSignerFlag,
+		utils.CongressRemoteSignerTimeoutFlag,
+		utils.CongressRemoteSignerCertFlag,
+		utils.CongressRemoteSignerKeyFlag,
+		utils.CongressRemoteSignerCAFlag,
+		utils.CongressCheckpointFlag,
 		utils.TxPoolLocalsFlag,
 		utils.TxPoolNoLocalsFlag,
 		utils.TxPoolJournalFlag,
@@ -140,6 +151,7 @@ var (
 		utils.MainnetFlag,
 		utils.DeveloperFlag,
 		utils.DeveloperPeriodFlag,
+		utils.DeveloperCongressFlag,
 		utils.TestnetFlag,
 		utils.VMEnableDebugFlag,
 		utils.NetworkIdFlag,
@@ -235,6 +247,12 @@ func init() {
 		utils.ShowDeprecated,
 		// See snapshot.go
 		snapshotCommand,
+		// See syscontractcmd.go
+		syscontractCommand,
+		// See validatorcmd.go
+		validatorCommand,
+		// See congresscmd.go
+		congressCommand,
 	}
 	sort.Sort(cli.CommandsByName(app.Commands))
 
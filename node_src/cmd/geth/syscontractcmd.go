@@ -0,0 +1,291 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/congress/systemcontract"
+	"github.com/ethereum/go-ethereum/consensus/congress/vmcaller"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/node"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+var (
+	syscontractBlockFlag = cli.Int64Flag{
+		Name:  "block",
+		Usage: "Block number to inspect, the head block if negative",
+		Value: -1,
+	}
+	syscontractFlags = []cli.Flag{
+		utils.DataDirFlag,
+		utils.AncientFlag,
+		utils.CacheFlag,
+		utils.SyncModeFlag,
+		utils.MainnetFlag,
+		utils.TestnetFlag,
+		syscontractBlockFlag,
+	}
+
+	syscontractCommand = cli.Command{
+		Name:      "syscontract",
+		Usage:     "Inspect and verify the congress system contracts",
+		ArgsUsage: "",
+		Category:  "BLOCKCHAIN COMMANDS",
+		Subcommands: []cli.Command{
+			{
+				Name:   "list",
+				Usage:  "List the system contracts",
+				Action: utils.MigrateFlags(listSysContracts),
+				Flags:  syscontractFlags,
+				Description: `
+geth syscontract list [--block <number>]
+prints the address, ABI name and code hash of every system contract, along
+with the fork that installed its code.`,
+			},
+			{
+				Name:   "verify",
+				Usage:  "Verify the system contract codes against the ones embedded in the client",
+				Action: utils.MigrateFlags(verifySysContracts),
+				Flags:  syscontractFlags,
+				Description: `
+geth syscontract verify [--block <number>]
+compares the code of every system contract at the given block with the code
+the client expects at that height, including the contracts set up in the
+genesis block.`,
+			},
+			{
+				Name:      "call",
+				Usage:     "Call a read-only method of a system contract",
+				ArgsUsage: "<name> <method> [args...]",
+				Action:    utils.MigrateFlags(callSysContract),
+				Flags:     syscontractFlags,
+				Description: `
+geth syscontract call [--block <number>] <name> <method> [args...]
+runs a read-only method of the system contract with the given ABI name on
+top of the local database. Numbers are given in decimal or 0x prefixed hex,
+addresses and bytes as 0x prefixed hex.`,
+			},
+		},
+	}
+)
+
+// errCodeMismatch is returned if a system contract doesn't have the expected code.
+var errCodeMismatch = errors.New("system contract code mismatch")
+
+// sysContractState opens the chain and returns the state at the block selected
+// on the command line, along with the chain for running calls. The node holds
+// the data directory lock, so it must be closed once done.
+func sysContractState(ctx *cli.Context) (*node.Node, *core.BlockChain, *types.Header, *state.StateDB) {
+	stack, _ := makeConfigNode(ctx)
+	chain, _ := utils.MakeChain(ctx, stack)
+
+	header := chain.CurrentHeader()
+	if number := ctx.Int64(syscontractBlockFlag.Name); number >= 0 {
+		header = chain.GetHeaderByNumber(uint64(number))
+		if header == nil {
+			utils.Fatalf("Block %d not found", number)
+		}
+	}
+	statedb, err := chain.StateAt(header.Root)
+	if err != nil {
+		utils.Fatalf("State of block %d not available: %v", header.Number, err)
+	}
+	return stack, chain, header, statedb
+}
+
+func listSysContracts(ctx *cli.Context) error {
+	stack, chain, header, statedb := sysContractState(ctx)
+	defer stack.Close()
+	defer chain.Stop()
+
+	fmt.Printf("Block %d [%x]\n", header.Number, header.Hash())
+	for _, contract := range systemcontract.SysContracts {
+		fork := "-"
+		if expected := systemcontract.ExpectedCode(chain.Config(), contract.Address, header.Number); expected != nil {
+			fork = fmt.Sprintf("%s (#%d)", expected.Fork, expected.Block)
+		}
+		fmt.Printf("%s  %-14s  %x  %s\n", contract.Address.Hex(), contract.Name, statedb.GetCodeHash(contract.Address), fork)
+	}
+	return nil
+}
+
+func verifySysContracts(ctx *cli.Context) error {
+	stack, chain, header, statedb := sysContractState(ctx)
+	defer stack.Close()
+	defer chain.Stop()
+
+	var failed bool
+	for _, contract := range systemcontract.SysContracts {
+		var (
+			code     = statedb.GetCode(contract.Address)
+			expected = systemcontract.ExpectedCode(chain.Config(), contract.Address, header.Number)
+			ok       bool
+		)
+		switch {
+		case expected == nil:
+			ok = len(code) == 0
+		default:
+			ok = crypto.Keccak256Hash(code) == expected.Hash
+		}
+		status := "OK"
+		if !ok {
+			status, failed = "MISMATCH", true
+		}
+		fmt.Printf("%-8s  %s  %-14s  %x\n", status, contract.Address.Hex(), contract.Name, crypto.Keccak256Hash(code))
+	}
+	if failed {
+		return errCodeMismatch
+	}
+	return nil
+}
+
+func callSysContract(ctx *cli.Context) error {
+	if ctx.NArg() < 2 {
+		utils.Fatalf("This command requires a contract name and a method.")
+	}
+	name, methodName := ctx.Args().Get(0), ctx.Args().Get(1)
+	contractABI, ok := systemcontract.GetInteractiveABI()[name]
+	if !ok {
+		utils.Fatalf("Unknown system contract %q", name)
+	}
+	var (
+		to    common.Address
+		found bool
+	)
+	for _, contract := range systemcontract.SysContracts {
+		if contract.Name == name {
+			to, found = contract.Address, true
+			break
+		}
+	}
+	if !found {
+		utils.Fatalf("No address known for system contract %q", name)
+	}
+	method, ok := contractABI.Methods[methodName]
+	if !ok {
+		utils.Fatalf("Unknown method %q of system contract %q", methodName, name)
+	}
+	if !method.IsConstant() {
+		utils.Fatalf("Method %q is not read-only", methodName)
+	}
+	args := ctx.Args()[2:]
+	if len(args) != len(method.Inputs) {
+		utils.Fatalf("Method %q requires %d arguments, have %d", methodName, len(method.Inputs), len(args))
+	}
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		value, err := parseABIArg(method.Inputs[i].Type, arg)
+		if err != nil {
+			utils.Fatalf("Invalid argument %d: %v", i, err)
+		}
+		values[i] = value
+	}
+	data, err := contractABI.Pack(methodName, values...)
+	if err != nil {
+		utils.Fatalf("Failed to pack call: %v", err)
+	}
+	stack, chain, header, statedb := sysContractState(ctx)
+	defer stack.Close()
+	defer chain.Stop()
+
+	msg := vmcaller.NewLegacyMessage(header.Coinbase, &to, 0, new(big.Int), math.MaxUint64, new(big.Int), data, false)
+	ret, err := vmcaller.ExecuteMsg(msg, statedb, header, chain, chain.Config())
+	if err != nil {
+		return err
+	}
+	results, err := contractABI.Unpack(methodName, ret)
+	if err != nil {
+		return err
+	}
+	for i, result := range results {
+		fmt.Printf("%s: %s\n", method.Outputs[i].Type, formatABIValue(result))
+	}
+	return nil
+}
+
+// parseABIArg converts a command line argument into the Go value the ABI packer
+// expects for the given type.
+func parseABIArg(typ abi.Type, arg string) (interface{}, error) {
+	switch typ.T {
+	case abi.IntTy, abi.UintTy:
+		n, ok := new(big.Int).SetString(arg, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid number %q", arg)
+		}
+		if typ.GetType() == reflect.TypeOf(n) {
+			return n, nil
+		}
+		if typ.T == abi.UintTy {
+			if !n.IsUint64() {
+				return nil, fmt.Errorf("number %q out of range", arg)
+			}
+			return reflect.ValueOf(n.Uint64()).Convert(typ.GetType()).Interface(), nil
+		}
+		if !n.IsInt64() {
+			return nil, fmt.Errorf("number %q out of range", arg)
+		}
+		return reflect.ValueOf(n.Int64()).Convert(typ.GetType()).Interface(), nil
+	case abi.BoolTy:
+		return strconv.ParseBool(arg)
+	case abi.StringTy:
+		return arg, nil
+	case abi.AddressTy:
+		if !common.IsHexAddress(arg) {
+			return nil, fmt.Errorf("invalid address %q", arg)
+		}
+		return common.HexToAddress(arg), nil
+	case abi.BytesTy:
+		return hexutil.Decode(arg)
+	case abi.FixedBytesTy:
+		blob, err := hexutil.Decode(arg)
+		if err != nil {
+			return nil, err
+		}
+		if len(blob) != typ.Size {
+			return nil, fmt.Errorf("invalid length %d for %s", len(blob), typ)
+		}
+		value := reflect.New(typ.GetType()).Elem()
+		reflect.Copy(value, reflect.ValueOf(blob))
+		return value.Interface(), nil
+	}
+	return nil, fmt.Errorf("unsupported argument type %s", typ)
+}
+
+// formatABIValue formats a value returned by a system contract for printing.
+func formatABIValue(value interface{}) string {
+	switch v := value.(type) {
+	case []byte:
+		return hexutil.Encode(v)
+	case [32]byte:
+		return hexutil.Encode(v[:])
+	}
+	return fmt.Sprint(value)
+}
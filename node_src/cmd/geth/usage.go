@@ -73,6 +73,7 @@ var AppHelpFlagGroups = []flags.FlagGroup{
 			utils.DeveloperFlag,
 			utils.DeveloperPeriodFlag,
 			utils.DeveloperGasLimitFlag,
+			utils.DeveloperCongressFlag,
 		},
 	},
 	{
@@ -88,6 +89,22 @@ var AppHelpFlagGroups = []flags.FlagGroup{
 			utils.EthashDatasetsLockMmapFlag,
 		},
 	},
+	{
+		Name: "CONGRESS",
+		Flags: []cli.Flag{
+			utils.CongressRewardIndexFlag,
+			utils.CongressLeaseFileFlag,
+			utils.CongressLeaseTTLFlag,
+			utils.CongressLeaseHostFlag,
+			utils.CongressConsensusKeyFlag,
+			utils.CongressRemoteSignerFlag,
+			utils.CongressRemoteSignerTimeoutFlag,
+			utils.CongressRemoteSignerCertFlag,
+			utils.CongressRemoteSignerKeyFlag,
+			utils.CongressRemoteSignerCAFlag,
+			utils.CongressCheckpointFlag,
+		},
+	},
 	{
 		Name: "TRANSACTION POOL",
 		Flags: []cli.Flag{
@@ -0,0 +1,161 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/congress/slashprotect"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/node"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+var (
+	slashingProtectionFlags = []cli.Flag{
+		utils.DataDirFlag,
+		utils.AncientFlag,
+		utils.MainnetFlag,
+		utils.TestnetFlag,
+	}
+
+	validatorCommand = cli.Command{
+		Name:      "validator",
+		Usage:     "Manage the local congress validator",
+		ArgsUsage: "",
+		Category:  "ACCOUNT COMMANDS",
+		Subcommands: []cli.Command{
+			{
+				Name:  "slashing-protection",
+				Usage: "Manage the slashing-protection database",
+				Description: `
+The slashing-protection database records every header signed by the local
+validator. It's kept in the instance directory apart from the chain database,
+so that it survives a resync, and opened once the node starts sealing. The
+node refuses to sign a header at a height where it already signed a different
+one. When moving a validator to another machine, export the database of the
+old node once it's stopped and import it on the new one before it starts
+sealing.`,
+				Subcommands: []cli.Command{
+					{
+						Name:      "export",
+						Usage:     "Export the signing history in the interchange format",
+						ArgsUsage: "<file>",
+						Action:    utils.MigrateFlags(exportSlashingProtection),
+						Flags:     slashingProtectionFlags,
+						Description: `
+geth validator slashing-protection export <file>
+writes the headers signed by the local validators to the given JSON file.`,
+					},
+					{
+						Name:      "import",
+						Usage:     "Import a signing history in the interchange format",
+						ArgsUsage: "<file>",
+						Action:    utils.MigrateFlags(importSlashingProtection),
+						Flags:     slashingProtectionFlags,
+						Description: `
+geth validator slashing-protection import <file>
+merges the headers listed in the given JSON file into the local database. The
+file must have been exported for the same chain, and is rejected as a whole if
+it conflicts with the local signing history.`,
+					},
+				},
+			},
+		},
+	}
+)
+
+// openSlashingProtection opens the slashing-protection database of the node,
+// along with the genesis hash of its chain. The node holds the data directory
+// lock, so it must be closed once done to let the node start again.
+func openSlashingProtection(ctx *cli.Context) (*node.Node, *slashprotect.DB, common.Hash) {
+	stack, _ := makeConfigNode(ctx)
+
+	genesis := readGenesisHash(ctx, stack)
+	db, err := slashprotect.Open(stack.ResolvePath(slashprotect.DirName))
+	if err != nil {
+		utils.Fatalf("Failed to open slashing-protection database: %v", err)
+	}
+	return stack, db, genesis
+}
+
+// readGenesisHash returns the hash of the genesis block of the local chain.
+func readGenesisHash(ctx *cli.Context, stack *node.Node) common.Hash {
+	chaindb := utils.MakeChainDatabase(ctx, stack, true)
+	defer chaindb.Close()
+
+	genesis := rawdb.ReadCanonicalHash(chaindb, 0)
+	if genesis == (common.Hash{}) {
+		utils.Fatalf("Chain database not initialized")
+	}
+	return genesis
+}
+
+func exportSlashingProtection(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	stack, db, genesis := openSlashingProtection(ctx)
+	defer stack.Close()
+	defer db.Close()
+
+	ic, err := db.Export(genesis)
+	if err != nil {
+		utils.Fatalf("Failed to export slashing-protection database: %v", err)
+	}
+	blob, err := json.MarshalIndent(ic, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(ctx.Args().First(), blob, 0600); err != nil {
+		utils.Fatalf("Failed to write interchange file: %v", err)
+	}
+	var records int
+	for _, history := range ic.Data {
+		records += len(history.SignedBlocks)
+	}
+	fmt.Printf("Exported %d signed headers of %d validators\n", records, len(ic.Data))
+	return nil
+}
+
+func importSlashingProtection(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	blob, err := ioutil.ReadFile(ctx.Args().First())
+	if err != nil {
+		utils.Fatalf("Failed to read interchange file: %v", err)
+	}
+	ic := new(slashprotect.Interchange)
+	if err := json.Unmarshal(blob, ic); err != nil {
+		utils.Fatalf("Invalid interchange file: %v", err)
+	}
+	stack, db, genesis := openSlashingProtection(ctx)
+	defer stack.Close()
+	defer db.Close()
+
+	records, err := db.Import(ic, genesis)
+	if err != nil {
+		utils.Fatalf("Failed to import interchange file: %v", err)
+	}
+	fmt.Printf("Imported %d signed headers\n", records)
+	return nil
+}
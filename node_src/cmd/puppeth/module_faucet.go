@@ -33,7 +33,7 @@ import (
 // faucetDockerfile is the Dockerfile required to build a faucet container to
 // grant crypto tokens based on GitHub authentications.
 var faucetDockerfile = `
-FROM ethereum/client-go:alltools-latest
+FROM {{.Image}}
 
 ADD genesis.json /genesis.json
 ADD account.json /account.json
@@ -92,8 +92,13 @@ func deployFaucet(client *sshClient, network string, bootnodes []string, config
 	workdir := fmt.Sprintf("%d", rand.Int63())
 	files := make(map[string][]byte)
 
+	image := config.node.image
+	if image == "" {
+		image = "ethereum/client-go:alltools-latest"
+	}
 	dockerfile := new(bytes.Buffer)
 	template.Must(template.New("").Parse(faucetDockerfile)).Execute(dockerfile, map[string]interface{}{
+		"Image":         image,
 		"NetworkID":     config.node.network,
 		"Bootnodes":     strings.Join(bootnodes, ","),
 		"Ethstats":      config.node.ethstats,
@@ -32,7 +32,7 @@ import (
 
 // nodeDockerfile is the Dockerfile required to run an Ethereum node.
 var nodeDockerfile = `
-FROM ethereum/client-go:latest
+FROM {{.Image}}
 
 ADD genesis.json /genesis.json
 {{if .Unlock}}
@@ -96,8 +96,13 @@ func deployNode(client *sshClient, network string, bootnodes []string, config *n
 	if config.peersLight > 0 {
 		lightFlag = fmt.Sprintf("--light.maxpeers=%d --light.serve=50", config.peersLight)
 	}
+	image := config.image
+	if image == "" {
+		image = "ethereum/client-go:latest"
+	}
 	dockerfile := new(bytes.Buffer)
 	template.Must(template.New("").Parse(nodeDockerfile)).Execute(dockerfile, map[string]interface{}{
+		"Image":     image,
 		"NetworkID": config.network,
 		"Port":      config.port,
 		"IP":        client.address,
@@ -152,6 +157,7 @@ func deployNode(client *sshClient, network string, bootnodes []string, config *n
 // nodeInfos is returned from a boot or seal node status check to allow reporting
 // various configuration parameters.
 type nodeInfos struct {
+	image      string // Docker image of the client (empty = upstream image)
 	genesis    []byte
 	network    int64
 	datadir    string
@@ -191,7 +197,7 @@ func (info *nodeInfos) Report() map[string]string {
 			report["Miner account"] = info.etherbase
 		}
 		if info.keyJSON != "" {
-			// Clique proof-of-authority signer
+			// Clique or congress proof-of-authority signer
 			var key struct {
 				Address string `json:"address"`
 			}
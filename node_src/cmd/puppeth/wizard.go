@@ -47,6 +47,7 @@ type config struct {
 
 	Genesis *core.Genesis     `json:"genesis,omitempty"` // Genesis block to cache for node deploys
 	Servers map[string][]byte `json:"servers,omitempty"`
+	Image   string            `json:"image,omitempty"` // Docker image of the client to deploy congress networks with
 }
 
 // servers retrieves an alphabetically sorted list of servers.
@@ -50,6 +50,7 @@ func (w *wizard) deployFaucet() {
 
 	infos.node.genesis, _ = json.MarshalIndent(w.conf.Genesis, "", "  ")
 	infos.node.network = w.conf.Genesis.Config.ChainID.Int64()
+	infos.node.image = w.readImage()
 
 	// Figure out which port to listen on
 	fmt.Println()
@@ -30,6 +30,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/congress"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
@@ -54,11 +55,15 @@ func (w *wizard) makeGenesis() {
 			IstanbulBlock:       big.NewInt(0),
 		},
 	}
+	// Congress genesis are assembled from a spec of the chain
+	var spec *congress.GenesisSpec
+
 	// Figure out which consensus engine to choose
 	fmt.Println()
 	fmt.Println("Which consensus engine to use? (default = clique)")
 	fmt.Println(" 1. Ethash - proof-of-work")
 	fmt.Println(" 2. Clique - proof-of-authority")
+	fmt.Println(" 3. Congress - proof-of-staked-authority")
 
 	choice := w.read()
 	switch {
@@ -81,29 +86,57 @@ func (w *wizard) makeGenesis() {
 		// We also need the initial list of signers
 		fmt.Println()
 		fmt.Println("Which accounts are allowed to seal? (mandatory at least one)")
+		genesis.ExtraData = sealersExtraData(w.readSealers())
+
+	case choice == "3":
+		// In the case of congress, collect the chain parameters, the genesis is
+		// assembled with the system contracts once the funds are known
+		spec = &congress.GenesisSpec{
+			GasLimit:  genesis.GasLimit,
+			Timestamp: genesis.Timestamp,
+			Alloc:     make(map[common.Address]*big.Int),
+		}
+		fmt.Println()
+		fmt.Println("How many seconds should blocks take? (default = 3)")
+		spec.Period = uint64(w.readDefaultInt(3))
+
+		fmt.Println()
+		fmt.Println("How many blocks should an epoch last? (default = 100)")
+		spec.Epoch = uint64(w.readDefaultInt(100))
 
-		var signers []common.Address
+		// The system contract forks are applied from the block initializing
+		// the contracts, Sophon building on RedCoast
+		fmt.Println()
+		fmt.Println("Which block should RedCoast come into effect? (default = none, 1 or above)")
 		for {
-			if address := w.readAddress(); address != nil {
-				signers = append(signers, *address)
-				continue
-			}
-			if len(signers) > 0 {
+			spec.RedCoastBlock = w.readDefaultBigInt(nil)
+			if spec.RedCoastBlock == nil || spec.RedCoastBlock.Sign() > 0 {
 				break
 			}
+			log.Error("RedCoast must come into effect at block 1 or above")
 		}
-		// Sort the signers and embed into the extra-data section
-		for i := 0; i < len(signers); i++ {
-			for j := i + 1; j < len(signers); j++ {
-				if bytes.Compare(signers[i][:], signers[j][:]) > 0 {
-					signers[i], signers[j] = signers[j], signers[i]
+		if spec.RedCoastBlock != nil {
+			fmt.Println()
+			fmt.Printf("Which block should Sophon come into effect? (default = none, %v or above)\n", spec.RedCoastBlock)
+			for {
+				spec.SophonBlock = w.readDefaultBigInt(nil)
+				if spec.SophonBlock == nil || spec.SophonBlock.Cmp(spec.RedCoastBlock) >= 0 {
+					break
 				}
+				log.Error("Sophon must come into effect at or after RedCoast", "redcoast", spec.RedCoastBlock)
 			}
+			fmt.Println()
+			fmt.Println("Which account should administer the system contracts? (default = built-in admin)")
+			spec.Admin = w.readAddress()
+
+			fmt.Println()
+			fmt.Println("Should only verified developers be allowed to deploy contracts (y/n)? (default = no)")
+			spec.EnableDevVerification = w.readDefaultYesNo(false)
 		}
-		genesis.ExtraData = make([]byte, 32+len(signers)*common.AddressLength+65)
-		for i, signer := range signers {
-			copy(genesis.ExtraData[32+i*common.AddressLength:], signer[:])
-		}
+		// We also need the initial list of validators
+		fmt.Println()
+		fmt.Println("Which accounts are allowed to validate? (mandatory at least one)")
+		spec.Validators = w.readSealers()
 
 	default:
 		log.Crit("Invalid consensus engine choice", "choice", choice)
@@ -114,8 +147,11 @@ func (w *wizard) makeGenesis() {
 	for {
 		// Read the address of the account to fund
 		if address := w.readAddress(); address != nil {
-			genesis.Alloc[*address] = core.GenesisAccount{
-				Balance: new(big.Int).Lsh(big.NewInt(1), 256-7), // 2^256 / 128 (allow many pre-funds without balance overflows)
+			balance := new(big.Int).Lsh(big.NewInt(1), 256-7) // 2^256 / 128 (allow many pre-funds without balance overflows)
+			if spec != nil {
+				spec.Alloc[*address] = balance
+			} else {
+				genesis.Alloc[*address] = core.GenesisAccount{Balance: balance}
 			}
 			continue
 		}
@@ -126,7 +162,11 @@ func (w *wizard) makeGenesis() {
 	if w.readDefaultYesNo(true) {
 		// Add a batch of precompile balances to avoid them getting deleted
 		for i := int64(0); i < 256; i++ {
-			genesis.Alloc[common.BigToAddress(big.NewInt(i))] = core.GenesisAccount{Balance: big.NewInt(1)}
+			if spec != nil {
+				spec.Alloc[common.BigToAddress(big.NewInt(i))] = big.NewInt(1)
+			} else {
+				genesis.Alloc[common.BigToAddress(big.NewInt(i))] = core.GenesisAccount{Balance: big.NewInt(1)}
+			}
 		}
 	}
 	// Query the user for some custom extras
@@ -134,6 +174,17 @@ func (w *wizard) makeGenesis() {
 	fmt.Println("Specify your chain/network ID if you want an explicit one (default = random)")
 	genesis.Config.ChainID = new(big.Int).SetUint64(uint64(w.readDefaultInt(rand.Intn(65536))))
 
+	// Assemble the congress genesis, verifying the pre-funds left the system
+	// contracts intact
+	if spec != nil {
+		spec.ChainID = genesis.Config.ChainID
+
+		var err error
+		if genesis, err = spec.Genesis(); err != nil {
+			log.Error("Invalid congress genesis", "err", err)
+			return
+		}
+	}
 	// All done, store the genesis and flush to disk
 	log.Info("Configured new genesis block")
 
@@ -141,6 +192,37 @@ func (w *wizard) makeGenesis() {
 	w.conf.flush()
 }
 
+// readSealers reads the accounts allowed to seal, at least one.
+func (w *wizard) readSealers() []common.Address {
+	var sealers []common.Address
+	for {
+		if address := w.readAddress(); address != nil {
+			sealers = append(sealers, *address)
+			continue
+		}
+		if len(sealers) > 0 {
+			return sealers
+		}
+	}
+}
+
+// sealersExtraData sorts the sealers and embeds them into the extra-data section,
+// between the vanity and the seal.
+func sealersExtraData(sealers []common.Address) []byte {
+	for i := 0; i < len(sealers); i++ {
+		for j := i + 1; j < len(sealers); j++ {
+			if bytes.Compare(sealers[i][:], sealers[j][:]) > 0 {
+				sealers[i], sealers[j] = sealers[j], sealers[i]
+			}
+		}
+	}
+	extra := make([]byte, 32+len(sealers)*common.AddressLength+65)
+	for i, sealer := range sealers {
+		copy(extra[32+i*common.AddressLength:], sealer[:])
+	}
+	return extra
+}
+
 // importGenesis imports a Geth genesis spec into puppeth.
 func (w *wizard) importGenesis() {
 	// Request the genesis JSON spec URL from the user
@@ -243,6 +325,16 @@ func (w *wizard) manageGenesis() {
 		fmt.Printf("Which block should London come into effect? (default = %v)\n", w.conf.Genesis.Config.LondonBlock)
 		w.conf.Genesis.Config.LondonBlock = w.readDefaultBigInt(w.conf.Genesis.Config.LondonBlock)
 
+		if w.conf.Genesis.Config.Congress != nil {
+			fmt.Println()
+			fmt.Printf("Which block should RedCoast come into effect? (default = %v)\n", w.conf.Genesis.Config.RedCoastBlock)
+			w.conf.Genesis.Config.RedCoastBlock = w.readDefaultBigInt(w.conf.Genesis.Config.RedCoastBlock)
+
+			fmt.Println()
+			fmt.Printf("Which block should Sophon come into effect? (default = %v)\n", w.conf.Genesis.Config.SophonBlock)
+			w.conf.Genesis.Config.SophonBlock = w.readDefaultBigInt(w.conf.Genesis.Config.SophonBlock)
+		}
+
 		out, _ := json.MarshalIndent(w.conf.Genesis.Config, "", "  ")
 		fmt.Printf("Chain configuration updated:\n\n%s\n", out)
 
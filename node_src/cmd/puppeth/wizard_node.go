@@ -57,6 +57,7 @@ func (w *wizard) deployNode(boot bool) {
 
 	infos.genesis, _ = json.MarshalIndent(w.conf.Genesis, "", "  ")
 	infos.network = w.conf.Genesis.Config.ChainID.Int64()
+	infos.image = w.readImage()
 
 	// Figure out where the user wants to store the persistent data
 	fmt.Println()
@@ -87,10 +88,15 @@ func (w *wizard) deployNode(boot bool) {
 	fmt.Printf("How many peers to allow connecting? (default = %d)\n", infos.peersTotal)
 	infos.peersTotal = w.readDefaultInt(infos.peersTotal)
 
-	// Figure out how many light peers to allow (different based on node type)
-	fmt.Println()
-	fmt.Printf("How many light peers to allow connecting? (default = %d)\n", infos.peersLight)
-	infos.peersLight = w.readDefaultInt(infos.peersLight)
+	// Figure out how many light peers to allow (different based on node type),
+	// light clients can't follow congress chains
+	if w.conf.Genesis.Config.Congress != nil {
+		infos.peersLight = 0
+	} else {
+		fmt.Println()
+		fmt.Printf("How many light peers to allow connecting? (default = %d)\n", infos.peersLight)
+		infos.peersLight = w.readDefaultInt(infos.peersLight)
+	}
 
 	// Set a proper name to report on the stats page
 	fmt.Println()
@@ -118,7 +124,7 @@ func (w *wizard) deployNode(boot bool) {
 				fmt.Printf("What address should the miner use? (default = %s)\n", infos.etherbase)
 				infos.etherbase = w.readDefaultAddress(common.HexToAddress(infos.etherbase)).Hex()
 			}
-		} else if w.conf.Genesis.Config.Clique != nil {
+		} else if w.conf.Genesis.Config.Clique != nil || w.conf.Genesis.Config.Congress != nil {
 			// If a previous signer was already set, offer to reuse it
 			if infos.keyJSON != "" {
 				if key, err := keystore.DecryptKey([]byte(infos.keyJSON), infos.keyPass); err != nil {
@@ -131,7 +137,7 @@ func (w *wizard) deployNode(boot bool) {
 					}
 				}
 			}
-			// Clique based signers need a keyfile and unlock password, ask if unavailable
+			// Clique and congress signers need a keyfile and unlock password, ask if unavailable
 			if infos.keyJSON == "" {
 				fmt.Println()
 				fmt.Println("Please paste the signer's key JSON:")
@@ -180,3 +186,22 @@ func (w *wizard) deployNode(boot bool) {
 
 	w.networkStats()
 }
+
+// readImage returns the docker image of the client to deploy with, asking for it
+// on congress networks as the upstream images lack the engine. The image is the
+// upstream one on other networks.
+func (w *wizard) readImage() string {
+	if w.conf.Genesis.Config.Congress == nil {
+		return ""
+	}
+	fmt.Println()
+	if w.conf.Image == "" {
+		fmt.Println("Which docker image runs the congress client? (built from its Dockerfile.alltools)")
+		w.conf.Image = w.readString()
+	} else {
+		fmt.Printf("Which docker image runs the congress client? (default = %s)\n", w.conf.Image)
+		w.conf.Image = w.readDefaultString(w.conf.Image)
+	}
+	w.conf.flush()
+	return w.conf.Image
+}
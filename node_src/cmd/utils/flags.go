@@ -39,6 +39,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/fdlimit"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/ethereum/go-ethereum/consensus/congress"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
@@ -164,6 +165,10 @@ var (
 		Usage: "Initial block gas limit",
 		Value: 11500000,
 	}
+	DeveloperCongressFlag = cli.BoolFlag{
+		Name:  "dev.congress",
+		Usage: "Use the congress engine in developer mode, with the system contracts set up and the developer account as validator (RedCoast and Sophon active from block 1)",
+	}
 	IdentityFlag = cli.StringFlag{
 		Name:  "identity",
 		Usage: "Custom node name",
@@ -321,6 +326,53 @@ var (
 		Name:  "ethash.dagslockmmap",
 		Usage: "Lock memory maps for recent ethash mining DAGs",
 	}
+	// Congress settings
+	CongressRewardIndexFlag = cli.BoolFlag{
+		Name:  "congress.rewardindex",
+		Usage: "Index the fee distribution of every imported block for congress_getRewardBreakdown",
+	}
+	CongressLeaseFileFlag = cli.StringFlag{
+		Name:  "congress.lease.file",
+		Usage: "Lease file shared by the hosts running the validator key, only its holder seals (empty = always seal)",
+	}
+	CongressLeaseTTLFlag = cli.DurationFlag{
+		Name:  "congress.lease.ttl",
+		Usage: "Period the validator lease stays valid without renewal",
+		Value: ethconfig.Defaults.CongressLeaseTTL,
+	}
+	CongressLeaseHostFlag = cli.StringFlag{
+		Name:  "congress.lease.host",
+		Usage: "Identity of this host in the validator lease (default = host name and data directory)",
+	}
+	CongressConsensusKeyFlag = cli.StringFlag{
+		Name:  "congress.consensuskey",
+		Usage: "Account signing blocks, votes and system transactions on behalf of the etherbase validator once registered as its consensus key",
+	}
+	CongressRemoteSignerFlag = cli.StringFlag{
+		Name:  "congress.remotesigner",
+		Usage: "URL of a Web3Signer-style remote signer holding the validator keys (empty = local accounts)",
+	}
+	CongressRemoteSignerTimeoutFlag = cli.DurationFlag{
+		Name:  "congress.remotesigner.timeout",
+		Usage: "Timeout of each request to the remote signer, sealing pauses while it's unreachable",
+		Value: congress.DefaultRemoteSignerTimeout,
+	}
+	CongressRemoteSignerCertFlag = cli.StringFlag{
+		Name:  "congress.remotesigner.tls.cert",
+		Usage: "TLS client certificate to authenticate with the remote signer",
+	}
+	CongressRemoteSignerKeyFlag = cli.StringFlag{
+		Name:  "congress.remotesigner.tls.key",
+		Usage: "TLS client private key to authenticate with the remote signer",
+	}
+	CongressRemoteSignerCAFlag = cli.StringFlag{
+		Name:  "congress.remotesigner.tls.ca",
+		Usage: "CA certificates to verify the remote signer with (default = system ones)",
+	}
+	CongressCheckpointFlag = cli.StringFlag{
+		Name:  "congress.checkpoint",
+		Usage: "Epoch checkpoint to trust instead of verifying the headers up to it (<number>:<hash>)",
+	}
 	// Transaction pool settings
 	TxPoolLocalsFlag = cli.StringFlag{
 		Name:  "txpool.locals",
@@ -1364,6 +1416,62 @@ func setEthash(ctx *cli.Context, cfg *ethconfig.Config) {
 	}
 }
 
+func setCongress(ctx *cli.Context, cfg *ethconfig.Config) {
+	if ctx.GlobalIsSet(CongressRewardIndexFlag.Name) {
+		cfg.CongressRewardIndex = ctx.GlobalBool(CongressRewardIndexFlag.Name)
+	}
+	if ctx.GlobalIsSet(CongressLeaseFileFlag.Name) {
+		cfg.CongressLeaseFile = ctx.GlobalString(CongressLeaseFileFlag.Name)
+	}
+	if ctx.GlobalIsSet(CongressLeaseTTLFlag.Name) {
+		cfg.CongressLeaseTTL = ctx.GlobalDuration(CongressLeaseTTLFlag.Name)
+		if cfg.CongressLeaseTTL <= 0 {
+			Fatalf("Invalid validator lease period %v", cfg.CongressLeaseTTL)
+		}
+	}
+	if ctx.GlobalIsSet(CongressLeaseHostFlag.Name) {
+		cfg.CongressLeaseHost = ctx.GlobalString(CongressLeaseHostFlag.Name)
+	}
+	if ctx.GlobalIsSet(CongressConsensusKeyFlag.Name) {
+		key := ctx.GlobalString(CongressConsensusKeyFlag.Name)
+		if !common.IsHexAddress(key) {
+			Fatalf("Invalid consensus key %q", key)
+		}
+		cfg.CongressConsensusKey = common.HexToAddress(key)
+	}
+	if ctx.GlobalIsSet(CongressRemoteSignerFlag.Name) {
+		cfg.CongressRemoteSigner = ctx.GlobalString(CongressRemoteSignerFlag.Name)
+	}
+	if ctx.GlobalIsSet(CongressRemoteSignerTimeoutFlag.Name) {
+		cfg.CongressRemoteSignerTimeout = ctx.GlobalDuration(CongressRemoteSignerTimeoutFlag.Name)
+	}
+	if ctx.GlobalIsSet(CongressRemoteSignerCertFlag.Name) {
+		cfg.CongressRemoteSignerCert = ctx.GlobalString(CongressRemoteSignerCertFlag.Name)
+	}
+	if ctx.GlobalIsSet(CongressRemoteSignerKeyFlag.Name) {
+		cfg.CongressRemoteSignerKey = ctx.GlobalString(CongressRemoteSignerKeyFlag.Name)
+	}
+	if ctx.GlobalIsSet(CongressRemoteSignerCAFlag.Name) {
+		cfg.CongressRemoteSignerCA = ctx.GlobalString(CongressRemoteSignerCAFlag.Name)
+	}
+	if ctx.GlobalIsSet(CongressCheckpointFlag.Name) {
+		entry := ctx.GlobalString(CongressCheckpointFlag.Name)
+		parts := strings.Split(entry, ":")
+		if len(parts) != 2 {
+			Fatalf("Invalid congress checkpoint: %s", entry)
+		}
+		number, err := strconv.ParseUint(parts[0], 0, 64)
+		if err != nil {
+			Fatalf("Invalid congress checkpoint number %s: %v", parts[0], err)
+		}
+		var hash common.Hash
+		if err = hash.UnmarshalText([]byte(parts[1])); err != nil {
+			Fatalf("Invalid congress checkpoint hash %s: %v", parts[1], err)
+		}
+		cfg.CongressCheckpoint = &congress.TrustedCheckpoint{Number: number, Hash: hash}
+	}
+}
+
 func setMiner(ctx *cli.Context, cfg *miner.Config) {
 	if ctx.GlobalIsSet(MinerNotifyFlag.Name) {
 		cfg.Notify = strings.Split(ctx.GlobalString(MinerNotifyFlag.Name), ",")
@@ -1474,6 +1582,7 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	setGPO(ctx, &cfg.GPO, ctx.GlobalString(SyncModeFlag.Name) == "light")
 	setTxPool(ctx, &cfg.TxPool)
 	setEthash(ctx, cfg)
+	setCongress(ctx, cfg)
 	setMiner(ctx, &cfg.Miner)
 	setWhitelist(ctx, cfg)
 	setLes(ctx, cfg)
@@ -1633,7 +1742,11 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 		log.Info("Using developer account", "address", developer.Address)
 
 		// Create a new developer genesis block or reuse existing one
-		cfg.Genesis = core.DeveloperGenesisBlock(uint64(ctx.GlobalInt(DeveloperPeriodFlag.Name)), ctx.GlobalUint64(DeveloperGasLimitFlag.Name), developer.Address)
+		if ctx.GlobalBool(DeveloperCongressFlag.Name) {
+			cfg.Genesis = congress.DeveloperGenesisBlock(uint64(ctx.GlobalInt(DeveloperPeriodFlag.Name)), ctx.GlobalUint64(DeveloperGasLimitFlag.Name), developer.Address)
+		} else {
+			cfg.Genesis = core.DeveloperGenesisBlock(uint64(ctx.GlobalInt(DeveloperPeriodFlag.Name)), ctx.GlobalUint64(DeveloperGasLimitFlag.Name), developer.Address)
+		}
 		if ctx.GlobalIsSet(DataDirFlag.Name) {
 			// Check if we have an already initialized chain and fall back to
 			// that if so. Otherwise we need to generate a new genesis spec.
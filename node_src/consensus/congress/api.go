@@ -18,14 +18,27 @@
 package congress
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
+// errPendingBlock is returned if a method is requested on the pending block,
+// which the engine doesn't know.
+var errPendingBlock = errors.New("pending block not supported")
+
+// finalityChain defines the blockchain methods needed to resolve the safe and
+// finalized blocks.
+type finalityChain interface {
+	CurrentSafeBlock() *types.Block
+	CurrentFinalizedBlock() *types.Block
+}
+
 // API is a user facing RPC API to allow controlling the validator and voting
 // mechanisms of the proof-of-authority scheme.
 type API struct {
@@ -95,12 +108,15 @@ type status struct {
 	InturnPercent float64                `json:"inturnPercent"`
 	SigningStatus map[common.Address]int `json:"sealerActivity"`
 	NumBlocks     uint64                 `json:"numBlocks"`
+	MaxValidators int                    `json:"maxValidators"`
+	Epoch         uint64                 `json:"epoch"`
 }
 
 // Status returns the status of the last N blocks,
 // - the number of active validators,
 // - the number of validators,
 // - the percentage of in-turn blocks
+// - the validator cap and the epoch length in effect
 func (api *API) Status() (*status, error) {
 	var (
 		numBlocks = uint64(64)
@@ -144,5 +160,123 @@ func (api *API) Status() (*status, error) {
 		InturnPercent: float64(100*optimals) / float64(numBlocks),
 		SigningStatus: signStatus,
 		NumBlocks:     numBlocks,
+		MaxValidators: api.congress.maxValidatorsAt(header.Number.Uint64()),
+		Epoch:         api.congress.config.EpochAt(header.Number.Uint64()),
 	}, nil
 }
+
+// doubleSignEvidence is the RPC representation of a detected double sign.
+type doubleSignEvidence struct {
+	Hash      common.Hash    `json:"hash"`
+	Validator common.Address `json:"validator"`
+	Number    uint64         `json:"number"`
+	HeaderA   *types.Header  `json:"headerA"`
+	HeaderB   *types.Header  `json:"headerB"`
+}
+
+// GetDoubleSignEvidences returns the double sign evidences detected so far.
+func (api *API) GetDoubleSignEvidences() ([]*doubleSignEvidence, error) {
+	evidences := api.congress.evidences.list()
+
+	list := make([]*doubleSignEvidence, 0, len(evidences))
+	for _, evidence := range evidences {
+		validator, err := api.congress.evidenceValidator(evidence.HeaderA)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, &doubleSignEvidence{
+			Hash:      evidence.Hash(),
+			Validator: validator,
+			Number:    evidence.Number(),
+			HeaderA:   evidence.HeaderA,
+			HeaderB:   evidence.HeaderB,
+		})
+	}
+	return list, nil
+}
+
+// GetRewardBreakdown returns how the fees collected in the given block were
+// distributed to the validator and to the developer or contract addresses.
+func (api *API) GetRewardBreakdown(blockNrOrHash rpc.BlockNumberOrHash) (*RewardBreakdown, error) {
+	header, err := api.headerByNumberOrHash(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return api.congress.rewardBreakdown(api.chain, header)
+}
+
+// SimulateProposal executes a passed governance proposal on top of the given
+// block, as the next block would, and returns its outcome along with the state
+// changes of the touched accounts. Nothing is persisted.
+func (api *API) SimulateProposal(id hexutil.Big, blockNrOrHash rpc.BlockNumberOrHash) (*ProposalSimulation, error) {
+	header, err := api.headerByNumberOrHash(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return api.congress.simulateProposal(api.chain, header, id.ToInt())
+}
+
+// GetBlacklist returns the blacklisted addresses enforced on top of the given
+// block, along with the direction of the transfers they are denied.
+func (api *API) GetBlacklist(blockNrOrHash rpc.BlockNumberOrHash) ([]*BlacklistEntry, error) {
+	header, err := api.headerByNumberOrHash(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return api.congress.blacklistEntries(header)
+}
+
+// GetEventCheckRules returns the rules of the events whose topics are checked
+// against the blacklist on top of the given block.
+func (api *API) GetEventCheckRules(blockNrOrHash rpc.BlockNumberOrHash) ([]*EventRule, error) {
+	header, err := api.headerByNumberOrHash(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return api.congress.eventRules(header)
+}
+
+// CheckAddress explains whether transactions from or to the given address would
+// be rejected as denied on top of the given block.
+func (api *API) CheckAddress(addr common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*AddressCheck, error) {
+	header, err := api.headerByNumberOrHash(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return api.congress.checkAddress(header, addr)
+}
+
+// headerByNumberOrHash retrieves the requested header. The pending block isn't
+// known to the engine, and the safe and finalized blocks are only tracked with
+// the fast finality votes.
+func (api *API) headerByNumberOrHash(blockNrOrHash rpc.BlockNumberOrHash) (*types.Header, error) {
+	var header *types.Header
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		header = api.chain.GetHeaderByHash(hash)
+	} else if number, ok := blockNrOrHash.Number(); ok {
+		switch number {
+		case rpc.LatestBlockNumber:
+			header = api.chain.CurrentHeader()
+		case rpc.PendingBlockNumber:
+			return nil, errPendingBlock
+		case rpc.SafeBlockNumber, rpc.FinalizedBlockNumber:
+			chain, ok := api.chain.(finalityChain)
+			if !ok {
+				return nil, errUnknownBlock
+			}
+			block := chain.CurrentSafeBlock()
+			if number == rpc.FinalizedBlockNumber {
+				block = chain.CurrentFinalizedBlock()
+			}
+			if block != nil {
+				header = block.Header()
+			}
+		default:
+			header = api.chain.GetHeaderByNumber(uint64(number.Int64()))
+		}
+	}
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+	return header, nil
+}
@@ -0,0 +1,50 @@
+package congress
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestHeaderByNumberOrHash(t *testing.T) {
+	config := &params.ChainConfig{ChainID: big.NewInt(1), Congress: &params.CongressConfig{Period: 3, Epoch: 200}}
+	chain := newTestVoteChain(config, 3)
+	api := &API{chain: chain}
+
+	chain.SetSafe(chain.blocks[2])
+	chain.SetFinalized(chain.blocks[1])
+
+	tests := []struct {
+		number rpc.BlockNumber
+		want   uint64
+	}{
+		{rpc.LatestBlockNumber, 3},
+		{rpc.SafeBlockNumber, 2},
+		{rpc.FinalizedBlockNumber, 1},
+		{rpc.EarliestBlockNumber, 0},
+		{2, 2},
+	}
+	for _, tt := range tests {
+		header, err := api.headerByNumberOrHash(rpc.BlockNumberOrHashWithNumber(tt.number))
+		if err != nil {
+			t.Errorf("block %d: failed to resolve header: %v", tt.number, err)
+			continue
+		}
+		if have := header.Number.Uint64(); have != tt.want {
+			t.Errorf("block %d: number mismatch: have %d, want %d", tt.number, have, tt.want)
+		}
+	}
+	if _, err := api.headerByNumberOrHash(rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)); err != errPendingBlock {
+		t.Errorf("error mismatch: have %v, want %v", err, errPendingBlock)
+	}
+	if _, err := api.headerByNumberOrHash(rpc.BlockNumberOrHashWithNumber(10)); err != errUnknownBlock {
+		t.Errorf("error mismatch: have %v, want %v", err, errUnknownBlock)
+	}
+	// Without tracked finality the safe and finalized blocks are unknown
+	chain.SetFinalized(nil)
+	if _, err := api.headerByNumberOrHash(rpc.BlockNumberOrHashWithNumber(rpc.FinalizedBlockNumber)); err != errUnknownBlock {
+		t.Errorf("error mismatch: have %v, want %v", err, errUnknownBlock)
+	}
+}
@@ -0,0 +1,219 @@
+package congress
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// String implements fmt.Stringer.
+func (d blacklistDirection) String() string {
+	switch d {
+	case DirectionFrom:
+		return "from"
+	case DirectionTo:
+		return "to"
+	case DirectionBoth:
+		return "both"
+	}
+	return fmt.Sprintf("unknown(%d)", uint(d))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d blacklistDirection) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// checkTypeName returns the name of an address check type of an event rule.
+func checkTypeName(check common.AddressCheckType) string {
+	switch check {
+	case common.CheckNone:
+		return "none"
+	case common.CheckFrom:
+		return "from"
+	case common.CheckTo:
+		return "to"
+	case common.CheckBothInAny:
+		return "any"
+	}
+	return fmt.Sprintf("unknown(%d)", int(check))
+}
+
+// BlacklistEntry is a blacklisted address along with the direction of the
+// transfers it is denied.
+type BlacklistEntry struct {
+	Address   common.Address     `json:"address"`
+	Direction blacklistDirection `json:"direction"`
+}
+
+// EventRuleCheck is the check of a single topic of an event rule.
+type EventRuleCheck struct {
+	TopicIndex int    `json:"topicIndex"`
+	Check      string `json:"check"`
+}
+
+// EventRule lists the topics of an event whose addresses are checked against
+// the blacklist.
+type EventRule struct {
+	EventSig common.Hash      `json:"eventSig"`
+	Checks   []EventRuleCheck `json:"checks"`
+}
+
+// AddressCheck explains whether transactions involving an address would be
+// rejected with ErrAddressDenied in the next block.
+type AddressCheck struct {
+	Address           common.Address      `json:"address"`
+	Blacklisted       bool                `json:"blacklisted"`
+	Direction         *blacklistDirection `json:"direction,omitempty"`
+	DeniedAsSender    bool                `json:"deniedAsSender"`
+	DeniedAsRecipient bool                `json:"deniedAsRecipient"`
+	DeniedEvents      []common.Hash       `json:"deniedEvents"`
+	Reasons           []string            `json:"reasons"`
+}
+
+// blacklistAt returns the blacklist applied to the transactions of the block
+// following the given one, or nil if no blacklist is enforced there.
+func (c *Congress) blacklistAt(parent *types.Header) (map[common.Address]blacklistDirection, error) {
+	if c.stateFn == nil {
+		return nil, errMissingParentState
+	}
+	header := c.childHeader(parent)
+	if !c.isBlacklistEnforced(header) {
+		return nil, nil
+	}
+	statedb, err := c.stateFn(parent.Root)
+	if err != nil {
+		return nil, errMissingParentState
+	}
+	return c.getBlacklist(header, statedb)
+}
+
+// isBlacklistEnforced returns whether transactions of the given block are
+// validated against the blacklist, see ValidateTx.
+func (c *Congress) isBlacklistEnforced(header *types.Header) bool {
+	return c.chainConfig.RedCoastBlock != nil && c.chainConfig.RedCoastBlock.Cmp(header.Number) < 0
+}
+
+// isEventCheckEnforced returns whether the executions of the given block are
+// validated against the blacklist, see CreateEvmExtraValidator.
+func (c *Congress) isEventCheckEnforced(header *types.Header) bool {
+	return c.chainConfig.SophonBlock != nil && c.chainConfig.SophonBlock.Cmp(header.Number) < 0
+}
+
+// blacklistEntries returns the blacklist enforced in the block following the
+// given one, sorted by address.
+func (c *Congress) blacklistEntries(parent *types.Header) ([]*BlacklistEntry, error) {
+	blacks, err := c.blacklistAt(parent)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*BlacklistEntry, 0, len(blacks))
+	for addr, direction := range blacks {
+		entries = append(entries, &BlacklistEntry{Address: addr, Direction: direction})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].Address[:], entries[j].Address[:]) < 0
+	})
+	return entries, nil
+}
+
+// eventRulesAt returns the event check rules enforced in the block following
+// the given one, or nil if the events are not checked there.
+func (c *Congress) eventRulesAt(parent *types.Header) (map[common.Hash]*EventCheckRule, error) {
+	if c.stateFn == nil {
+		return nil, errMissingParentState
+	}
+	header := c.childHeader(parent)
+	if !c.isEventCheckEnforced(header) {
+		return nil, nil
+	}
+	statedb, err := c.stateFn(parent.Root)
+	if err != nil {
+		return nil, errMissingParentState
+	}
+	return c.getEventCheckRules(header, statedb)
+}
+
+// eventRules returns the event check rules enforced in the block following the
+// given one, sorted by event signature and topic index.
+func (c *Congress) eventRules(parent *types.Header) ([]*EventRule, error) {
+	rules, err := c.eventRulesAt(parent)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*EventRule, 0, len(rules))
+	for _, rule := range rules {
+		list = append(list, newEventRule(rule))
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return bytes.Compare(list[i].EventSig[:], list[j].EventSig[:]) < 0
+	})
+	return list, nil
+}
+
+// newEventRule converts a cached event check rule for the RPC.
+func newEventRule(rule *EventCheckRule) *EventRule {
+	checks := make([]EventRuleCheck, 0, len(rule.Checks))
+	for idx, check := range rule.Checks {
+		checks = append(checks, EventRuleCheck{TopicIndex: idx, Check: checkTypeName(check)})
+	}
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].TopicIndex < checks[j].TopicIndex
+	})
+	return &EventRule{EventSig: rule.EventSig, Checks: checks}
+}
+
+// checkAddress explains whether transactions from or to the given address, or
+// emitting events naming it, would be denied in the block following the given one.
+func (c *Congress) checkAddress(parent *types.Header, addr common.Address) (*AddressCheck, error) {
+	blacks, err := c.blacklistAt(parent)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := c.eventRulesAt(parent)
+	if err != nil {
+		return nil, err
+	}
+	return newAddressCheck(addr, blacks, rules), nil
+}
+
+// newAddressCheck evaluates the blacklist and event rules for an address.
+func newAddressCheck(addr common.Address, blacks map[common.Address]blacklistDirection, rules map[common.Hash]*EventCheckRule) *AddressCheck {
+	check := &AddressCheck{Address: addr, DeniedEvents: []common.Hash{}, Reasons: []string{}}
+
+	direction, ok := blacks[addr]
+	if !ok {
+		return check
+	}
+	check.Blacklisted, check.Direction = true, &direction
+	check.DeniedAsSender = direction != DirectionTo
+	check.DeniedAsRecipient = direction != DirectionFrom
+	if check.DeniedAsSender {
+		check.Reasons = append(check.Reasons, fmt.Sprintf("blacklisted with direction %s: transactions sent by the address are rejected", direction))
+	}
+	if check.DeniedAsRecipient {
+		check.Reasons = append(check.Reasons, fmt.Sprintf("blacklisted with direction %s: transactions sent to the address are rejected", direction))
+	}
+	if rules == nil {
+		return check
+	}
+	validator := &blacklistValidator{blacks: blacks, rules: rules}
+	for sig, rule := range rules {
+		for _, ct := range rule.Checks {
+			if validator.IsAddressDenied(addr, ct) {
+				check.DeniedEvents = append(check.DeniedEvents, sig)
+				break
+			}
+		}
+	}
+	sort.Slice(check.DeniedEvents, func(i, j int) bool {
+		return bytes.Compare(check.DeniedEvents[i][:], check.DeniedEvents[j][:]) < 0
+	})
+	for _, sig := range check.DeniedEvents {
+		check.Reasons = append(check.Reasons, fmt.Sprintf("executions emitting event %x with the address in a checked topic are rejected", sig))
+	}
+	return check
+}
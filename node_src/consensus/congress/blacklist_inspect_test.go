@@ -0,0 +1,60 @@
+package congress
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestAddressCheck(t *testing.T) {
+	var (
+		from     = common.HexToAddress("0x1000")
+		to       = common.HexToAddress("0x2000")
+		both     = common.HexToAddress("0x3000")
+		clean    = common.HexToAddress("0x4000")
+		transfer = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	)
+	blacks := map[common.Address]blacklistDirection{from: DirectionFrom, to: DirectionTo, both: DirectionBoth}
+	rules := map[common.Hash]*EventCheckRule{
+		transfer: {EventSig: transfer, Checks: map[int]common.AddressCheckType{1: common.CheckFrom, 2: common.CheckTo}},
+	}
+	tests := []struct {
+		addr      common.Address
+		sender    bool
+		recipient bool
+		events    int
+	}{
+		{from, true, false, 1},
+		{to, false, true, 1},
+		{both, true, true, 1},
+		{clean, false, false, 0},
+	}
+	for i, tt := range tests {
+		check := newAddressCheck(tt.addr, blacks, rules)
+		if check.DeniedAsSender != tt.sender || check.DeniedAsRecipient != tt.recipient {
+			t.Errorf("test %d: denial mismatch: have %v/%v, want %v/%v", i, check.DeniedAsSender, check.DeniedAsRecipient, tt.sender, tt.recipient)
+		}
+		if len(check.DeniedEvents) != tt.events {
+			t.Errorf("test %d: denied event count mismatch: have %d, want %d", i, len(check.DeniedEvents), tt.events)
+		}
+		if check.Blacklisted != (tt.sender || tt.recipient) || len(check.Reasons) == 0 == check.Blacklisted {
+			t.Errorf("test %d: blacklisted mismatch: have %v, reasons %v", i, check.Blacklisted, check.Reasons)
+		}
+	}
+	// Before the event checks are enforced, no event is denied
+	if check := newAddressCheck(both, blacks, nil); len(check.DeniedEvents) != 0 {
+		t.Errorf("events denied without rules: %v", check.DeniedEvents)
+	}
+	blob, err := json.Marshal(&BlacklistEntry{Address: both, Direction: DirectionBoth})
+	if err != nil {
+		t.Fatalf("failed to encode entry: %v", err)
+	}
+	if want := `{"address":"0x0000000000000000000000000000000000003000","direction":"both"}`; string(blob) != want {
+		t.Errorf("entry encoding mismatch: have %s, want %s", blob, want)
+	}
+	rule := newEventRule(rules[transfer])
+	if len(rule.Checks) != 2 || rule.Checks[0] != (EventRuleCheck{1, "from"}) || rule.Checks[1] != (EventRuleCheck{2, "to"}) {
+		t.Errorf("rule checks mismatch: have %v", rule.Checks)
+	}
+}
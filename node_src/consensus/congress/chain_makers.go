@@ -0,0 +1,331 @@
+package congress
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/congress/systemcontract"
+	"github.com/ethereum/go-ethereum/consensus/misc"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// makerTxGas is the gas limit of the transactions created by the chain maker.
+const makerTxGas = 1000000
+
+// adminABI holds the methods of the system contracts restricted to their admin,
+// which are not part of the interactive ABI of the engine.
+const adminABI = `[
+	{"type":"function","name":"commitProposal","inputs":[{"name":"action","type":"uint256"},{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"input","type":"bytes"}],"outputs":[]},
+	{"type":"function","name":"addBlacklist","inputs":[{"name":"addr","type":"address"},{"name":"direction","type":"uint8"}],"outputs":[]},
+	{"type":"function","name":"removeBlacklist","inputs":[{"name":"addr","type":"address"},{"name":"direction","type":"uint8"}],"outputs":[]}
+]`
+
+// errUnknownValidatorKey is returned if the chain maker is asked to seal a block
+// with a validator whose key it wasn't given.
+var errUnknownValidatorKey = errors.New("unknown validator key")
+
+// ChainMaker builds signed congress chains for tests. Every block is sealed with
+// the key of the validator picked by the caller, the in-turn one by default, and
+// imported into a blockchain backed by the given database. Sealing out of turn
+// simulates the in-turn validator missing its turn.
+type ChainMaker struct {
+	chain  *core.BlockChain
+	engine *Congress
+	keys   map[common.Address]*ecdsa.PrivateKey
+	signer types.Signer
+	admin  abi.ABI
+
+	nonces map[common.Address]uint64 // Nonces of the transactions created since the last block
+}
+
+// NewChainMaker commits the genesis to the database and creates a chain maker
+// on top of it, sealing blocks with the given validator and consensus keys.
+func NewChainMaker(db ethdb.Database, genesis *core.Genesis, keys ...*ecdsa.PrivateKey) (*ChainMaker, error) {
+	if genesis.Config == nil || genesis.Config.Congress == nil {
+		return nil, errNoCongressConfig
+	}
+	if _, err := genesis.Commit(db); err != nil {
+		return nil, err
+	}
+	engine := New(genesis.Config, db)
+	chain, err := core.NewBlockChain(db, nil, genesis.Config, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	engine.SetChain(chain)
+	engine.SetStateFn(chain.StateAt)
+
+	admin, err := abi.JSON(strings.NewReader(adminABI))
+	if err != nil {
+		return nil, err
+	}
+	maker := &ChainMaker{
+		chain:  chain,
+		engine: engine,
+		keys:   make(map[common.Address]*ecdsa.PrivateKey),
+		signer: types.LatestSigner(genesis.Config),
+		admin:  admin,
+		nonces: make(map[common.Address]uint64),
+	}
+	for _, key := range keys {
+		maker.keys[crypto.PubkeyToAddress(key.PublicKey)] = key
+	}
+	return maker, nil
+}
+
+// Chain returns the blockchain the blocks are imported into.
+func (m *ChainMaker) Chain() *core.BlockChain {
+	return m.chain
+}
+
+// Engine returns the consensus engine of the chain.
+func (m *ChainMaker) Engine() *Congress {
+	return m.engine
+}
+
+// Stop stops the blockchain.
+func (m *ChainMaker) Stop() {
+	m.chain.Stop()
+}
+
+// Snapshot returns the validators snapshot at the head of the chain.
+func (m *ChainMaker) Snapshot() (*Snapshot, error) {
+	head := m.chain.CurrentHeader()
+	return m.engine.snapshot(m.chain, head.Number.Uint64(), head.Hash(), nil)
+}
+
+// InTurn returns the validator in turn to seal the child of the given block.
+func (m *ChainMaker) InTurn(parent *types.Header) (common.Address, error) {
+	snap, err := m.engine.snapshot(m.chain, parent.Number.Uint64(), parent.Hash(), nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+	validators := snap.validators()
+	return validators[(parent.Number.Uint64()+1)%uint64(len(validators))], nil
+}
+
+// Mine seals a block with the transactions on top of the head of the chain, by
+// the validator in turn, and imports it. If the in-turn validator signed too
+// recently, after blocks sealed out of turn, the first validator allowed to seal
+// takes over like on the network.
+func (m *ChainMaker) Mine(txs ...*types.Transaction) (*types.Block, error) {
+	head := m.chain.CurrentHeader()
+	validator, err := m.InTurn(head)
+	if err != nil {
+		return nil, err
+	}
+	snap, err := m.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	number := head.Number.Uint64() + 1
+	if snap.signedRecently(number, validator) {
+		for _, other := range snap.validators() {
+			if !snap.signedRecently(number, other) {
+				validator = other
+				break
+			}
+		}
+	}
+	return m.MineBy(validator, txs...)
+}
+
+// MineBy seals a block with the transactions on top of the head of the chain,
+// by the given validator, and imports it. Unless the validator is in turn, the
+// block is sealed out of turn, the in-turn validator being punished for missing
+// its turn.
+func (m *ChainMaker) MineBy(validator common.Address, txs ...*types.Transaction) (*types.Block, error) {
+	block, err := m.Generate(m.chain.CurrentBlock(), validator, 0, txs)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := m.chain.InsertChain(types.Blocks{block}); err != nil {
+		return nil, err
+	}
+	m.nonces = make(map[common.Address]uint64)
+	return block, nil
+}
+
+// MineUntil seals empty blocks in turn until the head of the chain reaches the
+// given number, e.g. the next epoch.
+func (m *ChainMaker) MineUntil(number uint64) error {
+	for m.chain.CurrentHeader().Number.Uint64() < number {
+		if _, err := m.Mine(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Generate seals a block with the transactions on top of the given block of the
+// chain, by the given validator, without importing it. The block is timestamped
+// a block period, plus the given offset in seconds, after its parent, and the
+// clock of the engine moved forward if needed to accept it. System transactions
+// among the transactions are skipped, the engine issuing them itself.
+func (m *ChainMaker) Generate(parent *types.Block, validator common.Address, offset uint64, txs []*types.Transaction) (*types.Block, error) {
+	config := m.chain.Config()
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number(), common.Big1),
+		GasLimit:   parent.GasLimit(),
+	}
+	if config.IsLondon(header.Number) {
+		header.BaseFee = misc.CalcBaseFee(config, parent.Header())
+		if !config.IsLondon(parent.Number()) {
+			header.GasLimit = parent.GasLimit() * params.ElasticityMultiplier
+		}
+	}
+	// Seal with the validator, or with its consensus key if it registered one
+	snap, err := m.engine.snapshot(m.chain, parent.NumberU64(), parent.Hash(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := snap.Validators[validator]; !ok {
+		return nil, fmt.Errorf("%w: %x", errUnauthorizedValidator, validator)
+	}
+	key, ok := m.keys[snap.signingKey(validator)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %x", errUnknownValidatorKey, snap.signingKey(validator))
+	}
+	signFn := func(account accounts.Account, mimeType string, message []byte) ([]byte, error) {
+		return crypto.Sign(crypto.Keccak256(message), key)
+	}
+	m.engine.Authorize(validator, signFn, func(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+		key, ok := m.keys[account.Address]
+		if !ok {
+			return nil, fmt.Errorf("%w: %x", errUnknownValidatorKey, account.Address)
+		}
+		return types.SignTx(tx, types.LatestSignerForChainID(chainID), key)
+	})
+	if err := m.engine.Prepare(m.chain, header); err != nil {
+		return nil, err
+	}
+	header.Time = parent.Time() + m.engine.config.Period + offset
+	if ahead := int64(header.Time) - time.Now().Unix(); ahead > m.engine.TimeOffset() {
+		m.engine.SetTimeOffset(ahead)
+	}
+	// Run the transactions the way the miner does
+	statedb, err := m.chain.StateAt(parent.Root())
+	if err != nil {
+		return nil, err
+	}
+	if err := m.engine.PreHandle(m.chain, header, statedb); err != nil {
+		return nil, err
+	}
+	var (
+		extraValidator = m.engine.CreateEvmExtraValidator(header, statedb)
+		gp             = new(core.GasPool).AddGas(header.GasLimit)
+		included       []*types.Transaction
+		receipts       []*types.Receipt
+	)
+	for _, tx := range txs {
+		sender, err := types.Sender(m.signer, tx)
+		if err != nil {
+			return nil, err
+		}
+		if system, err := m.engine.IsSysTransaction(sender, tx, header); err != nil {
+			return nil, err
+		} else if system {
+			continue
+		}
+		if err := m.engine.ValidateTx(sender, tx, header, statedb); err != nil {
+			return nil, err
+		}
+		statedb.Prepare(tx.Hash(), len(included))
+		receipt, err := core.ApplyTransaction(config, m.chain, &header.Coinbase, gp, statedb, header, tx, &header.GasUsed, vm.Config{}, extraValidator)
+		if err != nil {
+			return nil, err
+		}
+		included = append(included, tx)
+		receipts = append(receipts, receipt)
+	}
+	block, _, err := m.engine.FinalizeAndAssemble(m.chain, header, statedb, included, nil, receipts)
+	if err != nil {
+		return nil, err
+	}
+	// Keep the state of the block around, it may be built upon before its import
+	if _, err := statedb.Commit(config.IsEIP158(header.Number)); err != nil {
+		return nil, err
+	}
+	header = block.Header()
+	if err := m.engine.signHeader(snap.signingKey(validator), signFn, header); err != nil {
+		return nil, err
+	}
+	return block.WithSeal(header), nil
+}
+
+// Transact creates a transaction sent by the key to the given account, with the
+// nonce following the ones of the transactions created since the last block.
+func (m *ChainMaker) Transact(key *ecdsa.PrivateKey, to common.Address, value *big.Int, data []byte) (*types.Transaction, error) {
+	head := m.chain.CurrentHeader()
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+
+	nonce, ok := m.nonces[sender]
+	if !ok {
+		statedb, err := m.chain.StateAt(head.Root)
+		if err != nil {
+			return nil, err
+		}
+		nonce = statedb.GetNonce(sender)
+	}
+	gasPrice := new(big.Int)
+	if head.BaseFee != nil {
+		gasPrice.Mul(head.BaseFee, common.Big2)
+	}
+	tx, err := types.SignTx(types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		To:       &to,
+		Value:    value,
+		Gas:      makerTxGas,
+		GasPrice: gasPrice,
+		Data:     data,
+	}), m.signer, key)
+	if err != nil {
+		return nil, err
+	}
+	m.nonces[sender] = nonce + 1
+	return tx, nil
+}
+
+// CommitProposal creates the transaction of the admin committing a governance
+// proposal, passed right away and executed by the validator sealing the block
+// that includes it. The admin can't be that validator.
+func (m *ChainMaker) CommitProposal(admin *ecdsa.PrivateKey, prop *Proposal) (*types.Transaction, error) {
+	data, err := m.admin.Pack("commitProposal", prop.Action, prop.From, prop.To, prop.Value, prop.Data)
+	if err != nil {
+		return nil, err
+	}
+	return m.Transact(admin, systemcontract.SysGovContractAddr, new(big.Int), data)
+}
+
+// AddBlacklist creates the transaction of the admin blacklisting the address in
+// the given direction, enforced from the block after the one including it.
+func (m *ChainMaker) AddBlacklist(admin *ecdsa.PrivateKey, addr common.Address, direction blacklistDirection) (*types.Transaction, error) {
+	return m.updateBlacklist(admin, "addBlacklist", addr, direction)
+}
+
+// RemoveBlacklist creates the transaction of the admin removing the address from
+// the blacklist of the given direction.
+func (m *ChainMaker) RemoveBlacklist(admin *ecdsa.PrivateKey, addr common.Address, direction blacklistDirection) (*types.Transaction, error) {
+	return m.updateBlacklist(admin, "removeBlacklist", addr, direction)
+}
+
+func (m *ChainMaker) updateBlacklist(admin *ecdsa.PrivateKey, method string, addr common.Address, direction blacklistDirection) (*types.Transaction, error) {
+	data, err := m.admin.Pack(method, addr, uint8(direction))
+	if err != nil {
+		return nil, err
+	}
+	return m.Transact(admin, systemcontract.AddressListContractAddr, new(big.Int), data)
+}
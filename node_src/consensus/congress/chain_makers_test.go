@@ -0,0 +1,189 @@
+package congress
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/congress/systemcontract"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// newTestChainMaker creates a chain maker sealing a chain of the given number of
+// validators, with RedCoast active from block 2, Sophon and slashing from block 3
+// and the system contracts administered by the returned admin key.
+func newTestChainMaker(t *testing.T, validators int, epoch uint64, alloc map[common.Address]*big.Int) (*ChainMaker, *ecdsa.PrivateKey) {
+	admin, _ := crypto.GenerateKey()
+	adminAddr := crypto.PubkeyToAddress(admin.PublicKey)
+
+	spec := &GenesisSpec{
+		ChainID:       big.NewInt(28525),
+		Period:        3,
+		Epoch:         epoch,
+		GasLimit:      30000000,
+		Alloc:         map[common.Address]*big.Int{adminAddr: big.NewInt(params.Ether)},
+		RedCoastBlock: big.NewInt(2),
+		SophonBlock:   big.NewInt(3),
+		SlashingBlock: big.NewInt(3),
+	}
+	for addr, balance := range alloc {
+		spec.Alloc[addr] = balance
+	}
+	keys := make([]*ecdsa.PrivateKey, validators)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		spec.Validators = append(spec.Validators, crypto.PubkeyToAddress(keys[i].PublicKey))
+	}
+	genesis, err := spec.Genesis()
+	if err != nil {
+		t.Fatalf("failed to assemble genesis: %v", err)
+	}
+	genesis.Config.Congress.Admin = &adminAddr
+
+	maker, err := NewChainMaker(rawdb.NewMemoryDatabase(), genesis, keys...)
+	if err != nil {
+		t.Fatalf("failed to create chain maker: %v", err)
+	}
+	return maker, admin
+}
+
+func TestChainMakerTurns(t *testing.T) {
+	maker, _ := newTestChainMaker(t, 3, 10, nil)
+	defer maker.Stop()
+
+	// Validators seal in turn, in ascending order
+	for i := 0; i < 6; i++ {
+		inturn, err := maker.InTurn(maker.Chain().CurrentHeader())
+		if err != nil {
+			t.Fatalf("failed to get in-turn validator: %v", err)
+		}
+		block, err := maker.Mine()
+		if err != nil {
+			t.Fatalf("failed to mine block %d: %v", i+1, err)
+		}
+		if block.Coinbase() != inturn || block.Difficulty().Cmp(diffInTurn) != 0 {
+			t.Errorf("block %d: sealer mismatch: have %x/%v, want %x/%v", i+1, block.Coinbase(), block.Difficulty(), inturn, diffInTurn)
+		}
+	}
+	// The validator having just sealed can't seal again
+	head := maker.Chain().CurrentBlock()
+	if _, err := maker.MineBy(head.Coinbase()); !errors.Is(err, errRecentlySigned) {
+		t.Errorf("error mismatch: have %v, want %v", err, errRecentlySigned)
+	}
+	// Another validator takes over the missed turn, punishing the in-turn one
+	snap, err := maker.Snapshot()
+	if err != nil {
+		t.Fatalf("failed to get snapshot: %v", err)
+	}
+	missed, _ := maker.InTurn(head.Header())
+	var sealer common.Address
+	for _, validator := range snap.validators() {
+		if validator != missed && !snap.signedRecently(head.NumberU64()+1, validator) {
+			sealer = validator
+		}
+	}
+	block, err := maker.MineBy(sealer)
+	if err != nil {
+		t.Fatalf("failed to mine out of turn: %v", err)
+	}
+	if block.Coinbase() != sealer || block.Difficulty().Cmp(diffNoTurn) != 0 {
+		t.Errorf("sealer mismatch: have %x/%v, want %x/%v", block.Coinbase(), block.Difficulty(), sealer, diffNoTurn)
+	}
+	statedb, _ := maker.Chain().State()
+	punish := systemcontract.GetPunishAddr(block.Number(), maker.Chain().Config())
+	ret, err := maker.Engine().commonCallContract(block.Header(), statedb, maker.Engine().abi[systemcontract.PunishContractName], *punish, "getPunishRecord", 1, missed)
+	if err != nil {
+		t.Fatalf("failed to query punish record: %v", err)
+	}
+	if record, _ := ret[0].(*big.Int); record == nil || record.Cmp(common.Big1) != 0 {
+		t.Errorf("punish record mismatch: have %v, want 1", ret[0])
+	}
+	// The checkpoint lists the validators
+	if err := maker.MineUntil(10); err != nil {
+		t.Fatalf("failed to mine to the checkpoint: %v", err)
+	}
+	checkpoint := maker.Chain().CurrentHeader()
+	validators, _ := parseCheckpoint(maker.Engine().config, checkpoint)
+	if len(validators) != 3 {
+		t.Errorf("checkpoint validator count mismatch: have %d, want 3", len(validators))
+	}
+}
+
+func TestChainMakerProposal(t *testing.T) {
+	var (
+		from = common.HexToAddress("0x1000")
+		to   = common.HexToAddress("0x2000")
+	)
+	maker, admin := newTestChainMaker(t, 2, 100, map[common.Address]*big.Int{from: big.NewInt(params.Ether)})
+	defer maker.Stop()
+
+	if err := maker.MineUntil(3); err != nil {
+		t.Fatalf("failed to set up the system contracts: %v", err)
+	}
+	// The proposal is executed with a system transaction of the sealer
+	tx, err := maker.CommitProposal(admin, &Proposal{Action: common.Big0, From: from, To: to, Value: big.NewInt(params.GWei)})
+	if err != nil {
+		t.Fatalf("failed to create proposal: %v", err)
+	}
+	block, err := maker.Mine(tx)
+	if err != nil {
+		t.Fatalf("failed to mine proposal: %v", err)
+	}
+	if txs := block.Transactions(); len(txs) != 2 || *txs[1].To() != systemcontract.SysGovToAddr {
+		t.Fatalf("system transaction missing: %v", txs)
+	}
+	statedb, _ := maker.Chain().State()
+	if have := statedb.GetBalance(to); have.Cmp(big.NewInt(params.GWei)) != 0 {
+		t.Errorf("proposal transfer mismatch: have %v, want %v", have, params.GWei)
+	}
+	// Proposals are only executed once
+	block, err = maker.Mine()
+	if err != nil {
+		t.Fatalf("failed to mine block: %v", err)
+	}
+	if txs := block.Transactions(); len(txs) != 0 {
+		t.Errorf("proposal executed again: %v", txs)
+	}
+}
+
+func TestChainMakerBlacklist(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	maker, admin := newTestChainMaker(t, 1, 100, map[common.Address]*big.Int{addr: big.NewInt(params.Ether)})
+	defer maker.Stop()
+
+	if err := maker.MineUntil(3); err != nil {
+		t.Fatalf("failed to set up the system contracts: %v", err)
+	}
+	tx, err := maker.AddBlacklist(admin, addr, DirectionFrom)
+	if err != nil {
+		t.Fatalf("failed to create blacklist update: %v", err)
+	}
+	if _, err := maker.Mine(tx); err != nil {
+		t.Fatalf("failed to mine blacklist update: %v", err)
+	}
+	// Transactions of the blacklisted account are denied
+	if tx, err = maker.Transact(key, common.Address{}, big.NewInt(1), nil); err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+	if _, err := maker.Mine(tx); !errors.Is(err, types.ErrAddressDenied) {
+		t.Errorf("error mismatch: have %v, want %v", err, types.ErrAddressDenied)
+	}
+	// And accepted again once removed
+	remove, err := maker.RemoveBlacklist(admin, addr, DirectionFrom)
+	if err != nil {
+		t.Fatalf("failed to create blacklist update: %v", err)
+	}
+	if _, err := maker.Mine(remove); err != nil {
+		t.Fatalf("failed to mine blacklist update: %v", err)
+	}
+	if _, err := maker.Mine(tx); err != nil {
+		t.Errorf("failed to mine transaction of the removed account: %v", err)
+	}
+}
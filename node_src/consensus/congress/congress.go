@@ -39,6 +39,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/congress/slashprotect"
 	"github.com/ethereum/go-ethereum/consensus/congress/systemcontract"
 	"github.com/ethereum/go-ethereum/consensus/congress/vmcaller"
 	"github.com/ethereum/go-ethereum/consensus/misc"
@@ -62,7 +63,7 @@ const (
 	inmemorySignatures = 4096 // Number of recent block signatures to keep in memory
 
 	wiggleTime    = 500 * time.Millisecond // Random delay (per validator) to allow concurrent validators
-	maxValidators = 21                     // Max validators allowed to seal.
+	maxValidators = 21                     // Default max validators allowed to seal, see CongressConfig.MaxValidators
 
 	inmemoryBlacklist = 21 // Number of recent blacklist snapshots to keep in memory
 )
@@ -115,6 +116,10 @@ var (
 	// errInvalidMixDigest is returned if a block's mix digest is non-zero.
 	errInvalidMixDigest = errors.New("non-zero mix digest")
 
+	// errInvalidNonce is returned if a block's nonce is non-zero, like the one of
+	// the blocks built by the dev RPCs on top of state overrides.
+	errInvalidNonce = errors.New("non-zero nonce")
+
 	// errInvalidUncleHash is returned if a block contains an non-empty uncle list.
 	errInvalidUncleHash = errors.New("non empty uncle hash")
 
@@ -203,6 +208,16 @@ type Congress struct {
 
 	proposals map[common.Address]bool // Current list of proposals we are pushing
 
+	evidences   *evidenceStore   // Recently sealed headers and detected double-sign evidences
+	rewardIndex bool             // Whether to index the fee shares of imported and sealed blocks
+	slashing    *slashprotect.DB // Headers signed by the local validator, if protected
+
+	assembledRewards *lru.Cache // Fee shares of the assembled blocks by seal hash, until they're sealed
+	failover    *failover        // Lease shared with the standby hosts of the validator, if any
+
+	remoteSigner *RemoteSigner     // Remote service holding the validator key, if any
+	trusted      *TrustedCheckpoint // Checkpoint trusted without verifying the headers up to it, if any
+
 	signer types.Signer // the signer instance to recover tx sender
 
 	validator common.Address // Ethereum address of the signing key
@@ -210,6 +225,12 @@ type Congress struct {
 	signTxFn  SignTxFn
 	lock      sync.RWMutex // Protects the validator fields
 
+	consensusKey      common.Address // Consensus key signing on behalf of the validator, if any
+	consensusSignFn   ValidatorFn    // Signing function of the consensus key
+	consensusSignTxFn SignTxFn       // Transaction signing function of the consensus key
+
+	dev devControls // Clock and sealing controls of developer chains, protected by lock
+
 	stateFn StateFn // Function to get state by state root
 
 	abi map[string]abi.ABI // Interactive with system contracts
@@ -245,6 +266,7 @@ func New(chainConfig *params.ChainConfig, db ethdb.Database) *Congress {
 		blacklists:      blacklists,
 		eventCheckRules: rules,
 		proposals:       make(map[common.Address]bool),
+		evidences:       newEvidenceStore(db),
 		abi:             abi,
 		signer:          types.LatestSignerForChainID(chainConfig.ChainID),
 	}
@@ -254,11 +276,29 @@ func (c *Congress) SetChain(chain consensus.ChainHeaderReader) {
 	c.chain = chain
 }
 
+// SetSlashingProtection sets the database recording the headers signed by the
+// local validator, to never sign two different headers at the same height.
+func (c *Congress) SetSlashingProtection(db *slashprotect.DB) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.slashing = db
+}
+
 // SetStateFn sets the function to get state.
 func (c *Congress) SetStateFn(fn StateFn) {
 	c.stateFn = fn
 }
 
+// maxValidatorsAt returns the maximum number of validators allowed to seal at
+// the given block.
+func (c *Congress) maxValidatorsAt(number uint64) int {
+	if c.config.MaxValidators != 0 && c.config.IsValidatorParams(number) {
+		return int(c.config.MaxValidators)
+	}
+	return maxValidators
+}
+
 // Author implements consensus.Engine, returning the Ethereum address recovered
 // from the signature in the header's extra-data section.
 func (c *Congress) Author(header *types.Header) (common.Address, error) {
@@ -268,6 +308,10 @@ func (c *Congress) Author(header *types.Header) (common.Address, error) {
 
 // VerifyHeader checks whether a header conforms to the consensus rules.
 func (c *Congress) VerifyHeader(chain consensus.ChainHeaderReader, header *types.Header, seal bool) error {
+	// The headers on the chain of the trusted checkpoint up to it aren't verified
+	if trusted, err := c.verifyTrusted(chain, header, nil); trusted {
+		return err
+	}
 	return c.verifyHeader(chain, header, nil)
 }
 
@@ -280,7 +324,10 @@ func (c *Congress) VerifyHeaders(chain consensus.ChainHeaderReader, headers []*t
 
 	go func() {
 		for i, header := range headers {
-			err := c.verifyHeader(chain, header, headers[:i])
+			trusted, err := c.verifyTrusted(chain, header, headers[i+1:])
+			if !trusted {
+				err = c.verifyHeader(chain, header, headers[:i])
+			}
 
 			select {
 			case <-abort:
@@ -303,7 +350,7 @@ func (c *Congress) verifyHeader(chain consensus.ChainHeaderReader, header *types
 	number := header.Number.Uint64()
 
 	// Don't waste time checking blocks from the future
-	if header.Time > uint64(time.Now().Unix()) {
+	if header.Time > uint64(c.now().Unix()) {
 		return consensus.ErrFutureBlock
 	}
 	// Check that the extra-data contains the vanity, validators and signature.
@@ -314,15 +361,16 @@ func (c *Congress) verifyHeader(chain consensus.ChainHeaderReader, header *types
 		return errMissingSignature
 	}
 	// check extra data
-	isEpoch := number%c.config.Epoch == 0
+	isEpoch := number%c.config.EpochAt(number) == 0
 
 	// Ensure that the extra-data contains a validator list on checkpoint, but none otherwise
 	validatorsBytes := len(header.Extra) - extraVanity - extraSeal
 	if !isEpoch && validatorsBytes != 0 {
 		return errExtraValidators
 	}
-	// Ensure that the validator bytes length is valid
-	if isEpoch && validatorsBytes%common.AddressLength != 0 {
+	// Ensure that the validator bytes length is valid, the keys of the validators
+	// following them from the consensus key fork
+	if _, ok := checkpointEntries(c.config, number, header.Extra); isEpoch && !ok {
 		return errExtraValidators
 	}
 
@@ -330,6 +378,10 @@ func (c *Congress) verifyHeader(chain consensus.ChainHeaderReader, header *types
 	if header.MixDigest != (common.Hash{}) {
 		return errInvalidMixDigest
 	}
+	// Ensure that the nonce is zero, it's only set on blocks that can't be replayed
+	if header.Nonce != (types.BlockNonce{}) {
+		return errInvalidNonce
+	}
 	// Ensure that the block doesn't contain any uncles which are meaningless in PoA
 	if header.UncleHash != uncleHash {
 		return errInvalidUncleHash
@@ -375,6 +427,13 @@ func (c *Congress) verifyCascadingFields(chain consensus.ChainHeaderReader, head
 	if parent.Time+c.config.Period > header.Time {
 		return ErrInvalidTimestamp
 	}
+	// Ensure the validator set of a checkpoint doesn't exceed the cap, which
+	// was left to the validators contract before the validator params fork
+	if c.config.IsValidatorParams(number) && number%c.config.EpochAt(number) == 0 {
+		if count, _ := checkpointEntries(c.config, number, header.Extra); count > c.maxValidatorsAt(number) {
+			return errInvalidValidatorsLength
+		}
+	}
 
 	// Verify that the gasUsed is <= gasLimit
 	if header.GasUsed > header.GasLimit {
@@ -418,20 +477,25 @@ func (c *Congress) snapshot(chain consensus.ChainHeaderReader, number uint64, ha
 				break
 			}
 		}
+		// If we're at the trusted checkpoint, snapshot the validators it lists
+		if s, err := c.trustedSnapshot(chain, number, hash, parents); err != nil {
+			return nil, err
+		} else if s != nil {
+			snap = s
+			break
+		}
 		// If we're at the genesis, snapshot the initial state. Alternatively if we're
 		// at a checkpoint block without a parent (light client CHT), or we have piled
 		// up more headers than allowed to be reorged (chain reinit from a freezer),
 		// consider the checkpoint trusted and snapshot it.
-		if number == 0 || (number%c.config.Epoch == 0 && (len(headers) > params.FullImmutabilityThreshold || chain.GetHeaderByNumber(number-1) == nil)) {
+		if number == 0 || (number%c.config.EpochAt(number) == 0 && (len(headers) > params.FullImmutabilityThreshold || chain.GetHeaderByNumber(number-1) == nil)) {
 			checkpoint := chain.GetHeaderByNumber(number)
 			if checkpoint != nil {
 				hash := checkpoint.Hash()
 
-				validators := make([]common.Address, (len(checkpoint.Extra)-extraVanity-extraSeal)/common.AddressLength)
-				for i := 0; i < len(validators); i++ {
-					copy(validators[i][:], checkpoint.Extra[extraVanity+i*common.AddressLength:])
-				}
+				validators, keys := parseCheckpoint(c.config, checkpoint)
 				snap = newSnapshot(c.config, c.signatures, number, hash, validators)
+				snap.Keys = consensusKeys(validators, keys)
 				if err := snap.store(c.db); err != nil {
 					return nil, err
 				}
@@ -510,28 +574,25 @@ func (c *Congress) verifySeal(chain consensus.ChainHeaderReader, header *types.H
     if err != nil {
         return err
     }
-    if signer != header.Coinbase {
+    // From the consensus key fork the coinbase is the validator, the block being
+    // signed by its consensus key
+    if c.config.IsConsensusKey(number) {
+        if snap.signingKey(header.Coinbase) != signer {
+            return errInvalidConsensusKey
+        }
+        signer = header.Coinbase
+    } else if signer != header.Coinbase {
         return errInvalidCoinbase
     }
 
     if _, ok := snap.Validators[signer]; !ok {
         return errUnauthorizedValidator
     }
+    c.detectDoubleSign(header, signer)
 
-    for seen, recent := range snap.Recents {
-        if recent == signer {
-            var limit uint64
-			limit = uint64(len(snap.Validators)/2 + 1)
-            if len(snap.Validators) > 21 || len(snap.Validators) == 1  {
-                limit = uint64(len(snap.Validators)/2 + 1)
-            } else {  //if number > 9299500 {  // Replace 'someGivenNumber' with the actual variable or value you want to compare against
-                limit = 2
-            }
-            // Validator is among recents, only fail if the current block doesn't shift it out
-            if seen > number-limit {
-                return errors.New("signed recently location-1")
-            }
-        }
+    // Ensure the validator didn't seal a block within the recents window
+    if snap.signedRecently(number, signer) {
+        return errRecentlySigned
     }
 
     // Ensure that the difficulty corresponds to the turn-ness of the signer
@@ -571,15 +632,18 @@ func (c *Congress) Prepare(chain consensus.ChainHeaderReader, header *types.Head
 	}
 	header.Extra = header.Extra[:extraVanity]
 
-	if number%c.config.Epoch == 0 {
+	if number%c.config.EpochAt(number) == 0 {
 		newSortedValidators, err := c.getTopValidators(chain, header)
 		if err != nil {
 			return err
 		}
-
-		for _, validator := range newSortedValidators {
-			header.Extra = append(header.Extra, validator.Bytes()...)
+		var keys []common.Address
+		if c.config.IsConsensusKey(number) {
+			if keys, err = c.getConsensusKeys(chain, header, newSortedValidators); err != nil {
+				return err
+			}
 		}
+		header.Extra = append(header.Extra, checkpointBytes(newSortedValidators, keys)...)
 	}
 	header.Extra = append(header.Extra, make([]byte, extraSeal)...)
 
@@ -592,8 +656,15 @@ func (c *Congress) Prepare(chain consensus.ChainHeaderReader, header *types.Head
 		return consensus.ErrUnknownAncestor
 	}
 	header.Time = parent.Time + c.config.Period
-	if header.Time < uint64(time.Now().Unix()) {
-		header.Time = uint64(time.Now().Unix())
+	if now := uint64(c.now().Unix()); header.Time < now {
+		header.Time = now
+	}
+	// Developer chains may pick the timestamp of the next block
+	c.lock.RLock()
+	next := c.dev.next
+	c.lock.RUnlock()
+	if next != 0 && next >= parent.Time+c.config.Period {
+		header.Time = next
 	}
 	return nil
 }
@@ -602,7 +673,7 @@ func (c *Congress) Prepare(chain consensus.ChainHeaderReader, header *types.Head
 // rewards given.
 func (c *Congress) Finalize(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, txs *[]*types.Transaction, uncles []*types.Header, receipts *[]*types.Receipt, systemTxs []*types.Transaction) error {
 	// Initialize all system contracts at block 1.
-	if header.Number.Cmp(common.Big1) == 0 {
+	if header.Number.Cmp(common.Big1) == 0 && !c.initializesEarly() {
 		if err := c.initializeSystemContracts(chain, header, state); err != nil {
 			log.Error("Initialize system contracts failed", "err", err)
 			return err
@@ -626,68 +697,53 @@ func (c *Congress) Finalize(chain consensus.ChainHeaderReader, header *types.Hea
 	}
 
 	// deposit block reward if any tx exists.
-	var addr [] common.Address
-	var gass [] uint64
-
-
-	if len(*txs) > 0 {
-				
-		var totalGasSum uint64
-
-		for i := 0; i < len(*txs); i++ {
-			TO := (*txs)[i]
-
-			if TO.To() == nil {
-				addr = append(addr, common.HexToAddress("0x0000000000000000000000000000000000000000"))
-			} else {
-				addr = append(addr, *TO.To())
-			}
-
-			gasFee := TO.Gas() * TO.GasPrice().Uint64()
-			gass = append(gass, gasFee)
-
-			// Accumulate gasFee to totalGasSum
-			totalGasSum += gasFee
-		}
-		
-	    fee := state.GetBalance(consensus.FeeRecoder)
-
-		feeUint64 := fee.Uint64()
-
-		if totalGasSum > feeUint64 {
-		
-			percentDifference := float64(totalGasSum-feeUint64) / float64(totalGasSum) * 100
-
-			for i := 0; i < len(gass); i++ {
-				decreaseAmount := uint64(float64(gass[i]) * (percentDifference / 100.0))
-				gass[i] -= decreaseAmount
-			}
+	if c.rewardIndex {
+		c.indexRewards(header, state, *txs, *receipts)
+	}
+	if chain.Config().IsFeeShare(header.Number) {
+		if err := c.trySendFeeShares(chain, header, state, *txs, *receipts); err != nil {
+			log.Info(err.Error())
 		}
-	    	
-		if err := c.trySendBlockReward(chain, header, state,addr,gass); err != nil {
+	} else if len(*txs) > 0 {
+		addr, gass := legacyFeeWeights(*txs, state.GetBalance(consensus.FeeRecoder))
+		if err := c.trySendBlockReward(chain, header, state, addr, gass); err != nil {
 			//panic(err)
 			log.Info(err.Error())
 		}
 	}
 
 	// do epoch thing at the end, because it will update active validators
-	if header.Number.Uint64()%c.config.Epoch == 0 {
+	if header.Number.Uint64()%c.config.EpochAt(header.Number.Uint64()) == 0 {
 		newValidators, err := c.doSomethingAtEpoch(chain, header, state)
 		if err != nil {
 			return err
 		}
 
-		validatorsBytes := make([]byte, len(newValidators)*common.AddressLength)
-		for i, validator := range newValidators {
-			copy(validatorsBytes[i*common.AddressLength:], validator.Bytes())
+		var keys []common.Address
+		if c.config.IsConsensusKey(header.Number.Uint64()) {
+			if keys, err = c.getConsensusKeys(chain, header, newValidators); err != nil {
+				return err
+			}
 		}
-
 		extraSuffix := len(header.Extra) - extraSeal
-		if !bytes.Equal(header.Extra[extraVanity:extraSuffix], validatorsBytes) {
+		if !bytes.Equal(header.Extra[extraVanity:extraSuffix], checkpointBytes(newValidators, keys)) {
 			return errInvalidExtraValidators
 		}
 	}
 
+	// split the double sign evidences from the system governance transactions,
+	// evidences are always submitted after the proposals
+	var evidenceTxs []*types.Transaction
+	if chain.Config().IsSlashing(header.Number) {
+		for i, tx := range systemTxs {
+			if *tx.To() != systemcontract.SysGovToAddr {
+				evidenceTxs = systemTxs[i:]
+				systemTxs = systemTxs[:i]
+				break
+			}
+		}
+	}
+
 	//handle system governance Proposal
 	if chain.Config().IsRedCoast(header.Number) {
 		proposalCount, err := c.getPassedProposalCount(chain, header, state)
@@ -726,6 +782,19 @@ func (c *Congress) Finalize(chain consensus.ChainHeaderReader, header *types.Hea
 		}
 	}
 
+	// handle double sign evidences
+	if len(evidenceTxs) > maxEvidencePerBlock {
+		return errTooManyEvidences
+	}
+	for _, tx := range evidenceTxs {
+		receipt, err := c.replayDoubleSignEvidence(chain, header, state, len(*txs), tx)
+		if err != nil {
+			return err
+		}
+		*txs = append(*txs, tx)
+		*receipts = append(*receipts, receipt)
+	}
+
 	// No block rewards in PoA, so the state remains as is and uncles are dropped
 	header.Root = state.IntermediateRoot(chain.Config().IsEIP158(header.Number))
 	header.UncleHash = types.CalcUncleHash(nil)
@@ -742,7 +811,7 @@ func (c *Congress) FinalizeAndAssemble(chain consensus.ChainHeaderReader, header
 		}
 	}()
 	// Initialize all system contracts at block 1.
-	if header.Number.Cmp(common.Big1) == 0 {
+	if header.Number.Cmp(common.Big1) == 0 && !c.initializesEarly() {
 		if err := c.initializeSystemContracts(chain, header, state); err != nil {
 			panic(err)
 		}
@@ -756,54 +825,27 @@ func (c *Congress) FinalizeAndAssemble(chain consensus.ChainHeaderReader, header
 	}
 
 	// deposit block reward if any tx exists.
-	var addr [] common.Address
-	var gass [] uint64
-	//addr = new[len(txs)]
-	
-	
-	if len(txs) > 0 {
-				
-		var totalGasSum uint64
-
-		for i := 0; i < len(txs); i++ {
-			TO := txs[i]
-
-			if TO.To() == nil {
-				addr = append(addr, common.HexToAddress("0x0000000000000000000000000000000000000000"))
-			} else {
-				addr = append(addr, *TO.To())
-			}
-
-			gasFee := TO.Gas() * TO.GasPrice().Uint64()
-			gass = append(gass, gasFee)
-
-			// Accumulate gasFee to totalGasSum
-			totalGasSum += gasFee
-		}
-		
-	    fee := state.GetBalance(consensus.FeeRecoder)
-
-		feeUint64 := fee.Uint64()
-
-		if totalGasSum > feeUint64 {
-		
-			percentDifference := float64(totalGasSum-feeUint64) / float64(totalGasSum) * 100
-
-			for i := 0; i < len(gass); i++ {
-				decreaseAmount := uint64(float64(gass[i]) * (percentDifference / 100.0))
-				gass[i] -= decreaseAmount
-			}
+	var (
+		rewards []*FeeShare
+		indexed bool
+	)
+	if c.rewardIndex {
+		rewards, indexed = c.rewardShares(header, state, txs, receipts)
+	}
+	if chain.Config().IsFeeShare(header.Number) {
+		if err := c.trySendFeeShares(chain, header, state, txs, receipts); err != nil {
+			log.Info(err.Error())
 		}
-	
-		if err := c.trySendBlockReward(chain, header, state,addr,gass); err != nil {
+	} else if len(txs) > 0 {
+		addr, gass := legacyFeeWeights(txs, state.GetBalance(consensus.FeeRecoder))
+		if err := c.trySendBlockReward(chain, header, state, addr, gass); err != nil {
 			//panic(err)
 			log.Info(err.Error())
-
 		}
 	}
 
 	// do epoch thing at the end, because it will update active validators
-	if header.Number.Uint64()%c.config.Epoch == 0 {
+	if header.Number.Uint64()%c.config.EpochAt(header.Number.Uint64()) == 0 {
 		if _, err := c.doSomethingAtEpoch(chain, header, state); err != nil {
 			//panic(err)
 			log.Info(err.Error())
@@ -815,8 +857,8 @@ func (c *Congress) FinalizeAndAssemble(chain consensus.ChainHeaderReader, header
 	// Note:
 	// Even if the miner is not `running`, it's still working,
 	// the 'miner.worker' will try to FinalizeAndAssemble a block,
-	// in this case, the signTxFn is not set. A `non-miner node` can't execute system governance proposal.
-	if c.signTxFn != nil && chain.Config().IsRedCoast(header.Number) {
+	// in this case, no transaction signer is set. A `non-miner node` can't execute system governance proposal.
+	if c.canSignSysTx() && chain.Config().IsRedCoast(header.Number) {
 		proposalCount, err := c.getPassedProposalCount(chain, header, state)
 		if err != nil {
 			return nil, nil, err
@@ -850,12 +892,23 @@ func (c *Congress) FinalizeAndAssemble(chain consensus.ChainHeaderReader, header
 		}
 	}
 
+	// submit the detected double sign evidences
+	if c.canSignSysTx() && chain.Config().IsSlashing(header.Number) {
+		evidenceTxs, evidenceReceipts := c.submitDoubleSignEvidences(chain, header, state, len(txs))
+		txs = append(txs, evidenceTxs...)
+		receipts = append(receipts, evidenceReceipts...)
+	}
+
 	// No block rewards in PoA, so the state remains as is and uncles are dropped
 	header.Root = state.IntermediateRoot(chain.Config().IsEIP158(header.Number))
 	header.UncleHash = types.CalcUncleHash(nil)
 
 	// Assemble and return the final block for sealing
-	return types.NewBlock(header, txs, nil, receipts, new(trie.Trie)), receipts, nil
+	block := types.NewBlock(header, txs, nil, receipts, new(trie.Trie))
+	if indexed {
+		c.stashRewards(block.Header(), rewards)
+	}
+	return block, receipts, nil
 }
 
 func (c *Congress) trySendBlockReward(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, addr []common.Address, gass []uint64) error {
@@ -916,14 +969,7 @@ func (c *Congress) tryPunishValidator(chain consensus.ChainHeaderReader, header
 	validators := snap.validators()
 	outTurnValidator := validators[number%uint64(len(validators))]
 	// check sigend recently or not
-	signedRecently := false
-	for _, recent := range snap.Recents {
-		if recent == outTurnValidator {
-			signedRecently = true
-			break
-		}
-	}
-	if !signedRecently {
+	if !snap.signedRecently(number, outTurnValidator) {
 		if err := c.punishValidator(outTurnValidator, chain, header, state); err != nil {
 			return err
 		}
@@ -950,6 +996,13 @@ func (c *Congress) doSomethingAtEpoch(chain consensus.ChainHeaderReader, header
 	return newSortedValidators, nil
 }
 
+// initializesEarly returns whether the genesis system contracts are initialized
+// before the transactions of block 1 instead of when finalizing it, for the
+// RedCoast upgrade at block 1 to migrate the validators they hold.
+func (c *Congress) initializesEarly() bool {
+	return c.chainConfig.RedCoastBlock != nil && c.chainConfig.RedCoastBlock.Cmp(common.Big1) == 0
+}
+
 // initializeSystemContracts initializes all genesis system contracts.
 func (c *Congress) initializeSystemContracts(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB) error {
 	snap, err := c.snapshot(chain, 0, header.ParentHash, nil)
@@ -958,7 +1011,7 @@ func (c *Congress) initializeSystemContracts(chain consensus.ChainHeaderReader,
 	}
 
 	genesisValidators := snap.validators()
-	if len(genesisValidators) == 0 || len(genesisValidators) > maxValidators {
+	if len(genesisValidators) == 0 || len(genesisValidators) > c.maxValidatorsAt(header.Number.Uint64()) {
 		return errInvalidValidatorsLength
 	}
 
@@ -1031,6 +1084,10 @@ func (c *Congress) getTopValidators(chain consensus.ChainHeaderReader, header *t
 	if !ok {
 		return []common.Address{}, errors.New("Invalid validators format")
 	}
+	// keep the top validators returned by the contract up to the cap
+	if limit := c.maxValidatorsAt(header.Number.Uint64()); c.config.IsValidatorParams(header.Number.Uint64()) && len(validators) > limit {
+		validators = validators[:limit]
+	}
 	sort.Sort(validatorsAscending(validators))
 	return validators, err
 }
@@ -1038,7 +1095,7 @@ func (c *Congress) getTopValidators(chain consensus.ChainHeaderReader, header *t
 func (c *Congress) updateValidators(vals []common.Address, chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB) error {
 	// method
 	method := "updateActiveValidatorSet"
-	data, err := c.abi[systemcontract.ValidatorsContractName].Pack(method, vals, new(big.Int).SetUint64(c.config.Epoch))
+	data, err := c.abi[systemcontract.ValidatorsContractName].Pack(method, vals, new(big.Int).SetUint64(c.config.EpochAt(header.Number.Uint64())))
 	if err != nil {
 		log.Error("Can't pack data for updateActiveValidatorSet", "error", err)
 		return err
@@ -1078,7 +1135,7 @@ func (c *Congress) punishValidator(val common.Address, chain consensus.ChainHead
 func (c *Congress) decreaseMissedBlocksCounter(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB) error {
 	// method
 	method := "decreaseMissedBlocksCounter"
-	data, err := c.abi[systemcontract.PunishContractName].Pack(method, new(big.Int).SetUint64(c.config.Epoch))
+	data, err := c.abi[systemcontract.PunishContractName].Pack(method, new(big.Int).SetUint64(c.config.EpochAt(header.Number.Uint64())))
 	if err != nil {
 		log.Error("Can't pack data for decreaseMissedBlocksCounter", "error", err)
 		return err
@@ -1104,6 +1161,7 @@ func (c *Congress) Authorize(validator common.Address, signFn ValidatorFn, signT
 	c.validator = validator
 	c.signFn = signFn
 	c.signTxFn = signTxFn
+	c.remoteSigner = nil
 }
 
 // Seal implements consensus.Engine, attempting to create a sealed block using
@@ -1116,16 +1174,28 @@ func (c *Congress) Seal(chain consensus.ChainHeaderReader, block *types.Block, r
 	if number == 0 {
 		return errUnknownBlock
 	}
-	// For 0-period chains, refuse to seal empty blocks (no reward but would spin sealing)
-	if c.config.Period == 0 && len(block.Transactions()) == 0 {
+	// For 0-period chains, refuse to seal empty blocks (no reward but would spin sealing),
+	// unless the system contracts are still being set up by the forks or empty blocks
+	// are requested on a developer chain
+	empty := c.config.Period == 0 && len(block.Transactions()) == 0 && !c.settingUp(header.Number)
+	if empty && !c.emptyRequested() {
 		log.Info("Sealing paused, waiting for transactions")
 		return nil
 	}
 	// Don't hold the val fields for the entire sealing procedure
 	c.lock.RLock()
-	val, signFn := c.validator, c.signFn
+	val, signFn, failover, remote := c.validator, c.signFn, c.failover, c.remoteSigner
 	c.lock.RUnlock()
 
+	// Standby hosts of the validator only seal once they acquire the lease
+	if failover != nil && !failover.isActive() {
+		return nil
+	}
+	// Don't seal while the remote signer holding the key is unreachable
+	if remote != nil && !remote.isReachable() {
+		return nil
+	}
+
 	// Bail out if we're unauthorized to sign a block
 	snap, err := c.snapshot(chain, number-1, header.ParentHash, nil)
 	if err != nil {
@@ -1134,28 +1204,19 @@ func (c *Congress) Seal(chain consensus.ChainHeaderReader, block *types.Block, r
 	if _, authorized := snap.Validators[val]; !authorized {
 		return errUnauthorizedValidator
 	}
- 
-  // If we're amongst the recent validators, wait for the next block
-  for seen, recent := range snap.Recents {
-  	if recent == val {
-  		// Determine the limit based on the number of validators
-  		var limit uint64
-		limit = uint64(len(snap.Validators)/2 + 1)
-  		if len(snap.Validators) > 21 || len(snap.Validators) == 1  {
-  			limit = uint64(len(snap.Validators)/2 + 1)
-  		} else { //if number > 9299500 {
-  			limit = 2
-  		}
-  		// Validator is among recents, only wait if the current block doesn't shift it out
-  		if number < limit || seen > number-limit {
-  			log.Info("Signed recently, must wait for others")
-  			return nil
-  		}
-  	}
-  }
+	// From the consensus key fork the block may be signed by the consensus key
+	signer, signFn, err := c.signerOf(snap, val, signFn)
+	if err != nil {
+		return err
+	}
+	// If we're amongst the recent validators, wait for the next block
+	if snap.signedRecently(number, val) {
+		log.Info("Signed recently, must wait for others")
+		return nil
+	}
 
 	// Sweet, the protocol permits us to sign the block, wait for our time
-	delay := time.Unix(int64(header.Time), 0).Sub(time.Now()) // nolint: gosimple
+	delay := time.Unix(int64(header.Time), 0).Sub(c.now()) // nolint: gosimple
 	if header.Difficulty.Cmp(diffNoTurn) == 0 {
 		// It's not our turn explicitly to sign, delay it a bit
 		wiggle := time.Duration(len(snap.Validators)/2+1) * wiggleTime
@@ -1163,13 +1224,8 @@ func (c *Congress) Seal(chain consensus.ChainHeaderReader, block *types.Block, r
 
 		log.Trace("Out-of-turn signing requested", "wiggle", common.PrettyDuration(wiggle))
 	}
-	// Sign all the things!
-	sighash, err := signFn(accounts.Account{Address: val}, accounts.MimetypeCongress, CongressRLP(header))
-	if err != nil {
-		return err
-	}
-	copy(header.Extra[len(header.Extra)-extraSeal:], sighash)
-	// Wait until sealing is terminated or delay timeout.
+	// Wait until sealing is terminated or delay timeout, the header is only
+	// signed once it's about to be propagated.
 	log.Trace("Waiting for slot to sign and propagate", "delay", common.PrettyDuration(delay))
 	go func() {
 		select {
@@ -1177,7 +1233,22 @@ func (c *Congress) Seal(chain consensus.ChainHeaderReader, block *types.Block, r
 			return
 		case <-time.After(delay):
 		}
-
+		// The lease may have been handed over or the signer gone meanwhile
+		if failover != nil && !failover.isActive() {
+			return
+		}
+		if remote != nil && !remote.isReachable() {
+			return
+		}
+		if !c.consumeDevControls(header, empty) {
+			return
+		}
+		// Sign all the things!
+		if err := c.signHeader(signer, signFn, header); err != nil {
+			log.Error("Failed to sign block", "number", number, "sealhash", SealHash(header), "err", err)
+			return
+		}
+		c.indexSealedRewards(header)
 		select {
 		case results <- block.WithSeal(header):
 		default:
@@ -1188,6 +1259,41 @@ func (c *Congress) Seal(chain consensus.ChainHeaderReader, block *types.Block, r
 	return nil
 }
 
+// settingUp returns whether the system contracts are still being set up at the
+// given block, by the genesis initialization or the RedCoast and Sophon forks.
+func (c *Congress) settingUp(number *big.Int) bool {
+	if number.Cmp(common.Big1) == 0 {
+		return true
+	}
+	for _, fork := range []*big.Int{c.chainConfig.RedCoastBlock, c.chainConfig.SophonBlock} {
+		if fork != nil && fork.Cmp(number) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// signHeader signs the header in place. If the slashing protection is enabled,
+// the header is recorded before being signed and refused if the signer already
+// signed a different header at the same height.
+func (c *Congress) signHeader(signer common.Address, signFn ValidatorFn, header *types.Header) error {
+	c.lock.RLock()
+	slashing := c.slashing
+	c.lock.RUnlock()
+
+	if slashing != nil {
+		if err := slashing.CheckAndRecord(signer, header.Number.Uint64(), SealHash(header)); err != nil {
+			return err
+		}
+	}
+	sighash, err := signFn(accounts.Account{Address: signer}, accounts.MimetypeCongress, CongressRLP(header))
+	if err != nil {
+		return err
+	}
+	copy(header.Extra[len(header.Extra)-extraSeal:], sighash)
+	return nil
+}
+
 // CalcDifficulty is the difficulty adjustment algorithm. It returns the difficulty
 // that a new block should have:
 // * DIFF_NOTURN(2) if BLOCK_NUMBER % validator_COUNT != validator_INDEX
@@ -1212,8 +1318,16 @@ func (c *Congress) SealHash(header *types.Header) common.Hash {
 	return SealHash(header)
 }
 
-// Close implements consensus.Engine. It's a noop for congress as there are no background threads.
+// Close implements consensus.Engine, stopping the background renewal of the
+// validator lease, if any.
 func (c *Congress) Close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.failover != nil {
+		c.failover.stop()
+		c.failover = nil
+	}
 	return nil
 }
 
@@ -1225,6 +1339,11 @@ func (c *Congress) APIs(chain consensus.ChainHeaderReader) []rpc.API {
 		Version:   "1.0",
 		Service:   &API{chain: chain, congress: c},
 		Public:    false,
+	}, {
+		Namespace: "admin",
+		Version:   "1.0",
+		Service:   &FailoverAPI{congress: c},
+		Public:    false,
 	}}
 }
 
@@ -1273,13 +1392,31 @@ func encodeSigHeader(w io.Writer, header *types.Header) {
 }
 
 func (c *Congress) PreHandle(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB) error {
+	if header.Number.Cmp(common.Big1) == 0 && c.initializesEarly() {
+		if err := c.initializeSystemContracts(chain, header, state); err != nil {
+			log.Error("Initialize system contracts failed", "err", err)
+			return err
+		}
+	}
+	// Both forks may be applied at the same block, Sophon building on RedCoast
 	if c.chainConfig.RedCoastBlock != nil && c.chainConfig.RedCoastBlock.Cmp(header.Number) == 0 {
-		return systemcontract.ApplySystemContractUpgrade(systemcontract.SysContractV1, state, header, newChainContext(chain, c), c.chainConfig)
+		if err := systemcontract.ApplySystemContractUpgrade(systemcontract.SysContractV1, state, header, newChainContext(chain, c), c.chainConfig); err != nil {
+			return err
+		}
 	}
 	if c.chainConfig.SophonBlock != nil && c.chainConfig.SophonBlock.Cmp(header.Number) == 0 {
-		return systemcontract.ApplySystemContractUpgrade(systemcontract.SysContractV2, state, header, newChainContext(chain, c), c.chainConfig)
+		if err := systemcontract.ApplySystemContractUpgrade(systemcontract.SysContractV2, state, header, newChainContext(chain, c), c.chainConfig); err != nil {
+			return err
+		}
 	}
-	return nil
+	// Double signs are slashed through the slashing contract from its fork
+	if c.chainConfig.SlashingBlock != nil && c.chainConfig.SlashingBlock.Cmp(header.Number) == 0 {
+		if err := systemcontract.ApplySystemContractUpgrade(systemcontract.SysContractSlashing, state, header, newChainContext(chain, c), c.chainConfig); err != nil {
+			return err
+		}
+	}
+	// Upgrades scheduled in the config are applied after the built-in ones
+	return systemcontract.ApplyScheduledUpgrades(state, header, newChainContext(chain, c), c.chainConfig)
 }
 
 // IsSysTransaction checks whether a specific transaction is a system transaction.
@@ -1289,11 +1426,14 @@ func (c *Congress) IsSysTransaction(sender common.Address, tx *types.Transaction
 	}
 
 	to := tx.To()
-	if sender == header.Coinbase && *to == systemcontract.SysGovToAddr && tx.GasPrice().Sign() == 0 {
+	if c.isSysTxSender(sender, header) && *to == systemcontract.SysGovToAddr && tx.GasPrice().Sign() == 0 {
 		return true, nil
 	}
 	// Make sure the miner can NOT call the system contract through a normal transaction.
-	if sender == header.Coinbase && *to == systemcontract.SysGovContractAddr {
+	if c.isSysTxSender(sender, header) && *to == systemcontract.SysGovContractAddr {
+		return true, nil
+	}
+	if c.isDoubleSignSysTx(sender, tx, header) {
 		return true, nil
 	}
 	return false, nil
@@ -1339,6 +1479,12 @@ func (c *Congress) ValidateTx(sender common.Address, tx *types.Transaction, head
 	return nil
 }
 
+// BlacklistUpdatedNumber returns the number of the block the blacklist was last
+// updated in, as recorded by the AddressList contract in the given state.
+func (c *Congress) BlacklistUpdatedNumber(state *state.StateDB) uint64 {
+	return lastBlacklistUpdatedNumber(state)
+}
+
 func (c *Congress) getBlacklist(header *types.Header, parentState *state.StateDB) (map[common.Address]blacklistDirection, error) {
 	defer func(start time.Time) {
 		getblacklistTimer.UpdateSince(start)
@@ -113,9 +113,10 @@ func (c *Congress) finishProposalById(chain consensus.ChainHeaderReader, header
 func (c *Congress) executeProposal(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, prop *Proposal, totalTxIndex int) (*types.Transaction, *types.Receipt, error) {
 	// Even if the miner is not `running`, it's still working,
 	// the 'miner.worker' will try to FinalizeAndAssemble a block,
-	// in this case, the signTxFn is not set. A `non-miner node` can't execute system governance proposal.
-	if c.signTxFn == nil {
-		return nil, nil, errors.New("signTxFn not set")
+	// in this case, no transaction signer is set. A `non-miner node` can't execute system governance proposal.
+	signer, signTxFn, err := c.sysTxSigner(chain, header)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	propRLP, err := rlp.EncodeToBytes(prop)
@@ -123,19 +124,19 @@ func (c *Congress) executeProposal(chain consensus.ChainHeaderReader, header *ty
 		return nil, nil, err
 	}
 	//make system governance transaction
-	nonce := state.GetNonce(c.validator)
+	nonce := state.GetNonce(signer)
 	amout := prop.Value
 	if c.chainConfig.IsSophon(header.Number) {
 		// fix bug
 		amout = new(big.Int)
 	}
 	tx := types.NewTransaction(nonce, systemcontract.SysGovToAddr, amout, header.GasLimit, new(big.Int), propRLP)
-	tx, err = c.signTxFn(accounts.Account{Address: c.validator}, tx, chain.Config().ChainID)
+	tx, err = signTxFn(accounts.Account{Address: signer}, tx, chain.Config().ChainID)
 	if err != nil {
 		return nil, nil, err
 	}
 	//add nonce for validator
-	state.SetNonce(c.validator, nonce+1)
+	state.SetNonce(signer, nonce+1)
 	receipt := c.executeProposalMsg(chain, header, state, prop, totalTxIndex, tx.Hash(), common.Hash{})
 
 	return tx, receipt, nil
@@ -146,7 +147,7 @@ func (c *Congress) replayProposal(chain consensus.ChainHeaderReader, header *typ
 	if err != nil {
 		return nil, err
 	}
-	if sender != header.Coinbase {
+	if !c.isSysTxSender(sender, header) {
 		return nil, errors.New("invalid sender for system governance transaction")
 	}
 	propRLP, err := rlp.EncodeToBytes(prop)
@@ -213,6 +214,9 @@ func (c *Congress) executeEvmCallProposal(chain consensus.ChainHeaderReader, hea
 // ApplySysTx applies a system-transaction using a given evm,
 // the main purpose of this method is for tracing a system-transaction.
 func (c *Congress) ApplySysTx(evm *vm.EVM, state *state.StateDB, txIndex int, sender common.Address, tx *types.Transaction) (ret []byte, vmerr error, err error) {
+	if *tx.To() != systemcontract.SysGovToAddr {
+		return c.applySysEvidenceTx(evm, state, txIndex, sender, tx)
+	}
 	var prop = &Proposal{}
 	if err = rlp.DecodeBytes(tx.Data(), prop); err != nil {
 		return
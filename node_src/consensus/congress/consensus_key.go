@@ -0,0 +1,218 @@
+package congress
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/congress/systemcontract"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	// errInvalidConsensusKey is returned if a header is signed by another key
+	// than the consensus key of the validator in its coinbase.
+	errInvalidConsensusKey = errors.New("invalid consensus key")
+
+	// errMissingConsensusKey is returned when sealing if the validator signs with
+	// a consensus key that isn't available locally.
+	errMissingConsensusKey = errors.New("consensus key unavailable locally")
+
+	// errMissingValidatorKey is returned when sealing if the validator signs with
+	// its own key while only its consensus key is available locally.
+	errMissingValidatorKey = errors.New("validator key unavailable locally")
+)
+
+// AuthorizeConsensusKey injects the consensus key of the local validator, which
+// signs the blocks, votes and system transactions on its behalf from the
+// consensus key fork once the key is registered in the validators contract.
+// The validator key itself may then be left off the node, see AuthorizeValidator.
+func (c *Congress) AuthorizeConsensusKey(key common.Address, signFn ValidatorFn, signTxFn SignTxFn) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.consensusKey = key
+	c.consensusSignFn = signFn
+	c.consensusSignTxFn = signTxFn
+}
+
+// AuthorizeValidator sets the local validator without its key, for nodes sealing
+// with the consensus key only.
+func (c *Congress) AuthorizeValidator(validator common.Address) {
+	c.Authorize(validator, nil, nil)
+}
+
+// signerOf returns the account signing on behalf of the local validator in the
+// given snapshot, along with its signing function.
+func (c *Congress) signerOf(snap *Snapshot, val common.Address, signFn ValidatorFn) (common.Address, ValidatorFn, error) {
+	key := snap.signingKey(val)
+	if key == val {
+		if signFn == nil {
+			return common.Address{}, nil, errMissingValidatorKey
+		}
+		return val, signFn, nil
+	}
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if key != c.consensusKey || c.consensusSignFn == nil {
+		return common.Address{}, nil, errMissingConsensusKey
+	}
+	return key, c.consensusSignFn, nil
+}
+
+// canSignSysTx returns whether the local validator may sign system transactions,
+// with its own key or its consensus key.
+func (c *Congress) canSignSysTx() bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.signTxFn != nil || c.consensusSignTxFn != nil
+}
+
+// sysTxSigner returns the account signing the system transactions of the local
+// validator in the block, along with its signing function: its consensus key
+// from the consensus key fork once registered, the validator itself otherwise.
+func (c *Congress) sysTxSigner(chain consensus.ChainHeaderReader, header *types.Header) (common.Address, SignTxFn, error) {
+	c.lock.RLock()
+	val, signTxFn, key, keyTxFn := c.validator, c.signTxFn, c.consensusKey, c.consensusSignTxFn
+	c.lock.RUnlock()
+
+	number := header.Number.Uint64()
+	if c.config.IsConsensusKey(number) {
+		snap, err := c.snapshot(chain, number-1, header.ParentHash, nil)
+		if err != nil {
+			return common.Address{}, nil, err
+		}
+		if signer := snap.signingKey(val); signer != val {
+			if signer != key || keyTxFn == nil {
+				return common.Address{}, nil, errMissingConsensusKey
+			}
+			return key, keyTxFn, nil
+		}
+	}
+	if signTxFn == nil {
+		return common.Address{}, nil, errMissingValidatorKey
+	}
+	return val, signTxFn, nil
+}
+
+// isSysTxSender returns whether the account may send the system transactions of
+// the block: its coinbase, or from the consensus key fork the consensus key that
+// sealed the block on behalf of the coinbase.
+func (c *Congress) isSysTxSender(sender common.Address, header *types.Header) bool {
+	if sender == header.Coinbase {
+		return true
+	}
+	if !c.config.IsConsensusKey(header.Number.Uint64()) {
+		return false
+	}
+	signer, err := ecrecover(header, c.signatures)
+	return err == nil && signer == sender
+}
+
+// checkpointEntries returns the number of entries listed in the extra-data of a
+// checkpoint at the given height, validators and their keys being distinct
+// entries from the consensus key fork.
+func checkpointEntries(config *params.CongressConfig, number uint64, extra []byte) (int, bool) {
+	size := common.AddressLength
+	if config.IsConsensusKey(number) {
+		size *= 2
+	}
+	length := len(extra) - extraVanity - extraSeal
+	return length / size, length%size == 0
+}
+
+// parseCheckpoint returns the validators listed in the extra-data of a checkpoint
+// header, followed from the consensus key fork by the key of each validator.
+func parseCheckpoint(config *params.CongressConfig, header *types.Header) ([]common.Address, []common.Address) {
+	count, _ := checkpointEntries(config, header.Number.Uint64(), header.Extra)
+
+	validators := make([]common.Address, count)
+	for i := 0; i < len(validators); i++ {
+		copy(validators[i][:], header.Extra[extraVanity+i*common.AddressLength:])
+	}
+	if !config.IsConsensusKey(header.Number.Uint64()) {
+		return validators, nil
+	}
+	keys := make([]common.Address, count)
+	for i := 0; i < len(keys); i++ {
+		copy(keys[i][:], header.Extra[extraVanity+(count+i)*common.AddressLength:])
+	}
+	return validators, keys
+}
+
+// checkpointBytes encodes the validators of a checkpoint, followed by their keys
+// if any, as listed in its extra-data.
+func checkpointBytes(validators []common.Address, keys []common.Address) []byte {
+	blob := make([]byte, 0, (len(validators)+len(keys))*common.AddressLength)
+	for _, validator := range validators {
+		blob = append(blob, validator.Bytes()...)
+	}
+	for _, key := range keys {
+		blob = append(blob, key.Bytes()...)
+	}
+	return blob
+}
+
+// getConsensusKeys returns the keys of the validators of the checkpoint, as set
+// and confirmed in the validators contract in the state of its parent. A
+// validator signs itself if it didn't set a key, if the key didn't confirm it,
+// or if its key is already taken by a validator address.
+func (c *Congress) getConsensusKeys(chain consensus.ChainHeaderReader, header *types.Header, validators []common.Address) ([]common.Address, error) {
+	parent := chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	if parent == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	statedb, err := c.stateFn(parent.Root)
+	if err != nil {
+		return nil, err
+	}
+	contract := systemcontract.GetValidatorAddr(parent.Number, c.chainConfig)
+	return assignConsensusKeys(validators, func(validator common.Address) common.Address {
+		return systemcontract.ConfirmedConsensusKey(statedb, *contract, validator)
+	}), nil
+}
+
+// assignConsensusKeys returns the keys of the validators given the keys they set,
+// so that no two validators sign with the same key. As a key confirms a single
+// validator, only a validator address can be claimed twice.
+func assignConsensusKeys(validators []common.Address, keyOf func(common.Address) common.Address) []common.Address {
+	taken := make(map[common.Address]bool, len(validators))
+	for _, validator := range validators {
+		taken[validator] = true
+	}
+	keys := make([]common.Address, len(validators))
+	for i, validator := range validators {
+		key := keyOf(validator)
+		if key == (common.Address{}) || taken[key] {
+			key = validator
+		}
+		taken[key] = true
+		keys[i] = key
+	}
+	return keys
+}
+
+// consensusKeys maps the validators of a checkpoint to their keys, leaving out
+// the validators signing themselves.
+func consensusKeys(validators []common.Address, keys []common.Address) map[common.Address]common.Address {
+	mapping := make(map[common.Address]common.Address)
+	for i, key := range keys {
+		if key != validators[i] {
+			mapping[validators[i]] = key
+		}
+	}
+	return mapping
+}
+
+// evidenceValidator returns the validator that sealed a header of a verified
+// double-sign evidence: its coinbase from the consensus key fork, its signer
+// before it.
+func (c *Congress) evidenceValidator(header *types.Header) (common.Address, error) {
+	if c.config.IsConsensusKey(header.Number.Uint64()) {
+		return header.Coinbase, nil
+	}
+	return ecrecover(header, c.signatures)
+}
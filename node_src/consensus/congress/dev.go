@@ -0,0 +1,105 @@
+package congress
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// errEmptyRequestNonZeroPeriod is returned if empty blocks are requested on a
+// chain sealing them anyway.
+var errEmptyRequestNonZeroPeriod = errors.New("empty blocks are only requested on 0-period chains")
+
+// devControls are the controls of the clock and of the sealing of the engine on
+// developer chains, set through the dev RPCs.
+type devControls struct {
+	offset int64  // Seconds the clock of the engine is shifted by
+	next   uint64 // Timestamp of the next sealed block, 0 if not set
+	empty  int    // Number of empty blocks still requested on 0-period chains
+}
+
+// now returns the time of the clock of the engine.
+func (c *Congress) now() time.Time {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return time.Now().Add(time.Duration(c.dev.offset) * time.Second)
+}
+
+// TimeOffset returns the number of seconds the clock of the engine is shifted by.
+func (c *Congress) TimeOffset() int64 {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.dev.offset
+}
+
+// SetTimeOffset shifts the clock of the engine by the given number of seconds,
+// forgetting the timestamp set for the next block.
+func (c *Congress) SetTimeOffset(offset int64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.dev.offset, c.dev.next = offset, 0
+}
+
+// IncreaseTime moves the clock of the engine forward and returns the number of
+// seconds it is shifted by.
+func (c *Congress) IncreaseTime(seconds uint64) int64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.dev.offset += int64(seconds)
+	return c.dev.offset
+}
+
+// SetNextBlockTimestamp makes the next sealed block carry the given timestamp,
+// and shifts the clock of the engine so the blocks after it follow on from it.
+// The timestamp must be past the one of the current head by the block period.
+func (c *Congress) SetNextBlockTimestamp(timestamp uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.dev.offset, c.dev.next = int64(timestamp)-time.Now().Unix(), timestamp
+}
+
+// RequestEmptyBlocks makes a 0-period chain seal the given number of blocks even
+// if they have no transactions, dropping any previous request.
+func (c *Congress) RequestEmptyBlocks(n int) error {
+	if c.config.Period != 0 {
+		return errEmptyRequestNonZeroPeriod
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.dev.empty = n
+	return nil
+}
+
+// emptyRequested returns whether empty blocks are still requested.
+func (c *Congress) emptyRequested() bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.dev.empty > 0
+}
+
+// consumeDevControls is called right before the header is signed. It consumes
+// the timestamp set for the header, and one of the requested empty blocks if the
+// header is one, returning false if there are none left.
+func (c *Congress) consumeDevControls(header *types.Header, empty bool) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if empty {
+		if c.dev.empty == 0 {
+			return false
+		}
+		c.dev.empty--
+	}
+	if c.dev.next == header.Time {
+		c.dev.next = 0
+	}
+	return true
+}
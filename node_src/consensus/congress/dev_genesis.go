@@ -0,0 +1,46 @@
+package congress
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/congress/systemcontract"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// DeveloperGenesisBlock returns the 'geth --dev --dev.congress' genesis block,
+// with the faucet as the single validator and the admin of the system contracts.
+// RedCoast and Sophon are active from block 1, the genesis system contracts being
+// initialized before their upgrades.
+func DeveloperGenesisBlock(period uint64, gasLimit uint64, faucet common.Address) *core.Genesis {
+	config := *params.AllCliqueProtocolChanges
+	config.Clique = nil
+	config.RedCoastBlock = big.NewInt(1)
+	config.SophonBlock = big.NewInt(1)
+	config.Congress = &params.CongressConfig{
+		Period: period,
+		Epoch:  200,
+
+		EnableDevVerification: true,
+
+		Admin: &faucet,
+	}
+	// Assemble and return the genesis with the system contracts, the precompiles
+	// and the faucet pre-funded
+	alloc := systemcontract.GenesisContracts()
+	for i := byte(1); i <= 9; i++ {
+		alloc[common.BytesToAddress([]byte{i})] = core.GenesisAccount{Balance: big.NewInt(1)}
+	}
+	alloc[faucet] = core.GenesisAccount{Balance: new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(9))}
+
+	return &core.Genesis{
+		Config:     &config,
+		ExtraData:  append(append(make([]byte, extraVanity), faucet[:]...), make([]byte, crypto.SignatureLength)...),
+		GasLimit:   gasLimit,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		Difficulty: big.NewInt(1),
+		Alloc:      alloc,
+	}
+}
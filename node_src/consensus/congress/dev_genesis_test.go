@@ -0,0 +1,153 @@
+package congress
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/congress/systemcontract"
+	"github.com/ethereum/go-ethereum/consensus/misc"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// mineBlock builds a block with the given transactions on top of the head of
+// the chain, seals it with the authorized validator and imports it.
+func mineBlock(t *testing.T, chain *core.BlockChain, engine *Congress, txs []*types.Transaction) *types.Block {
+	parent := chain.CurrentBlock()
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number(), common.Big1),
+		GasLimit:   parent.GasLimit(),
+		BaseFee:    misc.CalcBaseFee(chain.Config(), parent.Header()),
+	}
+	if err := engine.Prepare(chain, header); err != nil {
+		t.Fatalf("failed to prepare header %d: %v", header.Number, err)
+	}
+	statedb, err := chain.StateAt(parent.Root())
+	if err != nil {
+		t.Fatalf("failed to load parent state: %v", err)
+	}
+	if err := engine.PreHandle(chain, header, statedb); err != nil {
+		t.Fatalf("failed to apply forks of block %d: %v", header.Number, err)
+	}
+	var (
+		gp       = new(core.GasPool).AddGas(header.GasLimit)
+		receipts []*types.Receipt
+	)
+	for i, tx := range txs {
+		statedb.Prepare(tx.Hash(), i)
+		receipt, err := core.ApplyTransaction(chain.Config(), chain, &header.Coinbase, gp, statedb, header, tx, &header.GasUsed, vm.Config{}, nil)
+		if err != nil {
+			t.Fatalf("failed to apply transaction: %v", err)
+		}
+		receipts = append(receipts, receipt)
+	}
+	block, _, err := engine.FinalizeAndAssemble(chain, header, statedb, txs, nil, receipts)
+	if err != nil {
+		t.Fatalf("failed to finalize block %d: %v", header.Number, err)
+	}
+	results := make(chan *types.Block, 1)
+	if err := engine.Seal(chain, block, results, nil); err != nil {
+		t.Fatalf("failed to seal block %d: %v", header.Number, err)
+	}
+	select {
+	case block = <-results:
+	case <-time.After(time.Second):
+		t.Fatalf("block %d not sealed", header.Number)
+	}
+	if _, err := chain.InsertChain(types.Blocks{block}); err != nil {
+		t.Fatalf("failed to import block %d: %v", header.Number, err)
+	}
+	return block
+}
+
+// newDeveloperChain creates a developer chain sealed by the given key.
+func newDeveloperChain(t *testing.T, key *ecdsa.PrivateKey) (*core.BlockChain, *Congress) {
+	developer := crypto.PubkeyToAddress(key.PublicKey)
+	genesis := DeveloperGenesisBlock(0, 11500000, developer)
+
+	db := rawdb.NewMemoryDatabase()
+	genesis.MustCommit(db)
+
+	engine := New(genesis.Config, db)
+	engine.Authorize(developer, func(account accounts.Account, mimeType string, message []byte) ([]byte, error) {
+		return crypto.Sign(crypto.Keccak256(message), key)
+	}, nil)
+	chain, err := core.NewBlockChain(db, nil, genesis.Config, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	return chain, engine
+}
+
+func TestDeveloperGenesis(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	chain, engine := newDeveloperChain(t, key)
+	defer chain.Stop()
+
+	// The empty block 1 is sealed on demand, setting up the system contracts of
+	// both RedCoast and Sophon
+	mineBlock(t, chain, engine, nil)
+	statedb, _ := chain.State()
+	for addr, fork := range map[common.Address]string{
+		systemcontract.SysGovContractAddr:       systemcontract.ForkRedCoast,
+		systemcontract.AddressListContractAddr:  systemcontract.ForkSophon,
+		systemcontract.ValidatorsV1ContractAddr: systemcontract.ForkSophon,
+		systemcontract.PunishV1ContractAddr:     systemcontract.ForkRedCoast,
+	} {
+		expected := systemcontract.ExpectedCode(chain.Config(), addr, common.Big1)
+		if expected == nil || expected.Fork != fork || expected.Block.Cmp(common.Big1) != 0 {
+			t.Fatalf("system contract %x: expected code mismatch: have %+v, want %s fork at block 1", addr, expected, fork)
+		}
+		if have := statedb.GetCodeHash(addr); have != expected.Hash {
+			t.Errorf("system contract %x: code hash mismatch: have %x, want %x", addr, have, expected.Hash)
+		}
+	}
+	header := chain.CurrentHeader()
+	engine.SetStateFn(chain.StateAt)
+	validators, err := engine.getTopValidators(chain, &types.Header{ParentHash: header.Hash(), Number: new(big.Int).Add(header.Number, common.Big1)})
+	if err != nil {
+		t.Fatalf("failed to get top validators: %v", err)
+	}
+	if developer := crypto.PubkeyToAddress(key.PublicKey); len(validators) != 1 || validators[0] != developer {
+		t.Errorf("validators mismatch: have %x, want [%x]", validators, developer)
+	}
+	if !engine.settingUp(header.Number) {
+		t.Errorf("empty blocks sealed after the system contracts are set up")
+	}
+	if engine.settingUp(new(big.Int).Add(header.Number, common.Big1)) {
+		t.Errorf("empty blocks not sealed while the system contracts are set up")
+	}
+	// The developer administers the address list
+	developer, other := crypto.PubkeyToAddress(key.PublicKey), common.Address{0xde, 0xad}
+	data := append(common.FromHex("0x22fbf1e8"), common.LeftPadBytes(other.Bytes(), 32)...) // addDeveloper(address)
+	tx, err := types.SignTx(types.NewTx(&types.LegacyTx{
+		Nonce:    statedb.GetNonce(developer),
+		To:       &systemcontract.AddressListContractAddr,
+		Gas:      100000,
+		GasPrice: new(big.Int).Mul(header.BaseFee, common.Big2),
+		Data:     data,
+	}), types.LatestSigner(chain.Config()), key)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	block := mineBlock(t, chain, engine, []*types.Transaction{tx})
+	if receipts := chain.GetReceiptsByHash(block.Hash()); len(receipts) != 1 || receipts[0].Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("developer transaction failed")
+	}
+	statedb, _ = chain.State()
+	ret, err := engine.commonCallContract(block.Header(), statedb, engine.abi[systemcontract.AddressListContractName], systemcontract.AddressListContractAddr, "isDeveloper", 1, other)
+	if err != nil {
+		t.Fatalf("failed to query developers: %v", err)
+	}
+	if ok, _ := ret[0].(bool); !ok {
+		t.Errorf("developer not added by the admin")
+	}
+}
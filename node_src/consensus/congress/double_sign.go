@@ -0,0 +1,404 @@
+package congress
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/congress/systemcontract"
+	"github.com/ethereum/go-ethereum/consensus/congress/vmcaller"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	inmemorySignedHeaders = 4096 // Number of recent signed headers to keep for double-sign detection
+	maxEvidencePerBlock   = 4    // Maximum number of double-sign evidences submitted in a single block
+)
+
+var (
+	// errInvalidEvidence is returned if a double-sign evidence doesn't prove that
+	// the same validator signed two different headers at the same height.
+	errInvalidEvidence = errors.New("invalid double sign evidence")
+
+	// errTooManyEvidences is returned if a block contains more double-sign
+	// evidences than allowed.
+	errTooManyEvidences = errors.New("too many double sign evidences")
+
+	// errEvidenceSubmitted is returned if the double-sign of a validator at a
+	// given height has already been punished.
+	errEvidenceSubmitted = errors.New("double sign evidence already submitted")
+
+	// evidencePrefix is the database prefix of the detected double-sign evidences.
+	evidencePrefix = []byte("congress-evidence-")
+)
+
+// DoubleSignEvidence is the self-contained proof that a validator sealed two
+// different headers at the same height on top of the same parent. Sealing
+// again at a height after a reorg changed the parent is no equivocation. The
+// headers are kept in ascending hash order so that both detection orders yield
+// the same evidence.
+type DoubleSignEvidence struct {
+	HeaderA *types.Header `json:"headerA"`
+	HeaderB *types.Header `json:"headerB"`
+}
+
+// newDoubleSignEvidence creates an evidence from two conflicting headers.
+func newDoubleSignEvidence(a, b *types.Header) *DoubleSignEvidence {
+	if ha, hb := a.Hash(), b.Hash(); bytes.Compare(ha[:], hb[:]) > 0 {
+		a, b = b, a
+	}
+	return &DoubleSignEvidence{HeaderA: types.CopyHeader(a), HeaderB: types.CopyHeader(b)}
+}
+
+// Hash returns the unique identifier of the evidence.
+func (e *DoubleSignEvidence) Hash() common.Hash {
+	blob, _ := rlp.EncodeToBytes(e)
+	return crypto.Keccak256Hash(blob)
+}
+
+// Number returns the height at which the validator double signed.
+func (e *DoubleSignEvidence) Number() uint64 {
+	return e.HeaderA.Number.Uint64()
+}
+
+// slashed returns whether the double sign of the validator at the given height
+// has already been slashed, as recorded by the slashing contract.
+func slashed(state *state.StateDB, validator common.Address, number uint64) bool {
+	return state.GetState(systemcontract.SlashingContractAddr, systemcontract.DoubleSignSlot(validator, number)) != (common.Hash{})
+}
+
+// signedKey identifies the header sealed by a validator at some height on top
+// of some parent.
+type signedKey struct {
+	validator common.Address
+	number    uint64
+	parent    common.Hash
+}
+
+// evidenceStore keeps track of the recently sealed headers and the detected
+// double-sign evidences.
+type evidenceStore struct {
+	db        ethdb.Database
+	signed    *lru.ARCCache // Recently seen headers by (validator, number)
+	evidences map[common.Hash]*DoubleSignEvidence
+	lock      sync.RWMutex
+}
+
+// newEvidenceStore creates an evidence store, loading the evidences previously
+// detected from the database.
+func newEvidenceStore(db ethdb.Database) *evidenceStore {
+	signed, _ := lru.NewARC(inmemorySignedHeaders)
+	store := &evidenceStore{
+		db:        db,
+		signed:    signed,
+		evidences: make(map[common.Hash]*DoubleSignEvidence),
+	}
+	if db == nil {
+		return store
+	}
+	it := db.NewIterator(evidencePrefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		evidence := new(DoubleSignEvidence)
+		if err := rlp.DecodeBytes(it.Value(), evidence); err != nil {
+			log.Warn("Failed to decode double sign evidence", "key", it.Key(), "err", err)
+			continue
+		}
+		store.evidences[evidence.Hash()] = evidence
+	}
+	return store
+}
+
+// check records a verified header sealed by the validator and returns a new
+// evidence if the validator already sealed a different header at this height,
+// on top of the same parent.
+func (s *evidenceStore) check(header *types.Header, validator common.Address) *DoubleSignEvidence {
+	key := signedKey{validator: validator, number: header.Number.Uint64(), parent: header.ParentHash}
+	prev, ok := s.signed.Get(key)
+	if !ok {
+		s.signed.Add(key, types.CopyHeader(header))
+		return nil
+	}
+	if SealHash(prev.(*types.Header)) == SealHash(header) {
+		return nil
+	}
+	evidence := newDoubleSignEvidence(prev.(*types.Header), header)
+	hash := evidence.Hash()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.evidences[hash]; ok {
+		return nil
+	}
+	s.evidences[hash] = evidence
+	if s.db != nil {
+		blob, err := rlp.EncodeToBytes(evidence)
+		if err != nil {
+			log.Error("Failed to encode double sign evidence", "err", err)
+		} else if err := s.db.Put(append(evidencePrefix, hash[:]...), blob); err != nil {
+			log.Error("Failed to store double sign evidence", "err", err)
+		}
+	}
+	return evidence
+}
+
+// list returns all the detected evidences, ordered by height.
+func (s *evidenceStore) list() []*DoubleSignEvidence {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	list := make([]*DoubleSignEvidence, 0, len(s.evidences))
+	for _, evidence := range s.evidences {
+		list = append(list, evidence)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Number() != list[j].Number() {
+			return list[i].Number() < list[j].Number()
+		}
+		hi, hj := list[i].Hash(), list[j].Hash()
+		return bytes.Compare(hi[:], hj[:]) < 0
+	})
+	return list
+}
+
+// detectDoubleSign checks a header sealed by an authorized validator against
+// the ones seen before, recording an evidence on equivocation.
+func (c *Congress) detectDoubleSign(header *types.Header, validator common.Address) {
+	if evidence := c.evidences.check(header, validator); evidence != nil {
+		log.Warn("Detected double sign", "validator", validator, "number", header.Number,
+			"hashA", evidence.HeaderA.Hash(), "hashB", evidence.HeaderB.Hash())
+	}
+}
+
+// verifyDoubleSignEvidence checks that the two headers of the evidence are
+// different, at the same height on top of the same parent and sealed by a
+// validator authorized at that height on the given chain. It returns the
+// offending validator.
+func (c *Congress) verifyDoubleSignEvidence(chain consensus.ChainHeaderReader, evidence *DoubleSignEvidence) (common.Address, error) {
+	a, b := evidence.HeaderA, evidence.HeaderB
+	if a == nil || b == nil || a.Number == nil || b.Number == nil {
+		return common.Address{}, errInvalidEvidence
+	}
+	if a.Number.Cmp(b.Number) != 0 || a.Number.Sign() <= 0 || a.ParentHash != b.ParentHash {
+		return common.Address{}, errInvalidEvidence
+	}
+	if len(a.Extra) < extraSeal || len(b.Extra) < extraSeal {
+		return common.Address{}, errMissingSignature
+	}
+	// Two signatures over the same content are no equivocation
+	if SealHash(a) == SealHash(b) {
+		return common.Address{}, errInvalidEvidence
+	}
+	signerA, err := ecrecover(a, c.signatures)
+	if err != nil {
+		return common.Address{}, err
+	}
+	signerB, err := ecrecover(b, c.signatures)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if signerA != signerB {
+		return common.Address{}, errInvalidEvidence
+	}
+	// The validator set is the one of the common parent, which may be off the
+	// canonical chain
+	number := a.Number.Uint64()
+	if chain.GetHeader(a.ParentHash, number-1) == nil {
+		return common.Address{}, errUnknownBlock
+	}
+	snap, err := c.snapshot(chain, number-1, a.ParentHash, nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+	// From the consensus key fork the validator is the coinbase of both headers,
+	// signing with its consensus key
+	validator := signerA
+	if c.config.IsConsensusKey(number) {
+		if a.Coinbase != b.Coinbase || snap.signingKey(a.Coinbase) != signerA {
+			return common.Address{}, errInvalidEvidence
+		}
+		validator = a.Coinbase
+	}
+	if _, ok := snap.Validators[validator]; !ok {
+		return common.Address{}, errUnauthorizedValidator
+	}
+	return validator, nil
+}
+
+// isDoubleSignSysTx checks whether the transaction is a double-sign evidence
+// submission, which is sent by the miner to the slashing contract for free. Any
+// transaction of the miner to the slashing contract is one, so that the miner
+// can't slash without evidence through a normal transaction.
+func (c *Congress) isDoubleSignSysTx(sender common.Address, tx *types.Transaction, header *types.Header) bool {
+	if !c.chainConfig.IsSlashing(header.Number) || tx.To() == nil {
+		return false
+	}
+	return c.isSysTxSender(sender, header) && *tx.To() == systemcontract.SlashingContractAddr
+}
+
+// submitDoubleSignEvidences creates, signs and applies the system transactions
+// submitting the pending double-sign evidences to the slashing contract.
+func (c *Congress) submitDoubleSignEvidences(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, totalTxIndex int) ([]*types.Transaction, []*types.Receipt) {
+	var (
+		txs      []*types.Transaction
+		receipts []*types.Receipt
+	)
+	signer, signTxFn, err := c.sysTxSigner(chain, header)
+	if err != nil {
+		log.Warn("Failed to sign double sign evidence", "err", err)
+		return txs, receipts
+	}
+	for _, evidence := range c.evidences.list() {
+		if len(txs) >= maxEvidencePerBlock {
+			break
+		}
+		validator, err := c.verifyDoubleSignEvidence(chain, evidence)
+		if err != nil {
+			continue
+		}
+		if slashed(state, validator, evidence.Number()) {
+			continue
+		}
+		data, err := rlp.EncodeToBytes(evidence)
+		if err != nil {
+			continue
+		}
+		nonce := state.GetNonce(signer)
+		tx := types.NewTransaction(nonce, systemcontract.SlashingContractAddr, new(big.Int), header.GasLimit, new(big.Int), data)
+		tx, err = signTxFn(accounts.Account{Address: signer}, tx, chain.Config().ChainID)
+		if err != nil {
+			log.Warn("Failed to sign double sign evidence", "err", err)
+			return txs, receipts
+		}
+		// Only include the evidence if the punishment goes through in this block
+		if receipt := c.applyDoubleSignEvidence(chain, header, state.Copy(), validator, evidence, totalTxIndex+len(txs), tx.Hash(), common.Hash{}); receipt.Status != types.ReceiptStatusSuccessful {
+			continue
+		}
+		state.SetNonce(signer, nonce+1)
+		receipt := c.applyDoubleSignEvidence(chain, header, state, validator, evidence, totalTxIndex+len(txs), tx.Hash(), common.Hash{})
+		log.Info("Submitted double sign evidence", "validator", validator, "number", evidence.Number(), "tx", tx.Hash())
+		txs = append(txs, tx)
+		receipts = append(receipts, receipt)
+	}
+	return txs, receipts
+}
+
+// replayDoubleSignEvidence verifies and applies a double-sign evidence system
+// transaction included in an imported block.
+func (c *Congress) replayDoubleSignEvidence(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, totalTxIndex int, tx *types.Transaction) (*types.Receipt, error) {
+	if *tx.To() != systemcontract.SlashingContractAddr || tx.GasPrice().Sign() != 0 {
+		return nil, errInvalidEvidence
+	}
+	evidence := new(DoubleSignEvidence)
+	if err := rlp.DecodeBytes(tx.Data(), evidence); err != nil {
+		return nil, err
+	}
+	validator, err := c.verifyDoubleSignEvidence(chain, evidence)
+	if err != nil {
+		return nil, err
+	}
+	if slashed(state, validator, evidence.Number()) {
+		return nil, errEvidenceSubmitted
+	}
+	sender, err := types.Sender(c.signer, tx)
+	if err != nil {
+		return nil, err
+	}
+	nonce := state.GetNonce(sender)
+	state.SetNonce(sender, nonce+1)
+
+	receipt := c.applyDoubleSignEvidence(chain, header, state, validator, evidence, totalTxIndex, tx.Hash(), header.Hash())
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, errInvalidEvidence
+	}
+	return receipt, nil
+}
+
+// applyDoubleSignEvidence records the slash of the offending validator in the
+// slashing contract, refused if already slashed at that height, and punishes it
+// through the Punish contract.
+func (c *Congress) applyDoubleSignEvidence(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, validator common.Address, evidence *DoubleSignEvidence, totalTxIndex int, txHash, bHash common.Hash) *types.Receipt {
+	state.Prepare(txHash, totalTxIndex)
+	err := c.executeDoubleSignEvidence(chain, header, state, systemcontract.SlashingContractName, &systemcontract.SlashingContractAddr, "slash", validator, new(big.Int).SetUint64(evidence.Number()))
+	if err == nil {
+		err = c.executeDoubleSignEvidence(chain, header, state, systemcontract.PunishContractName, systemcontract.GetPunishAddr(header.Number, c.chainConfig), "punish", validator)
+	}
+	receipt := types.NewReceipt([]byte{}, err != nil, header.GasUsed)
+	receipt.Logs = state.GetLogs(txHash, bHash)
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	receipt.TxHash = txHash
+	receipt.BlockHash = bHash
+	receipt.BlockNumber = header.Number
+	receipt.TransactionIndex = uint(state.TxIndex())
+
+	log.Info("applyDoubleSignEvidence", "validator", validator, "number", evidence.Number(), "txHash", txHash.String(), "err", err)
+	return receipt
+}
+
+// executeDoubleSignEvidence calls the system contract on behalf of the miner.
+func (c *Congress) executeDoubleSignEvidence(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, contract string, addr *common.Address, method string, args ...interface{}) error {
+	data, err := c.abi[contract].Pack(method, args...)
+	if err != nil {
+		return err
+	}
+	msg := vmcaller.NewLegacyMessage(header.Coinbase, addr, state.GetNonce(header.Coinbase), new(big.Int), math.MaxUint64, new(big.Int), data, false)
+	_, err = vmcaller.ExecuteMsg(msg, state, header, newChainContext(chain, c), c.chainConfig)
+	return err
+}
+
+// applySysEvidenceTx applies a double-sign evidence system transaction using a
+// given evm, for tracing purposes.
+func (c *Congress) applySysEvidenceTx(evm *vm.EVM, state *state.StateDB, txIndex int, sender common.Address, tx *types.Transaction) (ret []byte, vmerr error, err error) {
+	evidence := new(DoubleSignEvidence)
+	if err = rlp.DecodeBytes(tx.Data(), evidence); err != nil {
+		return
+	}
+	if evidence.HeaderA == nil || evidence.HeaderA.Number == nil || len(evidence.HeaderA.Extra) < extraSeal {
+		err = errInvalidEvidence
+		return
+	}
+	validator, err := c.evidenceValidator(evidence.HeaderA)
+	if err != nil {
+		return
+	}
+	evm.Context.ExtraValidator = nil
+	nonce := evm.StateDB.GetNonce(sender)
+	evm.StateDB.SetNonce(sender, nonce+1)
+
+	slash, err := c.abi[systemcontract.SlashingContractName].Pack("slash", validator, new(big.Int).SetUint64(evidence.Number()))
+	if err != nil {
+		return
+	}
+	punish, err := c.abi[systemcontract.PunishContractName].Pack("punish", validator)
+	if err != nil {
+		return
+	}
+	state.Prepare(tx.Hash(), txIndex)
+	// The contracts are called by the miner, whichever key signed the transaction
+	miner := evm.Context.Coinbase
+	evm.TxContext = vm.TxContext{
+		Origin:   miner,
+		GasPrice: new(big.Int),
+	}
+	ret, _, vmerr = evm.Call(vm.AccountRef(miner), systemcontract.SlashingContractAddr, slash, tx.Gas(), new(big.Int))
+	if vmerr == nil {
+		ret, _, vmerr = evm.Call(vm.AccountRef(miner), *systemcontract.GetPunishAddr(evm.Context.BlockNumber, c.chainConfig), punish, tx.Gas(), new(big.Int))
+	}
+	state.Finalise(true)
+	return
+}
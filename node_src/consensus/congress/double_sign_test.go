@@ -0,0 +1,187 @@
+package congress
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/congress/slashprotect"
+	"github.com/ethereum/go-ethereum/consensus/congress/systemcontract"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// newSignedHeader creates a header at the given height sealed by the key.
+func newSignedHeader(t *testing.T, key *ecdsa.PrivateKey, parent *types.Header, time uint64) *types.Header {
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		Difficulty: big.NewInt(2),
+		Time:       time,
+		Coinbase:   crypto.PubkeyToAddress(key.PublicKey),
+		Extra:      make([]byte, extraVanity+extraSeal),
+	}
+	sig, err := crypto.Sign(SealHash(header).Bytes(), key)
+	if err != nil {
+		t.Fatalf("failed to sign header: %v", err)
+	}
+	copy(header.Extra[len(header.Extra)-extraSeal:], sig)
+	return header
+}
+
+func TestDoubleSignEvidence(t *testing.T) {
+	config := &params.ChainConfig{ChainID: big.NewInt(1), Congress: &params.CongressConfig{Period: 3, Epoch: 200}}
+	db := rawdb.NewMemoryDatabase()
+	engine := New(config, db)
+	chain := newTestVoteChain(config, 2)
+
+	validator, _ := crypto.GenerateKey()
+	outsider, _ := crypto.GenerateKey()
+	for _, block := range chain.blocks {
+		engine.recents.Add(block.Hash(), newSnapshot(engine.config, engine.signatures, block.NumberU64(), block.Hash(), []common.Address{crypto.PubkeyToAddress(validator.PublicKey)}))
+	}
+	parent := chain.blocks[1].Header()
+	a := newSignedHeader(t, validator, parent, 100)
+	b := newSignedHeader(t, validator, parent, 101)
+
+	// The second different header at the same height is detected
+	if evidence := engine.evidences.check(a, a.Coinbase); evidence != nil {
+		t.Fatalf("evidence detected for first header")
+	}
+	if evidence := engine.evidences.check(a, a.Coinbase); evidence != nil {
+		t.Fatalf("evidence detected for the same header")
+	}
+	evidence := engine.evidences.check(b, b.Coinbase)
+	if evidence == nil {
+		t.Fatalf("double sign not detected")
+	}
+	if engine.evidences.check(b, b.Coinbase) != nil {
+		t.Fatalf("double sign reported twice")
+	}
+	// Sealing the height again on top of another parent, e.g. after a reorg, isn't one
+	reseal := newSignedHeader(t, validator, &types.Header{Number: parent.Number, ParentHash: common.HexToHash("0x01")}, 102)
+	if engine.evidences.check(reseal, reseal.Coinbase) != nil {
+		t.Fatalf("reseal on another parent reported as double sign")
+	}
+	if have := newDoubleSignEvidence(b, a).Hash(); have != evidence.Hash() {
+		t.Fatalf("evidence hash depends on header order: have %x, want %x", have, evidence.Hash())
+	}
+	if have := len(newEvidenceStore(db).list()); have != 1 {
+		t.Fatalf("persisted evidence count mismatch: have %d, want 1", have)
+	}
+	// The evidence is verifiable against the snapshot
+	signer, err := engine.verifyDoubleSignEvidence(chain, evidence)
+	if err != nil {
+		t.Fatalf("failed to verify evidence: %v", err)
+	}
+	if signer != crypto.PubkeyToAddress(validator.PublicKey) {
+		t.Fatalf("validator mismatch: have %x, want %x", signer, crypto.PubkeyToAddress(validator.PublicKey))
+	}
+	// Malformed evidences are rejected
+	tests := []struct {
+		evidence *DoubleSignEvidence
+		err      error
+	}{
+		{&DoubleSignEvidence{HeaderA: a, HeaderB: a}, errInvalidEvidence},
+		{&DoubleSignEvidence{HeaderA: a, HeaderB: newSignedHeader(t, validator, a, 102)}, errInvalidEvidence},
+		{&DoubleSignEvidence{HeaderA: a, HeaderB: newSignedHeader(t, outsider, parent, 101)}, errInvalidEvidence},
+		{newDoubleSignEvidence(newSignedHeader(t, outsider, parent, 100), newSignedHeader(t, outsider, parent, 101)), errUnauthorizedValidator},
+		{&DoubleSignEvidence{HeaderA: a}, errInvalidEvidence},
+		{newDoubleSignEvidence(a, reseal), errInvalidEvidence},
+		{newDoubleSignEvidence(reseal, newSignedHeader(t, validator, &types.Header{Number: parent.Number, ParentHash: common.HexToHash("0x01")}, 103)), errUnknownBlock},
+	}
+	for i, tt := range tests {
+		if _, err := engine.verifyDoubleSignEvidence(chain, tt.evidence); err != tt.err {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
+
+func TestDoubleSignSlashing(t *testing.T) {
+	maker, _ := newTestChainMaker(t, 3, 100, nil)
+	defer maker.Stop()
+
+	if err := maker.MineUntil(3); err != nil {
+		t.Fatalf("failed to set up the system contracts: %v", err)
+	}
+	// The in-turn validator seals two different blocks on top of the head
+	head := maker.Chain().CurrentBlock()
+	offender, _ := maker.InTurn(head.Header())
+	a, err := maker.Generate(head, offender, 0, nil)
+	if err != nil {
+		t.Fatalf("failed to seal block: %v", err)
+	}
+	b, err := maker.Generate(head, offender, 1, nil)
+	if err != nil {
+		t.Fatalf("failed to seal conflicting block: %v", err)
+	}
+	if _, err := maker.Chain().InsertChain(types.Blocks{a}); err != nil {
+		t.Fatalf("failed to import block: %v", err)
+	}
+	maker.Engine().detectDoubleSign(a.Header(), offender)
+	maker.Engine().detectDoubleSign(b.Header(), offender)
+
+	// The next sealer submits the evidence to the slashing contract, which
+	// records the slash, and the offender is punished
+	block, err := maker.Mine()
+	if err != nil {
+		t.Fatalf("failed to mine evidence: %v", err)
+	}
+	if txs := block.Transactions(); len(txs) != 1 || *txs[0].To() != systemcontract.SlashingContractAddr {
+		t.Fatalf("evidence transaction missing: %v", txs)
+	}
+	statedb, _ := maker.Chain().State()
+	if !slashed(statedb, offender, a.NumberU64()) {
+		t.Errorf("slash not recorded")
+	}
+	punish := systemcontract.GetPunishAddr(block.Number(), maker.Chain().Config())
+	ret, err := maker.Engine().commonCallContract(block.Header(), statedb, maker.Engine().abi[systemcontract.PunishContractName], *punish, "getPunishRecord", 1, offender)
+	if err != nil {
+		t.Fatalf("failed to query punish record: %v", err)
+	}
+	if record, _ := ret[0].(*big.Int); record == nil || record.Cmp(common.Big1) != 0 {
+		t.Errorf("punish record mismatch: have %v, want 1", ret[0])
+	}
+	// The same double sign is slashed only once
+	if block, err = maker.Mine(); err != nil {
+		t.Fatalf("failed to mine block: %v", err)
+	}
+	if txs := block.Transactions(); len(txs) != 0 {
+		t.Errorf("evidence submitted again: %v", txs)
+	}
+}
+
+func TestSlashingProtection(t *testing.T) {
+	config := &params.ChainConfig{ChainID: big.NewInt(1), Congress: &params.CongressConfig{Period: 3, Epoch: 200}}
+	engine := New(config, rawdb.NewMemoryDatabase())
+	engine.SetSlashingProtection(slashprotect.New(memorydb.New()))
+
+	key, _ := crypto.GenerateKey()
+	signFn := func(validator accounts.Account, mimeType string, message []byte) ([]byte, error) {
+		return crypto.Sign(crypto.Keccak256(message), key)
+	}
+	validator := crypto.PubkeyToAddress(key.PublicKey)
+	parent := &types.Header{Number: big.NewInt(9)}
+
+	// The same header may be signed again, e.g. after a restart
+	a := newSignedHeader(t, key, parent, 100)
+	for i := 0; i < 2; i++ {
+		if err := engine.signHeader(validator, signFn, a); err != nil {
+			t.Fatalf("failed to sign header: %v", err)
+		}
+	}
+	if signer, err := ecrecover(a, engine.signatures); err != nil || signer != validator {
+		t.Fatalf("signer mismatch: have %x, want %x, err %v", signer, validator, err)
+	}
+	// A different header at the same height is refused
+	b := newSignedHeader(t, key, parent, 101)
+	if err := engine.signHeader(validator, signFn, b); !errors.Is(err, slashprotect.ErrDoubleSign) {
+		t.Errorf("error mismatch: have %v, want %v", err, slashprotect.ErrDoubleSign)
+	}
+}
@@ -0,0 +1,267 @@
+package congress
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/congress/systemcontract"
+	"github.com/ethereum/go-ethereum/consensus/congress/vmcaller"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// errReceiptsMismatch is returned if the number of receipts doesn't match the
+// number of transactions the fees are shared for.
+var errReceiptsMismatch = errors.New("receipts count mismatch")
+
+// maxShareChunk is the largest amount a single distributeBlockReward entry can
+// carry, bigger shares are split into several entries of the same receiver.
+var maxShareChunk = new(big.Int).SetUint64(math.MaxUint64)
+
+// FeeShare is the part of the block fees credited to a single receiver for a
+// single transaction.
+type FeeShare struct {
+	Address common.Address // Receiver of the fee, the zero address for contract creations
+	TxHash  common.Hash    // Transaction paying the fee
+	Amount  *big.Int       // Fee in wei
+}
+
+// txFeeShares attributes the fee paid by every transaction, gas used times the
+// effective tip, to the transaction recipient, and scales the result so that
+// the shares add up exactly to total.
+func txFeeShares(txs []*types.Transaction, receipts []*types.Receipt, baseFee *big.Int, total *big.Int) ([]*FeeShare, error) {
+	if len(txs) != len(receipts) {
+		return nil, errReceiptsMismatch
+	}
+	shares := make([]*FeeShare, len(txs))
+	raw := make([]*big.Int, len(txs))
+	for i, tx := range txs {
+		var to common.Address
+		if tx.To() != nil {
+			to = *tx.To()
+		}
+		raw[i] = new(big.Int).Mul(new(big.Int).SetUint64(receipts[i].GasUsed), tx.EffectiveGasTipValue(baseFee))
+		shares[i] = &FeeShare{Address: to, TxHash: tx.Hash()}
+	}
+	for i, amount := range calcFeeShares(raw, total) {
+		shares[i].Amount = amount
+	}
+	return shares, nil
+}
+
+// callFeeShares attributes the fee paid by every transaction, gas used times
+// the effective tip, to the contracts whose code ran, in proportion to the gas
+// consumed by each of them. Transactions that didn't run any code credit their
+// recipient, or the created contract. The shares are scaled so that they add
+// up exactly to total.
+func callFeeShares(txs []*types.Transaction, receipts []*types.Receipt, baseFee *big.Int, total *big.Int) ([]*FeeShare, error) {
+	if len(txs) != len(receipts) {
+		return nil, errReceiptsMismatch
+	}
+	var (
+		shares []*FeeShare
+		raw    []*big.Int
+	)
+	for i, tx := range txs {
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(receipts[i].GasUsed), tx.EffectiveGasTipValue(baseFee))
+		if len(receipts[i].CodeGas) == 0 {
+			to := receipts[i].ContractAddress
+			if tx.To() != nil {
+				to = *tx.To()
+			}
+			shares = append(shares, &FeeShare{Address: to, TxHash: tx.Hash()})
+			raw = append(raw, fee)
+			continue
+		}
+		gas := make([]*big.Int, len(receipts[i].CodeGas))
+		for j, code := range receipts[i].CodeGas {
+			gas[j] = new(big.Int).SetUint64(code.Gas)
+		}
+		for j, amount := range calcFeeShares(gas, fee) {
+			shares = append(shares, &FeeShare{Address: receipts[i].CodeGas[j].Address, TxHash: tx.Hash()})
+			raw = append(raw, amount)
+		}
+	}
+	for i, amount := range calcFeeShares(raw, total) {
+		shares[i].Amount = amount
+	}
+	return shares, nil
+}
+
+// legacyFeeWeights returns the receivers and weights of the block fees as
+// distributed before the FeeShare fork: the gas limit times the gas price of
+// every transaction, scaled down if they exceed the collected fee.
+func legacyFeeWeights(txs []*types.Transaction, fee *big.Int) ([]common.Address, []uint64) {
+	var (
+		addr        []common.Address
+		gass        []uint64
+		totalGasSum uint64
+	)
+	for _, tx := range txs {
+		if tx.To() == nil {
+			addr = append(addr, common.Address{})
+		} else {
+			addr = append(addr, *tx.To())
+		}
+		gasFee := tx.Gas() * tx.GasPrice().Uint64()
+		gass = append(gass, gasFee)
+
+		// Accumulate gasFee to totalGasSum
+		totalGasSum += gasFee
+	}
+	feeUint64 := fee.Uint64()
+	if totalGasSum > feeUint64 {
+		percentDifference := float64(totalGasSum-feeUint64) / float64(totalGasSum) * 100
+		for i := 0; i < len(gass); i++ {
+			decreaseAmount := uint64(float64(gass[i]) * (percentDifference / 100.0))
+			gass[i] -= decreaseAmount
+		}
+	}
+	return addr, gass
+}
+
+// blockFeeShares returns the shares of the fee collected in a block, following
+// the distribution rule active at the given header.
+//
+// Before the FeeShare fork the validators contract gets relative weights, the
+// shares are then the proportional part of the fee of every weight. No fee is
+// distributed at all for blocks without transactions.
+func (c *Congress) blockFeeShares(header *types.Header, txs []*types.Transaction, receipts []*types.Receipt, fee *big.Int) ([]*FeeShare, error) {
+	if c.chainConfig.IsFeeShare(header.Number) {
+		if fee.Sign() <= 0 {
+			return nil, nil
+		}
+		if c.chainConfig.IsCallFeeShare(header.Number) {
+			return callFeeShares(txs, receipts, header.BaseFee, fee)
+		}
+		return txFeeShares(txs, receipts, header.BaseFee, fee)
+	}
+	if len(txs) == 0 || fee.Sign() <= 0 {
+		return nil, nil
+	}
+	// The validators contract is only sent the weights, the rest of the fee is
+	// left to the coinbase
+	addrs, gass := legacyFeeWeights(txs, fee)
+	var (
+		shares = make([]*FeeShare, 0, len(txs)+1)
+		rest   = new(big.Int).Set(fee)
+	)
+	for i, gas := range gass {
+		amount := new(big.Int).SetUint64(gas)
+		shares = append(shares, &FeeShare{Address: addrs[i], TxHash: txs[i].Hash(), Amount: amount})
+		rest.Sub(rest, amount)
+	}
+	if rest.Sign() > 0 {
+		shares = append(shares, &FeeShare{Amount: rest})
+	}
+	return shares, nil
+}
+
+// calcFeeShares scales the raw fee amounts so that they add up exactly to total.
+//
+// Every amount is first rounded down to raw*total/sum, the wei left over by the
+// rounding is then handed out one by one to the largest remainders, ties going
+// to the lower index. If nothing was paid at all, total is split evenly.
+func calcFeeShares(raw []*big.Int, total *big.Int) []*big.Int {
+	shares := make([]*big.Int, len(raw))
+	if len(raw) == 0 {
+		return shares
+	}
+	sum := new(big.Int)
+	for _, amount := range raw {
+		sum.Add(sum, amount)
+	}
+	if sum.Cmp(total) == 0 {
+		for i, amount := range raw {
+			shares[i] = new(big.Int).Set(amount)
+		}
+		return shares
+	}
+	if sum.Sign() == 0 {
+		quo, rem := new(big.Int).QuoRem(total, big.NewInt(int64(len(raw))), new(big.Int))
+		for i := range shares {
+			shares[i] = new(big.Int).Set(quo)
+			if int64(i) < rem.Int64() {
+				shares[i].Add(shares[i], common.Big1)
+			}
+		}
+		return shares
+	}
+	var (
+		rems     = make([]*big.Int, len(raw))
+		order    = make([]int, len(raw))
+		assigned = new(big.Int)
+	)
+	for i, amount := range raw {
+		shares[i], rems[i] = new(big.Int).QuoRem(new(big.Int).Mul(amount, total), sum, new(big.Int))
+		assigned.Add(assigned, shares[i])
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return rems[order[a]].Cmp(rems[order[b]]) > 0
+	})
+	// The leftover is always less than the number of shares
+	left := new(big.Int).Sub(total, assigned).Int64()
+	for i := int64(0); i < left; i++ {
+		shares[order[i]].Add(shares[order[i]], common.Big1)
+	}
+	return shares
+}
+
+// packFeeShares converts the shares into the receiver and amount arrays of the
+// distributeBlockReward call, splitting amounts that don't fit into an uint64.
+func packFeeShares(shares []*FeeShare) ([]common.Address, []uint64) {
+	var (
+		addrs   []common.Address
+		amounts []uint64
+	)
+	for _, share := range shares {
+		left := new(big.Int).Set(share.Amount)
+		for left.Cmp(maxShareChunk) > 0 {
+			addrs = append(addrs, share.Address)
+			amounts = append(amounts, math.MaxUint64)
+			left.Sub(left, maxShareChunk)
+		}
+		addrs = append(addrs, share.Address)
+		amounts = append(amounts, left.Uint64())
+	}
+	return addrs, amounts
+}
+
+// trySendFeeShares deposits the collected block fees to the validators contract,
+// sharing them by the gas actually used by every transaction, or by every code
+// executed after the CallFeeShare fork.
+func (c *Congress) trySendFeeShares(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, txs []*types.Transaction, receipts []*types.Receipt) error {
+	fee := state.GetBalance(consensus.FeeRecoder)
+	if fee.Sign() <= 0 || len(txs) == 0 {
+		return nil
+	}
+	shares, err := c.blockFeeShares(header, txs, receipts, fee)
+	if err != nil {
+		return err
+	}
+	addrs, amounts := packFeeShares(shares)
+
+	// Miner will send tx to deposit block fees to contract, add to his balance first.
+	state.AddBalance(header.Coinbase, fee)
+	// Reset fee
+	state.SetBalance(consensus.FeeRecoder, common.Big0)
+
+	data, err := c.abi[systemcontract.ValidatorsContractName].Pack("distributeBlockReward", addrs, amounts)
+	if err != nil {
+		log.Error("Can't pack data for distributeBlockReward", "err", err)
+		return err
+	}
+	// The shares add up to the fee, which is handed over as the share total
+	msg := vmcaller.NewLegacyMessage(header.Coinbase, systemcontract.GetValidatorAddr(header.Number, c.chainConfig), state.GetNonce(header.Coinbase), fee, math.MaxUint64, new(big.Int).Set(fee), data, true)
+	if _, err := vmcaller.ExecuteMsg(msg, state, header, newChainContext(chain, c), c.chainConfig); err != nil {
+		log.Warn("Failed to distribute block fees", "number", header.Number, "fee", fee, "err", err)
+		return err
+	}
+	return nil
+}
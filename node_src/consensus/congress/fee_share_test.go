@@ -0,0 +1,154 @@
+package congress
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func bigs(values ...int64) []*big.Int {
+	list := make([]*big.Int, len(values))
+	for i, v := range values {
+		list[i] = big.NewInt(v)
+	}
+	return list
+}
+
+func TestCalcFeeShares(t *testing.T) {
+	huge := new(big.Int).Lsh(common.Big1, 100)
+	tests := []struct {
+		raw   []*big.Int
+		total *big.Int
+		want  []*big.Int
+	}{
+		// Nothing to share
+		{raw: nil, total: big.NewInt(10), want: nil},
+		// Exact match is passed through
+		{raw: bigs(3, 5, 2), total: big.NewInt(10), want: bigs(3, 5, 2)},
+		// Scaling down, the leftover goes to the largest remainder
+		{raw: bigs(5, 1, 1), total: big.NewInt(3), want: bigs(2, 1, 0)},
+		// Scaling down with equal remainders, ties go to the lower index
+		{raw: bigs(1, 1, 1), total: big.NewInt(2), want: bigs(1, 1, 0)},
+		// Scaling up
+		{raw: bigs(1, 2), total: big.NewInt(10), want: bigs(3, 7)},
+		// Dust total smaller than the number of shares
+		{raw: bigs(5, 7, 9), total: big.NewInt(1), want: bigs(0, 0, 1)},
+		// Zero total
+		{raw: bigs(5, 7), total: big.NewInt(0), want: bigs(0, 0)},
+		// Zero amounts are never credited while others pay
+		{raw: bigs(0, 3), total: big.NewInt(7), want: bigs(0, 7)},
+		// Nothing paid, the total is split evenly
+		{raw: bigs(0, 0, 0), total: big.NewInt(8), want: bigs(3, 3, 2)},
+		// Amounts beyond 64 bits
+		{raw: []*big.Int{huge, huge, common.Big1}, total: new(big.Int).Add(huge, common.Big1), want: []*big.Int{new(big.Int).Rsh(huge, 1), new(big.Int).Rsh(huge, 1), common.Big1}},
+	}
+	for i, tt := range tests {
+		have := calcFeeShares(tt.raw, tt.total)
+		if len(have) != len(tt.want) {
+			t.Errorf("test %d: share count mismatch: have %d, want %d", i, len(have), len(tt.want))
+			continue
+		}
+		sum := new(big.Int)
+		for j := range have {
+			if have[j].Cmp(tt.want[j]) != 0 {
+				t.Errorf("test %d: share %d mismatch: have %v, want %v", i, j, have[j], tt.want[j])
+			}
+			sum.Add(sum, have[j])
+		}
+		if len(have) > 0 && sum.Cmp(tt.total) != 0 {
+			t.Errorf("test %d: share sum mismatch: have %v, want %v", i, sum, tt.total)
+		}
+	}
+}
+
+func TestTxFeeShares(t *testing.T) {
+	to := common.HexToAddress("0x1000")
+	txs := []*types.Transaction{
+		types.NewTx(&types.LegacyTx{To: &to, Gas: 50000, GasPrice: big.NewInt(10)}),
+		types.NewTx(&types.DynamicFeeTx{To: &to, Gas: 50000, GasFeeCap: big.NewInt(30), GasTipCap: big.NewInt(5)}),
+		types.NewTx(&types.LegacyTx{Gas: 100000, GasPrice: big.NewInt(10)}),
+	}
+	receipts := []*types.Receipt{{GasUsed: 21000}, {GasUsed: 30000}, {GasUsed: 60000}}
+	baseFee := big.NewInt(7)
+
+	// The shares are the gas used times the effective tip, not the gas limit
+	want := []int64{21000 * 3, 30000 * 5, 60000 * 3}
+	total := big.NewInt(want[0] + want[1] + want[2])
+	shares, err := txFeeShares(txs, receipts, baseFee, total)
+	if err != nil {
+		t.Fatalf("failed to share fees: %v", err)
+	}
+	for i, share := range shares {
+		if share.Amount.Int64() != want[i] {
+			t.Errorf("share %d mismatch: have %v, want %v", i, share.Amount, want[i])
+		}
+		if share.TxHash != txs[i].Hash() {
+			t.Errorf("share %d tx hash mismatch: have %x, want %x", i, share.TxHash, txs[i].Hash())
+		}
+	}
+	if shares[0].Address != to || shares[2].Address != (common.Address{}) {
+		t.Errorf("receiver mismatch: have %x and %x", shares[0].Address, shares[2].Address)
+	}
+	if _, err := txFeeShares(txs, receipts[:2], baseFee, total); err != errReceiptsMismatch {
+		t.Errorf("error mismatch: have %v, want %v", err, errReceiptsMismatch)
+	}
+}
+
+func TestPackFeeShares(t *testing.T) {
+	a, b := common.HexToAddress("0xa"), common.HexToAddress("0xb")
+	amount := new(big.Int).SetUint64(math.MaxUint64)
+	amount.Add(amount, amount).Add(amount, common.Big2)
+
+	addrs, amounts := packFeeShares([]*FeeShare{{Address: a, Amount: amount}, {Address: b, Amount: common.Big0}})
+	wantAddrs := []common.Address{a, a, a, b}
+	wantAmounts := []uint64{math.MaxUint64, math.MaxUint64, 2, 0}
+	if len(addrs) != len(wantAddrs) || len(amounts) != len(wantAmounts) {
+		t.Fatalf("entry count mismatch: have %d/%d, want %d", len(addrs), len(amounts), len(wantAddrs))
+	}
+	for i := range addrs {
+		if addrs[i] != wantAddrs[i] || amounts[i] != wantAmounts[i] {
+			t.Errorf("entry %d mismatch: have %x/%d, want %x/%d", i, addrs[i], amounts[i], wantAddrs[i], wantAmounts[i])
+		}
+	}
+}
+
+func TestCallFeeShares(t *testing.T) {
+	var (
+		router  = common.HexToAddress("0x1000")
+		target  = common.HexToAddress("0x2000")
+		eoa     = common.HexToAddress("0x3000")
+		created = common.HexToAddress("0x4000")
+	)
+	txs := []*types.Transaction{
+		types.NewTx(&types.LegacyTx{To: &router, Gas: 100000, GasPrice: big.NewInt(1)}),
+		types.NewTx(&types.LegacyTx{To: &eoa, Gas: 21000, GasPrice: big.NewInt(1)}),
+		types.NewTx(&types.LegacyTx{Gas: 100000, GasPrice: big.NewInt(1)}),
+	}
+	receipts := []*types.Receipt{
+		{GasUsed: 40000, CodeGas: []types.CodeGas{{Address: target, Gas: 15000}, {Address: router, Gas: 5000}}},
+		{GasUsed: 21000},
+		{GasUsed: 60000, ContractAddress: created},
+	}
+	shares, err := callFeeShares(txs, receipts, nil, big.NewInt(40000+21000+60000))
+	if err != nil {
+		t.Fatalf("failed to share fees: %v", err)
+	}
+	want := []struct {
+		addr   common.Address
+		tx     int
+		amount int64
+	}{
+		{target, 0, 30000}, {router, 0, 10000}, {eoa, 1, 21000}, {created, 2, 60000},
+	}
+	if len(shares) != len(want) {
+		t.Fatalf("share count mismatch: have %d, want %d", len(shares), len(want))
+	}
+	for i, w := range want {
+		if shares[i].Address != w.addr || shares[i].TxHash != txs[w.tx].Hash() || shares[i].Amount.Int64() != w.amount {
+			t.Errorf("share %d mismatch: have %x/%x/%v, want %x/%x/%d", i, shares[i].Address, shares[i].TxHash, shares[i].Amount, w.addr, txs[w.tx].Hash(), w.amount)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package congress
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/congress/systemcontract"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	// errNoCongressConfig is returned if a genesis doesn't configure the congress
+	// engine.
+	errNoCongressConfig = errors.New("missing congress config")
+
+	// errDuplicateValidator is returned if the genesis lists a validator twice.
+	errDuplicateValidator = errors.New("duplicate genesis validator")
+)
+
+// GenesisSpec describes the genesis block of a new congress chain.
+type GenesisSpec struct {
+	ChainID    *big.Int
+	Period     uint64 // Number of seconds between blocks
+	Epoch      uint64 // Number of blocks between checkpoints
+	GasLimit   uint64
+	Timestamp  uint64
+	Validators []common.Address            // Validators sealing the first epoch
+	Alloc      map[common.Address]*big.Int // Balances of the prefunded accounts
+
+	// Admin of the system contracts set up by the RedCoast fork (nil = built-in
+	// admin of the network, the testnet one outside of the mainnet)
+	Admin *common.Address
+
+	// Restrict contract deployment to verified developers from the RedCoast fork
+	EnableDevVerification bool
+
+	// Fork schedule of the chain (nil = no fork), the Ethereum forks up to London
+	// being active from the genesis
+	CancunBlock       *big.Int
+	RedCoastBlock     *big.Int
+	SophonBlock       *big.Int
+	SlashingBlock     *big.Int
+	FeeShareBlock     *big.Int
+	CallFeeShareBlock *big.Int
+
+	// Congress engine forks (nil = no fork), see params.CongressConfig
+	ValidatorParamsBlock *big.Int
+	MaxValidators        uint64 // Validator cap from ValidatorParamsBlock (0 = unchanged)
+	EpochOverride        uint64 // Epoch length from ValidatorParamsBlock (0 = unchanged)
+	RecentsBlock         *big.Int
+	ConsensusKeyBlock    *big.Int
+
+	// System contract upgrades scheduled in the config, e.g. the upgrade of the
+	// validators contract required by the consensus key fork
+	Upgrades []params.SysContractUpgrade
+}
+
+// Genesis assembles the genesis block of the spec: the validators are listed in
+// ascending order in the extra-data, between the vanity and the seal, and the
+// system contracts initialized at block 1 are allocated next to the prefunded
+// accounts. The genesis is verified with VerifyGenesis.
+func (s *GenesisSpec) Genesis() (*core.Genesis, error) {
+	validators := make([]common.Address, len(s.Validators))
+	copy(validators, s.Validators)
+	sort.Sort(validatorsAscending(validators))
+
+	config := &params.ChainConfig{
+		ChainID:             s.ChainID,
+		HomesteadBlock:      big.NewInt(0),
+		EIP150Block:         big.NewInt(0),
+		EIP155Block:         big.NewInt(0),
+		EIP158Block:         big.NewInt(0),
+		ByzantiumBlock:      big.NewInt(0),
+		ConstantinopleBlock: big.NewInt(0),
+		PetersburgBlock:     big.NewInt(0),
+		IstanbulBlock:       big.NewInt(0),
+		MuirGlacierBlock:    big.NewInt(0),
+		BerlinBlock:         big.NewInt(0),
+		LondonBlock:         big.NewInt(0),
+		CancunBlock:         s.CancunBlock,
+		RedCoastBlock:       s.RedCoastBlock,
+		SophonBlock:         s.SophonBlock,
+		SlashingBlock:       s.SlashingBlock,
+		FeeShareBlock:       s.FeeShareBlock,
+		CallFeeShareBlock:   s.CallFeeShareBlock,
+		Congress: &params.CongressConfig{
+			Period:                s.Period,
+			Epoch:                 s.Epoch,
+			Admin:                 s.Admin,
+			EnableDevVerification: s.EnableDevVerification,
+			ValidatorParamsBlock:  s.ValidatorParamsBlock,
+			MaxValidators:         s.MaxValidators,
+			EpochOverride:         s.EpochOverride,
+			RecentsBlock:          s.RecentsBlock,
+			ConsensusKeyBlock:     s.ConsensusKeyBlock,
+			Upgrades:              s.Upgrades,
+		},
+	}
+	alloc := systemcontract.GenesisContracts()
+	for addr, balance := range s.Alloc {
+		if _, ok := alloc[addr]; ok {
+			return nil, fmt.Errorf("prefunded account %x is a system contract", addr)
+		}
+		alloc[addr] = core.GenesisAccount{Balance: new(big.Int).Set(balance)}
+	}
+	extra := append(make([]byte, extraVanity), checkpointBytes(validators, nil)...)
+	genesis := &core.Genesis{
+		Config:     config,
+		Timestamp:  s.Timestamp,
+		ExtraData:  append(extra, make([]byte, extraSeal)...),
+		GasLimit:   s.GasLimit,
+		Difficulty: big.NewInt(1),
+		Alloc:      alloc,
+	}
+	if errs := VerifyGenesis(genesis); len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return genesis, nil
+}
+
+// VerifyGenesis checks that a genesis block can start a congress chain, and
+// returns every problem found: in the chain config and its fork schedule, in
+// the validators listed in the extra-data, and in the code of the system
+// contracts initialized at block 1.
+func VerifyGenesis(genesis *core.Genesis) []error {
+	config := genesis.Config
+	if config == nil || config.Congress == nil {
+		return []error{errNoCongressConfig}
+	}
+	var errs []error
+	if config.ChainID == nil {
+		errs = append(errs, errors.New("missing chain ID"))
+	}
+	if config.Clique != nil || config.Ethash != nil {
+		errs = append(errs, errors.New("another consensus engine is configured"))
+	}
+	if forkErrs := verifyGenesisForks(config); len(forkErrs) > 0 {
+		errs = append(errs, forkErrs...)
+	} else if err := config.CheckConfigForkOrder(); err != nil {
+		errs = append(errs, err)
+	}
+	if config.Congress.Epoch == 0 {
+		errs = append(errs, errors.New("zero epoch length"))
+	}
+	if genesis.GasLimit < params.MinGasLimit {
+		errs = append(errs, fmt.Errorf("gas limit %d below minimum %d", genesis.GasLimit, params.MinGasLimit))
+	}
+	if genesis.Mixhash != (common.Hash{}) {
+		errs = append(errs, errInvalidMixDigest)
+	}
+	errs = append(errs, verifyGenesisValidators(config, genesis.ExtraData)...)
+
+	// The system contracts must hold the code the engine initializes
+	contracts := systemcontract.GenesisContracts()
+	for _, addr := range []common.Address{systemcontract.ValidatorsContractAddr, systemcontract.PunishContractAddr, systemcontract.ProposalAddr} {
+		account, ok := genesis.Alloc[addr]
+		if !ok {
+			errs = append(errs, fmt.Errorf("system contract %x not allocated", addr))
+			continue
+		}
+		if !bytes.Equal(account.Code, contracts[addr].Code) {
+			errs = append(errs, fmt.Errorf("system contract %x code mismatch", addr))
+		}
+	}
+	return errs
+}
+
+// verifyGenesisForks checks the schedule of the system contract forks, which
+// PreHandle applies at their exact block, one of them per block: RedCoast sets
+// up its contracts next to the ones initialized at block 1, and Sophon upgrades
+// them at a later block.
+func verifyGenesisForks(config *params.ChainConfig) []error {
+	var errs []error
+	if config.RedCoastBlock != nil && config.RedCoastBlock.Sign() <= 0 {
+		errs = append(errs, fmt.Errorf("redCoastBlock %v before block 1, the system contracts are initialized at block 1", config.RedCoastBlock))
+	}
+	if config.SophonBlock != nil {
+		switch {
+		case config.RedCoastBlock == nil:
+			errs = append(errs, fmt.Errorf("sophonBlock %v without redCoastBlock", config.SophonBlock))
+		case config.SophonBlock.Cmp(config.RedCoastBlock) < 0:
+			errs = append(errs, fmt.Errorf("sophonBlock %v before redCoastBlock %v", config.SophonBlock, config.RedCoastBlock))
+		}
+	}
+	return errs
+}
+
+// verifyGenesisValidators checks the vanity, validators and seal layout of the
+// genesis extra-data, as verifyHeader expects it at checkpoints.
+func verifyGenesisValidators(config *params.ChainConfig, extra []byte) []error {
+	if len(extra) < extraVanity {
+		return []error{errMissingVanity}
+	}
+	if len(extra) < extraVanity+extraSeal {
+		return []error{errMissingSignature}
+	}
+	count, ok := checkpointEntries(config.Congress, 0, extra)
+	if !ok {
+		return []error{errExtraValidators}
+	}
+	limit := maxValidators
+	if config.Congress.MaxValidators != 0 && config.Congress.IsValidatorParams(0) {
+		limit = int(config.Congress.MaxValidators)
+	}
+	if count == 0 || count > limit {
+		return []error{fmt.Errorf("%w: have %d, want 1 to %d", errInvalidValidatorsLength, count, limit)}
+	}
+	var errs []error
+	validators, _ := parseCheckpoint(config.Congress, &types.Header{Number: new(big.Int), Extra: extra})
+	seen := make(map[common.Address]bool)
+	for _, validator := range validators {
+		if validator == (common.Address{}) {
+			errs = append(errs, errors.New("zero address genesis validator"))
+		}
+		if seen[validator] {
+			errs = append(errs, fmt.Errorf("%w: %x", errDuplicateValidator, validator))
+		}
+		seen[validator] = true
+	}
+	return errs
+}
@@ -0,0 +1,139 @@
+package congress
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/congress/systemcontract"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestGenesisSpec(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	validator := crypto.PubkeyToAddress(key.PublicKey)
+	funded := common.HexToAddress("0x1234")
+
+	spec := &GenesisSpec{
+		ChainID:       big.NewInt(28525),
+		Period:        1,
+		Epoch:         100,
+		GasLimit:      30000000,
+		Validators:    []common.Address{validator},
+		Alloc:         map[common.Address]*big.Int{funded: big.NewInt(1e18)},
+		RedCoastBlock: big.NewInt(2),
+		SophonBlock:   big.NewInt(3),
+	}
+	genesis, err := spec.Genesis()
+	if err != nil {
+		t.Fatalf("failed to assemble genesis: %v", err)
+	}
+	if have := genesis.Alloc[funded].Balance; have.Cmp(big.NewInt(1e18)) != 0 {
+		t.Errorf("prefunded balance mismatch: have %v, want %v", have, big.NewInt(1e18))
+	}
+	// The chain seals the block initializing the system contracts
+	db := rawdb.NewMemoryDatabase()
+	genesis.MustCommit(db)
+
+	engine := New(genesis.Config, db)
+	engine.Authorize(validator, func(account accounts.Account, mimeType string, message []byte) ([]byte, error) {
+		return crypto.Sign(crypto.Keccak256(message), key)
+	}, nil)
+	chain, err := core.NewBlockChain(db, nil, genesis.Config, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	mineBlock(t, chain, engine, nil)
+
+	// Broken specs are rejected
+	spec.Validators = []common.Address{validator, validator}
+	if _, err := spec.Genesis(); !errors.Is(err, errDuplicateValidator) {
+		t.Errorf("error mismatch: have %v, want %v", err, errDuplicateValidator)
+	}
+	spec.Validators = []common.Address{validator}
+	spec.Alloc = map[common.Address]*big.Int{systemcontract.PunishContractAddr: big.NewInt(1)}
+	if _, err := spec.Genesis(); err == nil {
+		t.Errorf("system contract prefunding accepted")
+	}
+}
+
+func TestGenesisSpecForks(t *testing.T) {
+	admin := common.HexToAddress("0xad")
+	spec := &GenesisSpec{
+		ChainID:           big.NewInt(28525),
+		Period:            3,
+		Epoch:             100,
+		GasLimit:          30000000,
+		Validators:        []common.Address{common.HexToAddress("0x01")},
+		Admin:             &admin,
+		RedCoastBlock:     big.NewInt(2),
+		SophonBlock:       big.NewInt(3),
+		RecentsBlock:      big.NewInt(50),
+		ConsensusKeyBlock: big.NewInt(200),
+	}
+	// The consensus keys require an upgrade of the validators contract
+	if _, err := spec.Genesis(); err == nil {
+		t.Errorf("consensus key fork accepted without upgrading the validators contract")
+	}
+	spec.Upgrades = []params.SysContractUpgrade{{Block: big.NewInt(200), Address: systemcontract.ValidatorsV1ContractAddr, Code: []byte{0x60, 0x00}}}
+	genesis, err := spec.Genesis()
+	if err != nil {
+		t.Fatalf("failed to assemble genesis: %v", err)
+	}
+	if have := genesis.Config.Congress.Admin; have == nil || *have != admin {
+		t.Errorf("admin mismatch: have %v, want %v", have, admin)
+	}
+	if have := genesis.Config.Congress.ConsensusKeyBlock; have.Cmp(spec.ConsensusKeyBlock) != 0 {
+		t.Errorf("consensus key block mismatch: have %v, want %v", have, spec.ConsensusKeyBlock)
+	}
+	// The system contract forks are applied from the block initializing the
+	// contracts, Sophon at or after RedCoast
+	for _, forks := range [][2]*big.Int{
+		{big.NewInt(0), nil},
+		{big.NewInt(3), big.NewInt(2)},
+		{nil, big.NewInt(3)},
+	} {
+		spec.RedCoastBlock, spec.SophonBlock = forks[0], forks[1]
+		if _, err := spec.Genesis(); err == nil {
+			t.Errorf("redcoast %v, sophon %v: fork schedule accepted", forks[0], forks[1])
+		}
+	}
+	spec.RedCoastBlock, spec.SophonBlock, spec.ConsensusKeyBlock, spec.Upgrades = big.NewInt(1), big.NewInt(1), nil, nil
+	if _, err := spec.Genesis(); err != nil {
+		t.Errorf("redcoast and sophon at block 1: fork schedule rejected: %v", err)
+	}
+}
+
+func TestVerifyGenesis(t *testing.T) {
+	spec := &GenesisSpec{
+		ChainID:    big.NewInt(28525),
+		Period:     3,
+		Epoch:      100,
+		GasLimit:   30000000,
+		Validators: []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02")},
+	}
+	genesis, err := spec.Genesis()
+	if err != nil {
+		t.Fatalf("failed to assemble genesis: %v", err)
+	}
+	// Every problem is reported
+	genesis.ExtraData = genesis.ExtraData[:len(genesis.ExtraData)-1]
+	genesis.Config.SophonBlock = big.NewInt(10)
+	delete(genesis.Alloc, systemcontract.ValidatorsContractAddr)
+	genesis.Alloc[systemcontract.ProposalAddr] = core.GenesisAccount{Balance: new(big.Int)}
+
+	if errs := VerifyGenesis(genesis); len(errs) != 4 {
+		t.Errorf("problem count mismatch: have %d, want 4: %v", len(errs), errs)
+	}
+	if errs := VerifyGenesis(&core.Genesis{}); len(errs) != 1 || errs[0] != errNoCongressConfig {
+		t.Errorf("error mismatch: have %v, want %v", errs, errNoCongressConfig)
+	}
+}
@@ -0,0 +1,457 @@
+package congress
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	leaseLockRetries = 20                    // Attempts to take the lease file lock before giving up
+	leaseLockBackoff = 10 * time.Millisecond // Delay between two attempts to take the lease file lock
+	leaseRenewals    = 3                     // Number of times the lease is renewed per lease period
+)
+
+var (
+	// errLeaseBusy is returned if the lease can't be read or updated because
+	// another host keeps it locked.
+	errLeaseBusy = errors.New("lease busy")
+
+	// errFailoverDisabled is returned by the failover RPCs if the validator
+	// doesn't share its key through a lease.
+	errFailoverDisabled = errors.New("validator failover not enabled")
+)
+
+// Validator roles of the hosts sharing a validator key.
+const (
+	RoleActive  = "active"  // Holds the lease and seals blocks
+	RoleStandby = "standby" // Follows the chain, waiting for the lease
+)
+
+// LeaseState is the state of a lease shared by the hosts running the same
+// validator key.
+type LeaseState struct {
+	Holder    string `json:"holder"`              // Host allowed to seal, empty if released
+	Expires   int64  `json:"expires"`             // Unix time in milliseconds the lease expires at unless renewed
+	Successor string `json:"successor,omitempty"` // Host the holder must hand the lease over to
+}
+
+// expired returns whether the lease isn't held by anybody at the given time.
+func (s *LeaseState) expired(now time.Time) bool {
+	return s.Holder == "" || now.UnixNano()/int64(time.Millisecond) >= s.Expires
+}
+
+// Lease is the exclusive right to seal with a validator key run by several
+// hosts. Only the holder of the lease is active, the others are standbys.
+type Lease interface {
+	// Acquire takes or renews the lease for the host if it's free, expired or
+	// already held by it. A holder asked to hand the lease over transfers it to
+	// its successor instead.
+	Acquire(host string) (*LeaseState, error)
+
+	// Release gives the lease up if held by the host, to its successor if any.
+	Release(host string) (*LeaseState, error)
+
+	// Request asks the holder to hand the lease over to the host at its next
+	// renewal.
+	Request(host string) (*LeaseState, error)
+
+	// State returns the current state of the lease.
+	State() (*LeaseState, error)
+}
+
+// FileLease is a lease stored in a file, typically on storage shared by the
+// hosts. Updates are serialized by an exclusive lock file next to it.
+type FileLease struct {
+	path string
+	ttl  time.Duration
+	now  func() time.Time // Clock, replaced in tests
+}
+
+// NewFileLease creates a lease stored in the given file, valid for the given
+// period after each renewal.
+func NewFileLease(path string, ttl time.Duration) *FileLease {
+	return &FileLease{path: path, ttl: ttl, now: time.Now}
+}
+
+// Acquire implements Lease.
+func (l *FileLease) Acquire(host string) (*LeaseState, error) {
+	return l.update(func(state *LeaseState, now time.Time) {
+		switch {
+		case state.Holder == host && state.Successor != "" && state.Successor != host:
+			state.Holder, state.Successor = state.Successor, ""
+		case state.Holder == host || state.expired(now):
+			state.Holder = host
+			if state.Successor == host {
+				state.Successor = ""
+			}
+		default:
+			return
+		}
+		state.Expires = now.Add(l.ttl).UnixNano() / int64(time.Millisecond)
+	})
+}
+
+// Release implements Lease.
+func (l *FileLease) Release(host string) (*LeaseState, error) {
+	return l.update(func(state *LeaseState, now time.Time) {
+		if state.Holder != host {
+			return
+		}
+		state.Holder, state.Successor, state.Expires = state.Successor, "", 0
+		if state.Holder != "" {
+			state.Expires = now.Add(l.ttl).UnixNano() / int64(time.Millisecond)
+		}
+	})
+}
+
+// Request implements Lease.
+func (l *FileLease) Request(host string) (*LeaseState, error) {
+	return l.update(func(state *LeaseState, now time.Time) {
+		if state.Holder != host {
+			state.Successor = host
+		}
+	})
+}
+
+// State implements Lease.
+func (l *FileLease) State() (*LeaseState, error) {
+	return l.read()
+}
+
+// read loads the lease from its file, a missing file being a free lease.
+func (l *FileLease) read() (*LeaseState, error) {
+	state := new(LeaseState)
+	blob, err := ioutil.ReadFile(l.path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(blob, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// update applies a change to the lease while holding the lock file, writing
+// it back only if it changed.
+func (l *FileLease) update(change func(state *LeaseState, now time.Time)) (*LeaseState, error) {
+	unlock, err := l.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	state, err := l.read()
+	if err != nil {
+		return nil, err
+	}
+	prev := *state
+	change(state, l.now())
+	if *state == prev {
+		return state, nil
+	}
+	blob, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	// Replace the file atomically, readers never see a partial lease
+	tmp := l.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, blob, 0644); err != nil {
+		return nil, err
+	}
+	return state, os.Rename(tmp, l.path)
+}
+
+// lock takes the lock file of the lease, removing it if it was left behind by
+// a host that died while updating the lease. The lock file records a token of
+// its taker, so that a lock is only ever removed by its taker or as the stale
+// lock that was observed.
+func (l *FileLease) lock() (func(), error) {
+	path := l.path + ".lock"
+	token := newLeaseToken()
+	for i := 0; i < leaseLockRetries; i++ {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_, err = file.WriteString(token)
+			file.Close()
+			if err != nil {
+				removeLeaseLock(path, token)
+				return nil, err
+			}
+			return func() { removeLeaseLock(path, token) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		info, err := os.Stat(path)
+		if err == nil && l.now().Sub(info.ModTime()) > l.ttl {
+			if stale, err := ioutil.ReadFile(path); err == nil && removeLeaseLock(path, string(stale)) {
+				log.Warn("Removed stale lease lock", "path", path, "age", l.now().Sub(info.ModTime()))
+			}
+			continue
+		}
+		time.Sleep(leaseLockBackoff)
+	}
+	return nil, errLeaseBusy
+}
+
+// removeLeaseLock removes the lock file if it records the given token. The file
+// is first moved aside atomically, so that of several hosts removing the same
+// lock only one succeeds, and a lock taken meanwhile by another host is put
+// back instead of being removed.
+func removeLeaseLock(path string, token string) bool {
+	aside := path + "." + newLeaseToken()
+	if err := os.Rename(path, aside); err != nil {
+		return false
+	}
+	defer os.Remove(aside)
+
+	if blob, err := ioutil.ReadFile(aside); err == nil && string(blob) == token {
+		return true
+	}
+	// Linking fails if yet another lock was taken since, which then stays
+	if err := os.Link(aside, path); err != nil {
+		log.Warn("Failed to restore lease lock", "path", path, "err", err)
+	}
+	return false
+}
+
+// newLeaseToken returns a random token identifying a taker of the lease lock.
+func newLeaseToken() string {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		panic("can't generate lease token: " + err.Error())
+	}
+	return hex.EncodeToString(token)
+}
+
+// failover decides whether the local validator is active, based on the lease
+// shared with the other hosts running the same key. The lease is renewed in the
+// background, sealing only reads the outcome of the last renewal.
+type failover struct {
+	lease Lease
+	host  string        // Identity of the local host in the lease
+	ttl   time.Duration // Period the local host stays standby after stepping down
+
+	standbyUntil time.Time // The local host doesn't acquire the lease before
+	active       bool      // Whether the local host held the lease at the last renewal
+	expires      time.Time // Time the lease held by the local host expires at
+	lock         sync.Mutex
+
+	renewLock sync.Mutex // Serializes the lease updates of the local host
+	quit      chan struct{}
+	wg        sync.WaitGroup
+}
+
+// newFailover creates the failover of the local host and starts renewing the
+// lease in the background.
+func newFailover(lease Lease, host string, ttl time.Duration) *failover {
+	f := &failover{lease: lease, host: host, ttl: ttl, quit: make(chan struct{})}
+	f.wg.Add(1)
+	go f.loop()
+	return f
+}
+
+// SetLease makes the local validator seal only while it holds the lease, so
+// that several hosts can run the same validator key. The host stays standby
+// for the given period after handing the role over.
+func (c *Congress) SetLease(lease Lease, host string, ttl time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.failover != nil {
+		c.failover.stop()
+	}
+	c.failover = newFailover(lease, host, ttl)
+}
+
+// loop renews the lease a few times per lease period, until stopped.
+func (f *failover) loop() {
+	defer f.wg.Done()
+
+	ticker := time.NewTicker(f.ttl / leaseRenewals)
+	defer ticker.Stop()
+
+	for {
+		f.renew()
+		select {
+		case <-ticker.C:
+		case <-f.quit:
+			return
+		}
+	}
+}
+
+// stop terminates the background renewal of the lease.
+func (f *failover) stop() {
+	close(f.quit)
+	f.wg.Wait()
+}
+
+// renew takes or renews the lease if the local host isn't standing by, and
+// records whether it holds it. Failing to reach the lease makes the validator
+// standby once the lease it held expires.
+func (f *failover) renew() {
+	f.renewLock.Lock()
+	defer f.renewLock.Unlock()
+
+	f.lock.Lock()
+	standby := time.Now().Before(f.standbyUntil)
+	f.lock.Unlock()
+
+	var (
+		state *LeaseState
+		err   error
+	)
+	if standby {
+		state, err = f.lease.State()
+	} else {
+		state, err = f.lease.Acquire(f.host)
+	}
+	if err != nil {
+		log.Warn("Failed to renew validator lease", "host", f.host, "err", err)
+		return
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	active := state.Holder == f.host && !state.expired(time.Now())
+	if active {
+		f.expires = time.Unix(0, state.Expires*int64(time.Millisecond))
+	}
+	if active != f.active {
+		if active {
+			log.Info("Validator lease acquired, sealing", "host", f.host)
+		} else {
+			log.Info("Validator lease lost, standing by", "host", f.host, "holder", holderOf(state))
+		}
+		f.active = active
+	}
+}
+
+// isActive returns whether the local validator may seal, that is whether it
+// held the lease at the last renewal and the lease hasn't expired since.
+func (f *failover) isActive() bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.active && time.Now().Before(f.expires)
+}
+
+// transfer hands the active role over. An active host releases the lease and
+// stands by for a lease period, letting a standby take it over. A standby asks
+// the active host to hand the lease over to it at its next renewal.
+func (f *failover) transfer() (*LeaseState, error) {
+	f.renewLock.Lock()
+	defer f.renewLock.Unlock()
+
+	state, err := f.lease.State()
+	if err != nil {
+		return nil, err
+	}
+	if state.Holder != f.host {
+		return f.lease.Request(f.host)
+	}
+	f.lock.Lock()
+	f.standbyUntil, f.active = time.Now().Add(f.ttl), false
+	f.lock.Unlock()
+
+	log.Info("Validator lease released, standing by", "host", f.host, "until", f.standbyUntil)
+	return f.lease.Release(f.host)
+}
+
+// holderOf returns the holder of a lease, if known.
+func holderOf(state *LeaseState) string {
+	if state == nil {
+		return ""
+	}
+	return state.Holder
+}
+
+// ValidatorRole is the role of the local host among the ones running the
+// same validator key.
+type ValidatorRole struct {
+	Role  string      `json:"role"`
+	Host  string      `json:"host"`
+	Lease *LeaseState `json:"lease"`
+}
+
+// role returns the current role of the local host.
+func (f *failover) role() (*ValidatorRole, error) {
+	state, err := f.lease.State()
+	if err != nil {
+		return nil, err
+	}
+	role := &ValidatorRole{Role: RoleStandby, Host: f.host, Lease: state}
+	if state.Holder == f.host && !state.expired(time.Now()) {
+		role.Role = RoleActive
+	}
+	return role, nil
+}
+
+// FailoverAPI provides the admin RPCs to inspect and transfer the active role
+// of a validator run by several hosts.
+type FailoverAPI struct {
+	congress *Congress
+}
+
+func (api *FailoverAPI) failover() (*failover, error) {
+	api.congress.lock.RLock()
+	defer api.congress.lock.RUnlock()
+
+	if api.congress.failover == nil {
+		return nil, errFailoverDisabled
+	}
+	return api.congress.failover, nil
+}
+
+// ValidatorRole returns whether the local host is the active or a standby host
+// of the validator, along with the state of the lease.
+func (api *FailoverAPI) ValidatorRole() (*ValidatorRole, error) {
+	f, err := api.failover()
+	if err != nil {
+		return nil, err
+	}
+	return f.role()
+}
+
+// TransferValidatorRole hands the active role over: called on the active host
+// it steps down for a lease period, called on a standby host it asks the
+// active one to hand the lease over at its next renewal.
+func (api *FailoverAPI) TransferValidatorRole() (*ValidatorRole, error) {
+	f, err := api.failover()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.transfer(); err != nil {
+		return nil, err
+	}
+	return f.role()
+}
+
+// DefaultLeaseHost returns the default identity of the local host in a lease,
+// made of the host name and the data directory of the node.
+func DefaultLeaseHost(datadir string) string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	if datadir != "" {
+		if abs, err := filepath.Abs(datadir); err == nil {
+			datadir = abs
+		}
+		return host + ":" + datadir
+	}
+	return host
+}
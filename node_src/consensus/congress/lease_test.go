@@ -0,0 +1,179 @@
+package congress
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileLease(t *testing.T) {
+	dir, err := ioutil.TempDir("", "congress-lease")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Unix(1000, 0)
+	lease := NewFileLease(filepath.Join(dir, "lease"), 10*time.Second)
+	lease.now = func() time.Time { return now }
+
+	acquire := func(host string, want string) {
+		t.Helper()
+		state, err := lease.Acquire(host)
+		if err != nil {
+			t.Fatalf("failed to acquire lease: %v", err)
+		}
+		if state.Holder != want {
+			t.Fatalf("holder mismatch after %s acquired: have %q, want %q", host, state.Holder, want)
+		}
+	}
+	// A free lease is taken and held until it expires
+	acquire("a", "a")
+	acquire("b", "a")
+	now = now.Add(9 * time.Second)
+	acquire("a", "a")
+	now = now.Add(9 * time.Second)
+	acquire("b", "a")
+
+	// An expired lease is taken over
+	now = now.Add(2 * time.Second)
+	acquire("b", "b")
+
+	// A requested lease is handed over at the next renewal of the holder
+	if _, err := lease.Request("a"); err != nil {
+		t.Fatalf("failed to request lease: %v", err)
+	}
+	acquire("b", "a")
+	acquire("a", "a")
+	if state, _ := lease.State(); state.Successor != "" {
+		t.Errorf("successor not cleared: %q", state.Successor)
+	}
+	// A released lease is free
+	if _, err := lease.Release("b"); err != nil {
+		t.Fatalf("failed to release lease: %v", err)
+	}
+	if state, _ := lease.State(); state.Holder != "a" {
+		t.Errorf("lease released by non-holder: %q", state.Holder)
+	}
+	if _, err := lease.Release("a"); err != nil {
+		t.Fatalf("failed to release lease: %v", err)
+	}
+	acquire("b", "b")
+
+	// A lock left behind by a dead host is eventually removed
+	lock := filepath.Join(dir, "lease.lock")
+	if err := ioutil.WriteFile(lock, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lease.Acquire("b"); err != errLeaseBusy {
+		t.Errorf("error mismatch: have %v, want %v", err, errLeaseBusy)
+	}
+	os.Chtimes(lock, now.Add(-time.Minute), now.Add(-time.Minute))
+	acquire("b", "b")
+}
+
+func TestLeaseLockTakeover(t *testing.T) {
+	dir, err := ioutil.TempDir("", "congress-lease")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// A lock taken by another host since the stale one was observed stays
+	lock := filepath.Join(dir, "lease.lock")
+	if err := ioutil.WriteFile(lock, []byte("fresh"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if removeLeaseLock(lock, "stale") {
+		t.Errorf("fresh lock removed as the stale one")
+	}
+	if blob, err := ioutil.ReadFile(lock); err != nil || string(blob) != "fresh" {
+		t.Errorf("fresh lock not restored: have %q, err %v", blob, err)
+	}
+	// The stale lock is removed once only
+	if !removeLeaseLock(lock, "fresh") {
+		t.Errorf("lock not removed")
+	}
+	if removeLeaseLock(lock, "fresh") {
+		t.Errorf("lock removed twice")
+	}
+	if files, _ := ioutil.ReadDir(dir); len(files) != 0 {
+		t.Errorf("lock files left behind: %d", len(files))
+	}
+}
+
+func TestFailoverTransfer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "congress-lease")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	lease := NewFileLease(filepath.Join(dir, "lease"), time.Minute)
+	var (
+		primary = &failover{lease: lease, host: "primary", ttl: time.Minute}
+		standby = &failover{lease: lease, host: "standby", ttl: time.Minute}
+	)
+	// Sealing only reads the outcome of the last renewal
+	if primary.isActive() {
+		t.Fatalf("host active before renewing the lease")
+	}
+	primary.renew()
+	standby.renew()
+	if !primary.isActive() || standby.isActive() {
+		t.Fatalf("first host not active alone")
+	}
+	// The standby asks for the role, the primary hands it over at its next renewal
+	if _, err := standby.transfer(); err != nil {
+		t.Fatalf("failed to request role: %v", err)
+	}
+	standby.renew()
+	if standby.isActive() {
+		t.Fatalf("standby active before the handover")
+	}
+	primary.renew()
+	standby.renew()
+	if primary.isActive() || !standby.isActive() {
+		t.Fatalf("role not handed over")
+	}
+	// The active host steps down, the other one takes the role over
+	if _, err := standby.transfer(); err != nil {
+		t.Fatalf("failed to step down: %v", err)
+	}
+	if standby.isActive() {
+		t.Fatalf("host active after stepping down")
+	}
+	primary.renew()
+	standby.renew()
+	if standby.isActive() || !primary.isActive() {
+		t.Fatalf("role not taken over")
+	}
+	if role, err := standby.role(); err != nil || role.Role != RoleStandby || role.Lease.Holder != "primary" {
+		t.Errorf("role mismatch: have %+v, err %v", role, err)
+	}
+}
+
+func TestFailoverRenewal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "congress-lease")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The lease is taken in the background, without any sealing
+	f := newFailover(NewFileLease(filepath.Join(dir, "lease"), 300*time.Millisecond), "primary", 300*time.Millisecond)
+	defer f.stop()
+
+	for start := time.Now(); !f.isActive(); time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > time.Second {
+			t.Fatalf("lease not acquired in the background")
+		}
+	}
+	// and renewed before it expires
+	time.Sleep(time.Second)
+	if !f.isActive() {
+		t.Fatalf("lease not renewed in the background")
+	}
+}
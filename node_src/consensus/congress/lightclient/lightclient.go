@@ -0,0 +1,286 @@
+// Package lightclient verifies congress headers against the validator set taken
+// from the epoch checkpoints, without any database or EVM state. It's meant to
+// be embedded in bridges and relayers following a congress chain.
+package lightclient
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	extraVanity = 32                     // Fixed number of extra-data prefix bytes reserved for validator vanity
+	extraSeal   = crypto.SignatureLength // Fixed number of extra-data suffix bytes reserved for validator seal
+)
+
+var (
+	diffInTurn = big.NewInt(2) // Block difficulty for in-turn signatures
+	diffNoTurn = big.NewInt(1) // Block difficulty for out-of-turn signatures
+)
+
+var (
+	// ErrMissingSignature is returned if a header's extra-data doesn't hold the
+	// vanity and the seal.
+	ErrMissingSignature = errors.New("extra-data 65 byte signature suffix missing")
+
+	// ErrInvalidCheckpoint is returned if the extra-data of a header lists
+	// validators while it isn't a checkpoint, or an invalid list of them if it is.
+	ErrInvalidCheckpoint = errors.New("invalid checkpoint validator list")
+
+	// ErrOutOfEpoch is returned if a header isn't in the epoch following the
+	// checkpoint of the validator set it's verified against.
+	ErrOutOfEpoch = errors.New("header out of validator set epoch")
+
+	// ErrUnauthorizedValidator is returned if a header is sealed by an account
+	// that isn't in the validator set, or isn't the key of the validator in its
+	// coinbase from the consensus key fork.
+	ErrUnauthorizedValidator = errors.New("unauthorized validator")
+
+	// ErrInvalidCoinbase is returned if a header is sealed by another account
+	// than its coinbase before the consensus key fork.
+	ErrInvalidCoinbase = errors.New("invalid coinbase")
+
+	// ErrInvalidDifficulty is returned if the difficulty of a header doesn't
+	// match the turn of its validator.
+	ErrInvalidDifficulty = errors.New("invalid difficulty")
+
+	// ErrRecentlySigned is returned if a validator seals a header within the
+	// recents window of another header it sealed.
+	ErrRecentlySigned = errors.New("recently signed")
+)
+
+// ValidatorSet is the set of validators sealing the blocks of an epoch, as
+// listed by the checkpoint header opening it.
+type ValidatorSet struct {
+	Number     uint64           // Number of the checkpoint header
+	Hash       common.Hash      // Hash of the checkpoint header
+	Validators []common.Address // Validators in checkpoint order
+	Keys       []common.Address // Key each validator seals with, the validator itself if it has none
+}
+
+// ParseCheckpoint returns the validator set listed in a checkpoint header. The
+// header itself isn't verified, it must either be trusted, like the genesis, or
+// be verified against the previous set.
+func ParseCheckpoint(config *params.CongressConfig, header *types.Header) (*ValidatorSet, error) {
+	number := header.Number.Uint64()
+	if number > 0 && number%config.EpochAt(number) != 0 {
+		return nil, ErrInvalidCheckpoint
+	}
+	if len(header.Extra) < extraVanity+extraSeal {
+		return nil, ErrMissingSignature
+	}
+	size := common.AddressLength
+	if config.IsConsensusKey(number) {
+		size *= 2
+	}
+	length := len(header.Extra) - extraVanity - extraSeal
+	if length == 0 || length%size != 0 {
+		return nil, ErrInvalidCheckpoint
+	}
+	count := length / size
+	set := &ValidatorSet{
+		Number:     number,
+		Hash:       header.Hash(),
+		Validators: make([]common.Address, count),
+		Keys:       make([]common.Address, count),
+	}
+	for i := 0; i < count; i++ {
+		copy(set.Validators[i][:], header.Extra[extraVanity+i*common.AddressLength:])
+		set.Keys[i] = set.Validators[i]
+		if config.IsConsensusKey(number) {
+			copy(set.Keys[i][:], header.Extra[extraVanity+(count+i)*common.AddressLength:])
+		}
+	}
+	return set, nil
+}
+
+// keyOf returns the key the validator seals with.
+func (s *ValidatorSet) keyOf(validator common.Address) (common.Address, bool) {
+	for i, v := range s.Validators {
+		if v == validator {
+			return s.Keys[i], true
+		}
+	}
+	return common.Address{}, false
+}
+
+// validatorOf returns the validator sealing with the given key.
+func (s *ValidatorSet) validatorOf(key common.Address) (common.Address, bool) {
+	for i, k := range s.Keys {
+		if k == key {
+			return s.Validators[i], true
+		}
+	}
+	return common.Address{}, false
+}
+
+// inturn returns whether the validator is in-turn at the given height.
+func (s *ValidatorSet) inturn(number uint64, validator common.Address) bool {
+	validators := make([]common.Address, len(s.Validators))
+	copy(validators, s.Validators)
+	sort.Slice(validators, func(i, j int) bool { return bytes.Compare(validators[i][:], validators[j][:]) < 0 })
+
+	offset := 0
+	for offset < len(validators) && validators[offset] != validator {
+		offset++
+	}
+	return number%uint64(len(validators)) == uint64(offset)
+}
+
+// Verifier verifies the headers of a congress chain against the validator set
+// of their epoch, moving from an epoch to the next one with the checkpoints.
+type Verifier struct {
+	config *params.CongressConfig
+	set    *ValidatorSet
+}
+
+// NewVerifier creates a verifier starting at the given trusted checkpoint,
+// typically the genesis header.
+func NewVerifier(config *params.CongressConfig, checkpoint *types.Header) (*Verifier, error) {
+	set, err := ParseCheckpoint(config, checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &Verifier{config: config, set: set}, nil
+}
+
+// ValidatorSet returns the validator set of the current epoch.
+func (v *Verifier) ValidatorSet() *ValidatorSet {
+	return v.set
+}
+
+// NextCheckpoint returns the number of the checkpoint closing the current epoch.
+func (v *Verifier) NextCheckpoint() uint64 {
+	return nextCheckpoint(v.config, v.set)
+}
+
+// VerifyHeader checks that a header of the current epoch, up to the checkpoint
+// closing it, is sealed by a validator of the set with the difficulty of its
+// turn. It returns the validator that sealed it.
+func (v *Verifier) VerifyHeader(header *types.Header) (common.Address, error) {
+	validator, _, err := verifyHeader(v.config, v.set, header)
+	return validator, err
+}
+
+// ApplyCheckpoint verifies the checkpoint closing the current epoch and moves
+// to the validator set it lists. A single checkpoint is only as trustworthy as
+// the validator that sealed it, see ApplyEpochChange.
+func (v *Verifier) ApplyCheckpoint(header *types.Header) error {
+	if header.Number.Uint64() != v.NextCheckpoint() {
+		return ErrOutOfEpoch
+	}
+	if _, err := v.VerifyHeader(header); err != nil {
+		return err
+	}
+	set, err := ParseCheckpoint(v.config, header)
+	if err != nil {
+		return err
+	}
+	v.set = set
+	return nil
+}
+
+// nextCheckpoint returns the number of the checkpoint following the one of the
+// given validator set.
+func nextCheckpoint(config *params.CongressConfig, set *ValidatorSet) uint64 {
+	return set.Number + config.EpochAt(set.Number+1)
+}
+
+// verifyHeader checks that a header of the epoch of the given validator set is
+// sealed by one of its validators, and returns it along with the key it sealed
+// the header with.
+func verifyHeader(config *params.CongressConfig, set *ValidatorSet, header *types.Header) (common.Address, common.Address, error) {
+	number := header.Number.Uint64()
+	if number <= set.Number || number > nextCheckpoint(config, set) {
+		return common.Address{}, common.Address{}, ErrOutOfEpoch
+	}
+	if len(header.Extra) < extraVanity+extraSeal {
+		return common.Address{}, common.Address{}, ErrMissingSignature
+	}
+	if number != nextCheckpoint(config, set) && len(header.Extra) != extraVanity+extraSeal {
+		return common.Address{}, common.Address{}, ErrInvalidCheckpoint
+	}
+	signer, err := Ecrecover(header)
+	if err != nil {
+		return common.Address{}, common.Address{}, err
+	}
+	validator, ok := set.validatorOf(signer)
+	if !ok {
+		return common.Address{}, common.Address{}, ErrUnauthorizedValidator
+	}
+	// From the consensus key fork the validator is the coinbase, signing
+	// with its consensus key, and it signs itself before
+	if config.IsConsensusKey(number) {
+		if validator != header.Coinbase {
+			return common.Address{}, common.Address{}, ErrUnauthorizedValidator
+		}
+	} else if signer != header.Coinbase {
+		return common.Address{}, common.Address{}, ErrInvalidCoinbase
+	}
+	want := diffNoTurn
+	if set.inturn(number, validator) {
+		want = diffInTurn
+	}
+	if header.Difficulty == nil || header.Difficulty.Cmp(want) != 0 {
+		return common.Address{}, common.Address{}, ErrInvalidDifficulty
+	}
+	return validator, signer, nil
+}
+
+// Ecrecover returns the account that sealed the header.
+func Ecrecover(header *types.Header) (common.Address, error) {
+	if len(header.Extra) < extraSeal {
+		return common.Address{}, ErrMissingSignature
+	}
+	signature := header.Extra[len(header.Extra)-extraSeal:]
+
+	pubkey, err := crypto.Ecrecover(SealHash(header).Bytes(), signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	var signer common.Address
+	copy(signer[:], crypto.Keccak256(pubkey[1:])[12:])
+	return signer, nil
+}
+
+// SealHash returns the hash of a header prior to it being sealed, the same as
+// congress.SealHash. The extra-data of the header must hold the seal.
+func SealHash(header *types.Header) (hash common.Hash) {
+	hasher := sha3.NewLegacyKeccak256()
+	encodeSigHeader(hasher, header)
+	hasher.Sum(hash[:0])
+	return hash
+}
+
+func encodeSigHeader(w io.Writer, header *types.Header) {
+	err := rlp.Encode(w, []interface{}{
+		header.ParentHash,
+		header.UncleHash,
+		header.Coinbase,
+		header.Root,
+		header.TxHash,
+		header.ReceiptHash,
+		header.Bloom,
+		header.Difficulty,
+		header.Number,
+		header.GasLimit,
+		header.GasUsed,
+		header.Time,
+		header.Extra[:len(header.Extra)-extraSeal], // Callers check the seal is present
+		header.MixDigest,
+		header.Nonce,
+	})
+	if err != nil {
+		panic("can't encode: " + err.Error())
+	}
+}
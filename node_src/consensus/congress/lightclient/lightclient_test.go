@@ -0,0 +1,341 @@
+package lightclient
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/congress"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// testChain builds congress headers sealed by a set of test keys.
+type testChain struct {
+	t       *testing.T
+	config  *params.CongressConfig
+	keys    map[common.Address]*ecdsa.PrivateKey
+	headers []*types.Header
+}
+
+// newTestChain creates the genesis of a chain with validators derived from the
+// given number of keys.
+func newTestChain(t *testing.T, config *params.CongressConfig, n int) (*testChain, []common.Address) {
+	c := &testChain{t: t, config: config, keys: make(map[common.Address]*ecdsa.PrivateKey)}
+	validators := c.newKeys(n)
+	c.headers = []*types.Header{{Number: new(big.Int), Difficulty: new(big.Int), Extra: checkpointExtra(validators, nil)}}
+	return c, validators
+}
+
+// newKeys generates keys, returning their addresses in ascending order.
+func (c *testChain) newKeys(n int) []common.Address {
+	addrs := make([]common.Address, n)
+	for i := range addrs {
+		key, _ := crypto.GenerateKey()
+		addrs[i] = crypto.PubkeyToAddress(key.PublicKey)
+		c.keys[addrs[i]] = key
+	}
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i][:], addrs[j][:]) < 0 })
+	return addrs
+}
+
+// add seals the next header with the given key on behalf of the validator, with
+// the difficulty of its turn in the given set.
+func (c *testChain) add(set []common.Address, validator, signer common.Address, extra []byte) *types.Header {
+	parent := c.headers[len(c.headers)-1]
+	number := parent.Number.Uint64() + 1
+
+	diff := diffNoTurn
+	if set[number%uint64(len(set))] == validator {
+		diff = diffInTurn
+	}
+	if extra == nil {
+		extra = checkpointExtra(nil, nil)
+	}
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Coinbase:   validator,
+		Number:     new(big.Int).SetUint64(number),
+		Difficulty: diff,
+		Extra:      extra,
+	}
+	c.seal(header, signer)
+	c.headers = append(c.headers, header)
+	return header
+}
+
+func (c *testChain) seal(header *types.Header, signer common.Address) {
+	sig, err := crypto.Sign(SealHash(header).Bytes(), c.keys[signer])
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	copy(header.Extra[len(header.Extra)-extraSeal:], sig)
+}
+
+// checkpointExtra returns the extra-data of a checkpoint listing the given
+// validators and keys, empty of validators if none.
+func checkpointExtra(validators []common.Address, keys []common.Address) []byte {
+	extra := make([]byte, extraVanity)
+	for _, validator := range validators {
+		extra = append(extra, validator.Bytes()...)
+	}
+	for _, key := range keys {
+		extra = append(extra, key.Bytes()...)
+	}
+	return append(extra, make([]byte, extraSeal)...)
+}
+
+func TestSealHash(t *testing.T) {
+	header := &types.Header{Number: big.NewInt(7), Difficulty: big.NewInt(2), Extra: checkpointExtra(nil, nil), Time: 42}
+	if have, want := SealHash(header), congress.SealHash(header); have != want {
+		t.Errorf("seal hash mismatch: have %x, want %x", have, want)
+	}
+}
+
+func TestVerifyHeader(t *testing.T) {
+	config := &params.CongressConfig{Period: 3, Epoch: 4}
+	chain, validators := newTestChain(t, config, 3)
+
+	verifier, err := NewVerifier(config, chain.headers[0])
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+	// In-turn and out-of-turn headers are accepted
+	for _, header := range []*types.Header{
+		chain.add(validators, validators[1], validators[1], nil),
+		chain.add(validators, validators[0], validators[0], nil),
+	} {
+		if validator, err := verifier.VerifyHeader(header); err != nil || validator != header.Coinbase {
+			t.Errorf("header %d: validator mismatch: have %x, want %x, err %v", header.Number, validator, header.Coinbase, err)
+		}
+	}
+	// Wrong difficulties, outsiders and headers of other epochs aren't
+	header := chain.add(validators, validators[1], validators[1], nil)
+	header.Difficulty = diffInTurn
+	chain.seal(header, validators[1])
+	if _, err := verifier.VerifyHeader(header); err != ErrInvalidDifficulty {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrInvalidDifficulty)
+	}
+	outsider := chain.newKeys(1)
+	if _, err := verifier.VerifyHeader(chain.add(validators, outsider[0], outsider[0], nil)); err != ErrUnauthorizedValidator {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrUnauthorizedValidator)
+	}
+	// Before the consensus key fork the validator must seal its own coinbase
+	chain.headers = chain.headers[:len(chain.headers)-1]
+	if _, err := verifier.VerifyHeader(chain.add(validators, validators[0], validators[1], nil)); err != ErrInvalidCoinbase {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrInvalidCoinbase)
+	}
+	if _, err := verifier.VerifyHeader(chain.add(validators, validators[2], validators[2], nil)); err != ErrOutOfEpoch {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrOutOfEpoch)
+	}
+}
+
+func TestVerifyEngineHeaders(t *testing.T) {
+	keys := make([]*ecdsa.PrivateKey, 3)
+	spec := &congress.GenesisSpec{ChainID: big.NewInt(28525), Period: 3, Epoch: 100, GasLimit: 30000000}
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		spec.Validators = append(spec.Validators, crypto.PubkeyToAddress(keys[i].PublicKey))
+	}
+	genesis, err := spec.Genesis()
+	if err != nil {
+		t.Fatalf("failed to assemble genesis: %v", err)
+	}
+	maker, err := congress.NewChainMaker(rawdb.NewMemoryDatabase(), genesis, keys...)
+	if err != nil {
+		t.Fatalf("failed to create chain maker: %v", err)
+	}
+	defer maker.Stop()
+
+	verifier, err := NewVerifier(genesis.Config.Congress, maker.Chain().Genesis().Header())
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+	// Headers sealed in turn and out of turn by the engine are accepted
+	var blocks []*types.Block
+	for i := 0; i < 3; i++ {
+		block, err := maker.Mine()
+		if err != nil {
+			t.Fatalf("failed to mine block %d: %v", i+1, err)
+		}
+		blocks = append(blocks, block)
+	}
+	outturn := blocks[1].Coinbase()
+	block, err := maker.MineBy(outturn)
+	if err != nil {
+		t.Fatalf("failed to mine out of turn: %v", err)
+	}
+	if block.Difficulty().Cmp(diffNoTurn) != 0 {
+		t.Fatalf("block sealed in turn: difficulty %v", block.Difficulty())
+	}
+	blocks = append(blocks, block)
+
+	for _, block := range blocks {
+		if validator, err := verifier.VerifyHeader(block.Header()); err != nil || validator != block.Coinbase() {
+			t.Errorf("header %d: validator mismatch: have %x, want %x, err %v", block.Number(), validator, block.Coinbase(), err)
+		}
+	}
+}
+
+func TestEpochChangeProof(t *testing.T) {
+	config := &params.CongressConfig{Period: 3, Epoch: 4}
+	chain, validators := newTestChain(t, config, 3)
+
+	verifier, err := NewVerifier(config, chain.headers[0])
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+	// The checkpoint replaces the last validator
+	incoming := append([]common.Address{validators[0], validators[1]}, chain.newKeys(1)...)
+	sort.Slice(incoming, func(i, j int) bool { return bytes.Compare(incoming[i][:], incoming[j][:]) < 0 })
+
+	for i := 1; i < 4; i++ {
+		chain.add(validators, validators[i%3], validators[i%3], nil)
+	}
+	checkpoint := chain.add(validators, validators[1], validators[1], checkpointExtra(incoming, nil))
+	// The incoming validators take turns, the checkpoint sealer last
+	var order []common.Address
+	for _, validator := range incoming {
+		if validator != validators[1] {
+			order = append(order, validator)
+		}
+	}
+	order = append(order, validators[1])
+
+	var headers []*types.Header
+	for i := 0; i < 4; i++ {
+		validator := order[i%3]
+		headers = append(headers, chain.add(incoming, validator, validator, nil))
+	}
+	// The proof stops once another outgoing validator sealed a header
+	proof, err := NewEpochChangeProof(config, verifier.ValidatorSet(), checkpoint, headers)
+	if err != nil {
+		t.Fatalf("failed to create proof: %v", err)
+	}
+	want := 0
+	for want < len(headers) && headers[want].Coinbase != validators[0] {
+		want++
+	}
+	if len(proof.Headers) != want+1 {
+		t.Errorf("proof length mismatch: have %d, want %d", len(proof.Headers), want+1)
+	}
+	// A checkpoint alone isn't enough, neither are unchained headers
+	if _, err := VerifyEpochChange(config, verifier.ValidatorSet(), &EpochChangeProof{Checkpoint: checkpoint}); err != ErrInsufficientQuorum {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrInsufficientQuorum)
+	}
+	if _, err := VerifyEpochChange(config, verifier.ValidatorSet(), &EpochChangeProof{Checkpoint: checkpoint, Headers: headers[1:]}); err != ErrBrokenChain {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrBrokenChain)
+	}
+	if err := verifier.ApplyEpochChange(proof); err != nil {
+		t.Fatalf("failed to apply proof: %v", err)
+	}
+	if set := verifier.ValidatorSet(); set.Number != 4 || set.Hash != checkpoint.Hash() || len(set.Validators) != 3 || set.Validators[2] != incoming[2] {
+		t.Errorf("validator set mismatch: %+v", set)
+	}
+	for _, header := range headers {
+		if _, err := verifier.VerifyHeader(header); err != nil {
+			t.Errorf("failed to verify header %d of new epoch: %v", header.Number, err)
+		}
+	}
+}
+
+func TestForgedEpochChange(t *testing.T) {
+	config := &params.CongressConfig{Period: 3, Epoch: 4}
+	chain, validators := newTestChain(t, config, 3)
+	set, _ := ParseCheckpoint(config, chain.headers[0])
+
+	for i := 1; i < 4; i++ {
+		chain.add(validators, validators[i%3], validators[i%3], nil)
+	}
+	// A single validator hands the chain over to keys of its own
+	forged := chain.newKeys(3)
+	checkpoint := chain.add(validators, validators[1], validators[1], checkpointExtra(forged, nil))
+
+	var headers []*types.Header
+	for i := 0; i < 3; i++ {
+		validator := forged[(5+i)%3]
+		headers = append(headers, chain.add(forged, validator, validator, nil))
+	}
+	if _, err := NewEpochChangeProof(config, set, checkpoint, headers); err != ErrInsufficientQuorum {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrInsufficientQuorum)
+	}
+}
+
+func TestConsensusKeyHeaders(t *testing.T) {
+	config := &params.CongressConfig{Period: 3, Epoch: 4, ConsensusKeyBlock: big.NewInt(4)}
+	chain, validators := newTestChain(t, config, 1)
+
+	verifier, err := NewVerifier(config, chain.headers[0])
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+	for i := 1; i < 4; i++ {
+		chain.add(validators, validators[0], validators[0], nil)
+	}
+	// The fork checkpoint lists the key of the validator
+	keys := chain.newKeys(1)
+	checkpoint := chain.add(validators, validators[0], validators[0], checkpointExtra(validators, keys))
+	if err := verifier.ApplyCheckpoint(checkpoint); err != nil {
+		t.Fatalf("failed to apply checkpoint: %v", err)
+	}
+	if set := verifier.ValidatorSet(); set.Keys[0] != keys[0] {
+		t.Errorf("consensus key mismatch: have %x, want %x", set.Keys[0], keys[0])
+	}
+	// Headers are sealed by the key on behalf of the validator in the coinbase
+	if _, err := verifier.VerifyHeader(chain.add(validators, validators[0], keys[0], nil)); err != nil {
+		t.Errorf("failed to verify header sealed by consensus key: %v", err)
+	}
+	chain.headers = chain.headers[:len(chain.headers)-1]
+	if _, err := verifier.VerifyHeader(chain.add(validators, validators[0], validators[0], nil)); err != ErrUnauthorizedValidator {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrUnauthorizedValidator)
+	}
+	chain.headers = chain.headers[:len(chain.headers)-1]
+	if _, err := verifier.VerifyHeader(chain.add(validators, keys[0], keys[0], nil)); err != ErrUnauthorizedValidator {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrUnauthorizedValidator)
+	}
+}
+
+func TestForgedEpochChangeKeys(t *testing.T) {
+	config := &params.CongressConfig{Period: 3, Epoch: 4, ConsensusKeyBlock: big.NewInt(4)}
+	chain, validators := newTestChain(t, config, 3)
+	set, _ := ParseCheckpoint(config, chain.headers[0])
+
+	for i := 1; i < 4; i++ {
+		chain.add(validators, validators[i%3], validators[i%3], nil)
+	}
+	// A single validator pairs the others with keys of its own at the fork
+	forged := chain.newKeys(3)
+	checkpoint := chain.add(validators, validators[1], validators[1], checkpointExtra(validators, forged))
+
+	var headers []*types.Header
+	for i := 0; i < 3; i++ {
+		headers = append(headers, chain.add(validators, validators[(5+i)%3], forged[(5+i)%3], nil))
+	}
+	if _, err := NewEpochChangeProof(config, set, checkpoint, headers); err != ErrInsufficientQuorum {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrInsufficientQuorum)
+	}
+}
+
+func TestRecentlySignedEpochChange(t *testing.T) {
+	config := &params.CongressConfig{Period: 3, Epoch: 4}
+	chain, validators := newTestChain(t, config, 3)
+	set, _ := ParseCheckpoint(config, chain.headers[0])
+
+	for i := 1; i < 4; i++ {
+		chain.add(validators, validators[i%3], validators[i%3], nil)
+	}
+	// The validator sealing the checkpoint endorses it again right away
+	checkpoint := chain.add(validators, validators[1], validators[1], checkpointExtra(validators, nil))
+	headers := []*types.Header{
+		chain.add(validators, validators[1], validators[1], nil),
+		chain.add(validators, validators[0], validators[0], nil),
+	}
+	if _, err := VerifyEpochChange(config, set, &EpochChangeProof{Checkpoint: checkpoint, Headers: headers}); err != ErrRecentlySigned {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrRecentlySigned)
+	}
+}
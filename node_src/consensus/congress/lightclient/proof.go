@@ -0,0 +1,119 @@
+package lightclient
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	// ErrBrokenChain is returned if the headers of an epoch change proof don't
+	// form a chain starting at the checkpoint.
+	ErrBrokenChain = errors.New("epoch change proof headers not chained")
+
+	// ErrInsufficientQuorum is returned if the headers of an epoch change proof
+	// aren't sealed by a majority of the outgoing validators.
+	ErrInsufficientQuorum = errors.New("epoch change proof quorum not reached")
+)
+
+// EpochChangeProof proves the transition from a validator set to the one of
+// the next epoch. It holds the checkpoint closing the epoch, sealed by an
+// outgoing validator, followed by the headers built on top of it until a
+// majority of the outgoing validators sealed one of them with the key of the
+// outgoing set: a single validator can't forge a validator set on its own.
+type EpochChangeProof struct {
+	Checkpoint *types.Header
+	Headers    []*types.Header
+}
+
+// quorum returns the number of distinct outgoing validators that must seal the
+// headers of an epoch change proof.
+func quorum(set *ValidatorSet) int {
+	return len(set.Validators)/2 + 1
+}
+
+// NewEpochChangeProof creates the shortest proof of the transition from the
+// given validator set, out of the checkpoint closing its epoch and the headers
+// following it.
+func NewEpochChangeProof(config *params.CongressConfig, set *ValidatorSet, checkpoint *types.Header, headers []*types.Header) (*EpochChangeProof, error) {
+	proof := &EpochChangeProof{Checkpoint: checkpoint}
+	for i := 0; ; i++ {
+		_, err := verifyEpochChange(config, set, proof)
+		if err == nil {
+			return proof, nil
+		}
+		if err != ErrInsufficientQuorum {
+			return nil, err
+		}
+		if i == len(headers) {
+			return nil, ErrInsufficientQuorum
+		}
+		proof.Headers = headers[:i+1]
+	}
+}
+
+// VerifyEpochChange verifies the transition from the given validator set and
+// returns the validator set of the next epoch.
+func VerifyEpochChange(config *params.CongressConfig, set *ValidatorSet, proof *EpochChangeProof) (*ValidatorSet, error) {
+	return verifyEpochChange(config, set, proof)
+}
+
+// ApplyEpochChange verifies the transition from the current validator set and
+// moves to the one of the next epoch.
+func (v *Verifier) ApplyEpochChange(proof *EpochChangeProof) error {
+	next, err := verifyEpochChange(v.config, v.set, proof)
+	if err != nil {
+		return err
+	}
+	v.set = next
+	return nil
+}
+
+func verifyEpochChange(config *params.CongressConfig, set *ValidatorSet, proof *EpochChangeProof) (*ValidatorSet, error) {
+	// The checkpoint must close the epoch and be sealed by an outgoing validator
+	if proof.Checkpoint == nil || proof.Checkpoint.Number.Uint64() != nextCheckpoint(config, set) {
+		return nil, ErrOutOfEpoch
+	}
+	validator, _, err := verifyHeader(config, set, proof.Checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	next, err := ParseCheckpoint(config, proof.Checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	sealed := map[common.Address]bool{validator: true}
+	recents := map[common.Address]uint64{validator: proof.Checkpoint.Number.Uint64()}
+
+	// The headers on top of it are sealed by the incoming validators, the ones
+	// staying in the set endorse the checkpoint. As the keys of the incoming
+	// validators come from the checkpoint being proven, a header only endorses
+	// it if sealed with the key the outgoing set holds for its validator:
+	// otherwise a single validator could list keys of its own for the others.
+	parent := proof.Checkpoint
+	for _, header := range proof.Headers {
+		number := header.Number.Uint64()
+		if header.ParentHash != parent.Hash() || number != parent.Number.Uint64()+1 {
+			return nil, ErrBrokenChain
+		}
+		validator, signer, err := verifyHeader(config, next, header)
+		if err != nil {
+			return nil, err
+		}
+		if seen, ok := recents[validator]; ok && seen+config.RecentsLimit(number, len(next.Validators)) > number {
+			return nil, ErrRecentlySigned
+		}
+		recents[validator] = number
+
+		if key, ok := set.keyOf(validator); ok && key == signer {
+			sealed[validator] = true
+		}
+		parent = header
+	}
+	if len(sealed) < quorum(set) {
+		return nil, ErrInsufficientQuorum
+	}
+	return next, nil
+}
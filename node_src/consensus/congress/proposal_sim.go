@@ -0,0 +1,239 @@
+package congress
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// errProposalNotPassed is returned if the proposal to simulate is not in the
+// passed proposals of the governance contract.
+var errProposalNotPassed = errors.New("proposal not passed")
+
+// AccountState is the state of an account before or after a simulation. Only
+// the storage slots written by the simulation are included.
+type AccountState struct {
+	Balance  *hexutil.Big                `json:"balance"`
+	Nonce    uint64                      `json:"nonce"`
+	CodeHash common.Hash                 `json:"codeHash"`
+	Storage  map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+// AccountDiff is the change of an account touched by a simulation.
+type AccountDiff struct {
+	Address common.Address `json:"address"`
+	Pre     *AccountState  `json:"pre"`
+	Post    *AccountState  `json:"post"`
+}
+
+// ProposalSimulation is the outcome of executing a passed governance proposal
+// on top of a block.
+type ProposalSimulation struct {
+	Id           *hexutil.Big   `json:"id"`
+	Action       uint64         `json:"action"`
+	From         common.Address `json:"from"`
+	To           common.Address `json:"to"`
+	Value        *hexutil.Big   `json:"value"`
+	Data         hexutil.Bytes  `json:"data"`
+	Success      bool           `json:"success"`
+	GasUsed      uint64         `json:"gasUsed"`
+	Error        string         `json:"error,omitempty"`
+	RevertReason string         `json:"revertReason,omitempty"`
+	Logs         []*types.Log   `json:"logs"`
+	StateDiff    []*AccountDiff `json:"stateDiff"`
+}
+
+// touchTracer records the accounts and storage slots an execution may modify.
+type touchTracer struct {
+	accounts []common.Address
+	touched  map[common.Address]map[common.Hash]struct{}
+}
+
+func newTouchTracer() *touchTracer {
+	return &touchTracer{touched: make(map[common.Address]map[common.Hash]struct{})}
+}
+
+func (t *touchTracer) touch(addr common.Address) map[common.Hash]struct{} {
+	slots, ok := t.touched[addr]
+	if !ok {
+		slots = make(map[common.Hash]struct{})
+		t.touched[addr] = slots
+		t.accounts = append(t.accounts, addr)
+	}
+	return slots
+}
+
+func (t *touchTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.touch(from)
+	t.touch(to)
+}
+
+func (t *touchTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	switch op {
+	case vm.SSTORE:
+		if len(scope.Stack.Data()) > 0 {
+			slot := common.Hash(scope.Stack.Back(0).Bytes32())
+			t.touch(scope.Contract.Address())[slot] = struct{}{}
+		}
+	case vm.SELFDESTRUCT:
+		if len(scope.Stack.Data()) > 0 {
+			t.touch(common.Address(scope.Stack.Back(0).Bytes20()))
+		}
+	}
+}
+
+func (t *touchTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	t.touch(from)
+	t.touch(to)
+}
+
+func (t *touchTracer) CaptureExit(output []byte, gasUsed uint64, err error) {}
+
+func (t *touchTracer) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
+
+func (t *touchTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) {}
+
+// accountState returns the state of an account, including the given slots.
+func accountState(statedb *state.StateDB, addr common.Address, slots map[common.Hash]struct{}) *AccountState {
+	account := &AccountState{
+		Balance:  (*hexutil.Big)(statedb.GetBalance(addr)),
+		Nonce:    statedb.GetNonce(addr),
+		CodeHash: statedb.GetCodeHash(addr),
+	}
+	if len(slots) > 0 {
+		account.Storage = make(map[common.Hash]common.Hash, len(slots))
+		for slot := range slots {
+			account.Storage[slot] = statedb.GetState(addr, slot)
+		}
+	}
+	return account
+}
+
+// diffAccounts returns the changes of the touched accounts between two states.
+func diffAccounts(pre, post *state.StateDB, tracer *touchTracer) []*AccountDiff {
+	diffs := make([]*AccountDiff, 0, len(tracer.accounts))
+	for _, addr := range tracer.accounts {
+		slots := tracer.touched[addr]
+		before, after := accountState(pre, addr, slots), accountState(post, addr, slots)
+
+		changed := before.Balance.ToInt().Cmp(after.Balance.ToInt()) != 0 || before.Nonce != after.Nonce || before.CodeHash != after.CodeHash
+		for slot, value := range before.Storage {
+			if after.Storage[slot] != value {
+				changed = true
+			} else {
+				delete(before.Storage, slot)
+				delete(after.Storage, slot)
+			}
+		}
+		if changed {
+			diffs = append(diffs, &AccountDiff{Address: addr, Pre: before, Post: after})
+		}
+	}
+	return diffs
+}
+
+// simulateProposal executes the passed proposal with the given id on top of the
+// given block, the way the next block would execute it in Finalize, and reports
+// the outcome without persisting any change.
+func (c *Congress) simulateProposal(chain consensus.ChainHeaderReader, parent *types.Header, id *big.Int) (*ProposalSimulation, error) {
+	if c.stateFn == nil {
+		return nil, errMissingParentState
+	}
+	statedb, err := c.stateFn(parent.Root)
+	if err != nil {
+		return nil, errMissingParentState
+	}
+	// The proposal is executed by the next block
+	header := c.childHeader(parent)
+	if !c.chainConfig.IsRedCoast(header.Number) {
+		return nil, errProposalNotPassed
+	}
+	prop, err := c.findPassedProposal(chain, header, statedb.Copy(), id)
+	if err != nil {
+		return nil, err
+	}
+	sim := &ProposalSimulation{
+		Id:     (*hexutil.Big)(prop.Id),
+		Action: prop.Action.Uint64(),
+		From:   prop.From,
+		To:     prop.To,
+		Value:  (*hexutil.Big)(prop.Value),
+		Data:   prop.Data,
+		Logs:   []*types.Log{},
+	}
+	var (
+		pre    = statedb.Copy()
+		tracer = newTouchTracer()
+	)
+	switch sim.Action {
+	case 0:
+		statedb.Prepare(common.Hash{}, 0)
+		blockContext := core.NewEVMBlockContext(header, newChainContext(chain, c), nil)
+		evm := vm.NewEVM(blockContext, vm.TxContext{Origin: prop.From, GasPrice: new(big.Int)}, statedb, c.chainConfig, vm.Config{Debug: true, Tracer: tracer})
+
+		// Like executeEvmCallProposal, the access list isn't warmed up
+		ret, leftOver, err := evm.Call(vm.AccountRef(prop.From), prop.To, prop.Data, header.GasLimit, prop.Value)
+		statedb.Finalise(true)
+
+		sim.GasUsed = header.GasLimit - leftOver
+		sim.Success = err == nil
+		if err != nil {
+			sim.Error = err.Error()
+		}
+		if errors.Is(err, vm.ErrExecutionReverted) {
+			if reason, errUnpack := abi.UnpackRevert(ret); errUnpack == nil {
+				sim.RevertReason = reason
+			}
+		}
+		sim.Logs = append(sim.Logs, statedb.GetLogs(common.Hash{}, common.Hash{})...)
+	case 1:
+		tracer.touch(prop.To)
+		sim.Success = statedb.Erase(prop.To)
+	default:
+		sim.Error = "unsupported action"
+	}
+	sim.StateDiff = diffAccounts(pre, statedb, tracer)
+	return sim, nil
+}
+
+// childHeader returns a header for the block following the given one, as sealed
+// by the same validator, to inspect what would happen in the next block.
+func (c *Congress) childHeader(parent *types.Header) *types.Header {
+	return &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		Coinbase:   parent.Coinbase,
+		Difficulty: new(big.Int).Set(diffInTurn),
+		GasLimit:   parent.GasLimit,
+		Time:       parent.Time + c.config.Period,
+		BaseFee:    parent.BaseFee,
+	}
+}
+
+// findPassedProposal looks up the passed proposal with the given id.
+func (c *Congress) findPassedProposal(chain consensus.ChainHeaderReader, header *types.Header, statedb *state.StateDB, id *big.Int) (*Proposal, error) {
+	count, err := c.getPassedProposalCount(chain, header, statedb)
+	if err != nil {
+		return nil, err
+	}
+	for i := uint32(0); i < count; i++ {
+		prop, err := c.getPassedProposalByIndex(chain, header, statedb, i)
+		if err != nil {
+			return nil, err
+		}
+		if prop.Id.Cmp(id) == 0 {
+			return prop, nil
+		}
+	}
+	return nil, errProposalNotPassed
+}
@@ -0,0 +1,57 @@
+package congress
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestProposalStateDiff(t *testing.T) {
+	var (
+		from     = common.HexToAddress("0x1000")
+		contract = common.HexToAddress("0x2000")
+		idle     = common.HexToAddress("0x3000")
+	)
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	statedb.AddBalance(from, big.NewInt(100))
+	// sstore(0, 1), sstore(1, sload(1)), call(idle) without value
+	statedb.SetCode(contract, common.FromHex("0x60016000556001546001556000600060006000600061300061fffff100"))
+	pre := statedb.Copy()
+
+	tracer := newTouchTracer()
+	evm := vm.NewEVM(vm.BlockContext{BlockNumber: common.Big1, CanTransfer: func(db vm.StateDB, addr common.Address, amount *big.Int) bool {
+		return db.GetBalance(addr).Cmp(amount) >= 0
+	}, Transfer: func(db vm.StateDB, sender, recipient common.Address, amount *big.Int) {
+		db.SubBalance(sender, amount)
+		db.AddBalance(recipient, amount)
+	}}, vm.TxContext{Origin: from, GasPrice: new(big.Int)}, statedb, params.AllCongressProtocolChanges, vm.Config{Debug: true, Tracer: tracer})
+	statedb.PrepareAccessList(from, &contract, nil, nil)
+	if _, _, err := evm.Call(vm.AccountRef(from), contract, nil, 1000000, big.NewInt(5)); err != nil {
+		t.Fatalf("failed to execute call: %v", err)
+	}
+	statedb.Finalise(true)
+
+	diffs := diffAccounts(pre, statedb, tracer)
+	if len(diffs) != 2 {
+		t.Fatalf("diff count mismatch: have %d, want 2", len(diffs))
+	}
+	if diffs[0].Address != from || diffs[0].Pre.Balance.ToInt().Int64() != 100 || diffs[0].Post.Balance.ToInt().Int64() != 95 || diffs[0].Post.Storage != nil {
+		t.Errorf("sender diff mismatch: have %+v -> %+v", diffs[0].Pre, diffs[0].Post)
+	}
+	if diffs[1].Address != contract || diffs[1].Post.Balance.ToInt().Int64() != 5 {
+		t.Errorf("contract diff mismatch: have %+v -> %+v", diffs[1].Pre, diffs[1].Post)
+	}
+	// Only the slot actually changed is reported
+	slot := common.Hash{}
+	if len(diffs[1].Post.Storage) != 1 || diffs[1].Pre.Storage[slot] != (common.Hash{}) || diffs[1].Post.Storage[slot] != common.BigToHash(common.Big1) {
+		t.Errorf("storage diff mismatch: have %v -> %v", diffs[1].Pre.Storage, diffs[1].Post.Storage)
+	}
+	if _, ok := tracer.touched[idle]; !ok {
+		t.Errorf("called account not touched")
+	}
+}
@@ -0,0 +1,241 @@
+package congress
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/congress/signerapi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// DefaultRemoteSignerTimeout is the timeout of the requests to a remote signer
+// if none is configured.
+const DefaultRemoteSignerTimeout = 2 * time.Second
+
+// remoteUpcheckInterval is the minimum time between two upchecks of the remote
+// signer while sealing.
+const remoteUpcheckInterval = time.Second
+
+var (
+	// errRemoteSignature is returned if the signature of a remote signer doesn't
+	// recover to the requested account.
+	errRemoteSignature = errors.New("invalid remote signature")
+
+	// errUnsupportedRemoteTx is returned if a remote signer is asked to sign a
+	// transaction other than a legacy one.
+	errUnsupportedRemoteTx = errors.New("unsupported remote transaction type")
+
+	// errRemoteSignerTLS is returned if TLS files are configured for a remote
+	// signer reached over plain HTTP.
+	errRemoteSignerTLS = errors.New("TLS configured for a plain HTTP remote signer")
+)
+
+// RemoteSignerConfig is the configuration of a remote signer.
+type RemoteSignerConfig struct {
+	URL      string        // Base URL of the signer
+	Timeout  time.Duration // Timeout of each request to the signer (0 = default)
+	CertFile string        // TLS client certificate, if the signer authenticates its clients
+	KeyFile  string        // TLS client private key
+	CAFile   string        // CA certificates the signer is verified with (empty = system ones)
+}
+
+// RemoteSigner signs headers, votes and system transactions with keys held by
+// a remote service speaking a Web3Signer-style HTTP API, so that the validator
+// keys never enter the node.
+type RemoteSigner struct {
+	url    string
+	client *http.Client
+
+	reachable bool      // Whether the signer answered the last upcheck
+	checked   time.Time // Time the last upcheck completed
+	checking  bool      // Whether an upcheck is in flight
+	lock      sync.Mutex
+}
+
+// NewRemoteSigner creates a client of the remote signer at the given URL,
+// authenticating with the given TLS client certificate if any.
+func NewRemoteSigner(config RemoteSignerConfig) (*RemoteSigner, error) {
+	secure := config.CertFile != "" || config.KeyFile != "" || config.CAFile != ""
+	if secure && strings.HasPrefix(strings.ToLower(config.URL), "http://") {
+		return nil, errRemoteSignerTLS
+	}
+	tlsConfig := new(tls.Config)
+	if config.CertFile != "" || config.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	if config.CAFile != "" {
+		pem, err := ioutil.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificates: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no CA certificate in %s", config.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = DefaultRemoteSignerTimeout
+	}
+	return &RemoteSigner{
+		url: strings.TrimRight(config.URL, "/"),
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig, Proxy: http.ProxyFromEnvironment},
+		},
+		reachable: true,
+	}, nil
+}
+
+// SignData implements ValidatorFn, signing keccak256(data) with the key of the
+// account. The signature is returned with a V of 0 or 1.
+func (s *RemoteSigner) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	return s.sign(account.Address, data)
+}
+
+// SignTx implements SignTxFn. Only legacy transactions are supported, which is
+// what the system transactions are.
+func (s *RemoteSigner) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if tx.Type() != types.LegacyTxType {
+		return nil, errUnsupportedRemoteTx
+	}
+	// The signer hashes the data, send the preimage of the EIP-155 signing hash
+	signer := types.LatestSignerForChainID(chainID)
+	data, err := rlp.EncodeToBytes([]interface{}{
+		tx.Nonce(), tx.GasPrice(), tx.Gas(), tx.To(), tx.Value(), tx.Data(), chainID, uint(0), uint(0),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if crypto.Keccak256Hash(data) != signer.Hash(tx) {
+		return nil, errUnsupportedRemoteTx
+	}
+	sig, err := s.sign(account.Address, data)
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(signer, sig)
+}
+
+// sign requests the signature of keccak256(data) by the given account, checking
+// it was made by that account.
+func (s *RemoteSigner) sign(address common.Address, data []byte) ([]byte, error) {
+	body, err := json.Marshal(map[string]hexutil.Bytes{"data": data})
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.client.Post(s.url+signerapi.SignPath+address.Hex(), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	blob, err := readRemoteResponse(res)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := hexutil.Decode(strings.Trim(strings.TrimSpace(string(blob)), `"`))
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return nil, errRemoteSignature
+	}
+	// Web3Signer returns V on the form 27 or 28
+	if sig[crypto.RecoveryIDOffset] == 27 || sig[crypto.RecoveryIDOffset] == 28 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pubkey, err := crypto.SigToPub(crypto.Keccak256(data), sig)
+	if err != nil || crypto.PubkeyToAddress(*pubkey) != address {
+		return nil, errRemoteSignature
+	}
+	return sig, nil
+}
+
+// Upcheck returns whether the remote signer is reachable and able to sign.
+func (s *RemoteSigner) Upcheck() error {
+	res, err := s.client.Get(s.url + signerapi.UpcheckPath)
+	if err != nil {
+		return err
+	}
+	_, err = readRemoteResponse(res)
+	return err
+}
+
+// isReachable returns whether the remote signer answered the last upcheck,
+// without waiting for it: a new upcheck is started in the background once the
+// last one is older than remoteUpcheckInterval.
+func (s *RemoteSigner) isReachable() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.checking && time.Since(s.checked) >= remoteUpcheckInterval {
+		s.checking = true
+		go s.upcheck()
+	}
+	return s.reachable
+}
+
+// upcheck checks the remote signer is reachable, logging when it goes down or
+// comes back.
+func (s *RemoteSigner) upcheck() {
+	err := s.Upcheck()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if reachable := err == nil; reachable != s.reachable {
+		if reachable {
+			log.Info("Remote signer reachable, sealing resumed", "url", s.url)
+		} else {
+			log.Warn("Remote signer unreachable, sealing paused", "url", s.url, "err", err)
+		}
+		s.reachable = reachable
+	}
+	s.checked, s.checking = time.Now(), false
+}
+
+// readRemoteResponse reads the body of a response of the remote signer, failing
+// if the request wasn't successful.
+func readRemoteResponse(res *http.Response) ([]byte, error) {
+	defer res.Body.Close()
+
+	blob, err := ioutil.ReadAll(io.LimitReader(res.Body, 64*1024))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("remote signer error: %s: %s", res.Status, strings.TrimSpace(string(blob)))
+	}
+	return blob, nil
+}
+
+// AuthorizeRemote injects a remote signer holding the key of the validator to
+// seal blocks and sign system transactions with. The validator stops sealing
+// while the signer is unreachable.
+func (c *Congress) AuthorizeRemote(validator common.Address, signer *RemoteSigner) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.validator = validator
+	c.signFn = signer.SignData
+	c.signTxFn = signer.SignTx
+	c.remoteSigner = signer
+}
@@ -0,0 +1,196 @@
+package congress
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/congress/signerapi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	server := httptest.NewServer(signerapi.NewStub(key))
+	defer server.Close()
+
+	signer, err := NewRemoteSigner(RemoteSignerConfig{URL: server.URL, Timeout: time.Second})
+	if err != nil {
+		t.Fatalf("failed to create remote signer: %v", err)
+	}
+	// Headers are signed with a V of 0 or 1
+	header := &types.Header{Number: big.NewInt(1), Difficulty: diffInTurn, Extra: make([]byte, extraVanity+extraSeal)}
+	sig, err := signer.SignData(accounts.Account{Address: addr}, accounts.MimetypeCongress, CongressRLP(header))
+	if err != nil {
+		t.Fatalf("failed to sign header: %v", err)
+	}
+	copy(header.Extra[extraVanity:], sig)
+	sigcache, _ := lru.NewARC(1)
+	if signer, err := ecrecover(header, sigcache); err != nil || signer != addr {
+		t.Errorf("header signer mismatch: have %x, want %x, err %v", signer, addr, err)
+	}
+	// Unknown keys are refused
+	if _, err := signer.SignData(accounts.Account{Address: common.HexToAddress("0x01")}, accounts.MimetypeCongress, nil); err == nil {
+		t.Errorf("signed with unknown key")
+	}
+	// System transactions are signed with replay protection
+	chainID := big.NewInt(128)
+	tx, err := signer.SignTx(accounts.Account{Address: addr}, types.NewTransaction(1, common.HexToAddress("0x02"), big.NewInt(3), 21000, new(big.Int), []byte{4}), chainID)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	if from, err := types.Sender(types.LatestSignerForChainID(chainID), tx); err != nil || from != addr {
+		t.Errorf("transaction sender mismatch: have %x, want %x, err %v", from, addr, err)
+	}
+	// Sealing stops once the signer goes down, as seen by the background upchecks
+	signer.upcheck()
+	if !signer.isReachable() {
+		t.Fatalf("signer unreachable while up")
+	}
+	server.Close()
+	signer.upcheck()
+	if signer.isReachable() {
+		t.Errorf("signer reachable while down")
+	}
+	// TLS is refused over plain HTTP
+	if _, err := NewRemoteSigner(RemoteSignerConfig{URL: server.URL, CAFile: "ca.pem"}); err != errRemoteSignerTLS {
+		t.Errorf("error mismatch: have %v, want %v", err, errRemoteSignerTLS)
+	}
+}
+
+func TestRemoteSignerUpcheckOffSealing(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+
+	// A signer answering slowly doesn't hold the sealing up
+	release := make(chan struct{})
+	stub := signerapi.NewStub(key)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		stub.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	signer, err := NewRemoteSigner(RemoteSignerConfig{URL: server.URL, Timeout: time.Minute})
+	if err != nil {
+		t.Fatalf("failed to create remote signer: %v", err)
+	}
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		if !signer.isReachable() {
+			t.Fatalf("signer unreachable before any upcheck")
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("reachability check waited for the signer: %v", elapsed)
+	}
+}
+
+func TestRemoteSignerTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "congress-signer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The signer and its client share a self-signed certificate
+	cert, certFile, keyFile := newTestCertificate(t, dir)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert.Leaf)
+
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	server := httptest.NewUnstartedServer(signerapi.NewStub(key))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	// Clients without certificate are refused
+	signer, err := NewRemoteSigner(RemoteSignerConfig{URL: server.URL, Timeout: time.Second, CAFile: certFile})
+	if err != nil {
+		t.Fatalf("failed to create remote signer: %v", err)
+	}
+	if _, err := signer.SignData(accounts.Account{Address: addr}, accounts.MimetypeCongress, []byte{1}); err == nil {
+		t.Errorf("signed without client certificate")
+	}
+	signer, err = NewRemoteSigner(RemoteSignerConfig{URL: server.URL, Timeout: time.Second, CAFile: certFile, CertFile: certFile, KeyFile: keyFile})
+	if err != nil {
+		t.Fatalf("failed to create remote signer: %v", err)
+	}
+	if _, err := signer.SignData(accounts.Account{Address: addr}, accounts.MimetypeCongress, []byte{1}); err != nil {
+		t.Errorf("failed to sign with client certificate: %v", err)
+	}
+}
+
+// newTestCertificate creates a self-signed certificate for the local host, valid
+// for both servers and clients, and writes it along with its key to the given
+// directory.
+func newTestCertificate(t *testing.T, dir string) (tls.Certificate, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		certPEM  = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+		keyPEM   = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+		certFile = filepath.Join(dir, "cert.pem")
+		keyFile  = filepath.Join(dir, "key.pem")
+	)
+	if err := ioutil.WriteFile(certFile, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cert.Leaf, err = x509.ParseCertificate(der); err != nil {
+		t.Fatal(err)
+	}
+	return cert, certFile, keyFile
+}
@@ -0,0 +1,258 @@
+package congress
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	rewardIndexPrefix = "congress-reward-" // Database key prefix of the indexed fee shares of a block
+	inmemoryRewards   = 16                 // Number of assembled blocks whose fee shares are kept until they're sealed
+)
+
+var (
+	// errMissingBlockBody is returned if the transactions or receipts of the block
+	// the reward breakdown is requested for are not available.
+	errMissingBlockBody = errors.New("missing block body or receipts")
+
+	// errMissingParentState is returned if the reward breakdown of a block can't
+	// be computed because the state of its parent is not available.
+	errMissingParentState = errors.New("missing parent state")
+)
+
+// rewardChain defines the blockchain methods needed to compute the reward
+// breakdown of a block that is not indexed.
+type rewardChain interface {
+	consensus.ChainHeaderReader
+
+	GetBlock(hash common.Hash, number uint64) *types.Block
+	GetReceiptsByHash(hash common.Hash) types.Receipts
+}
+
+// RewardCredit is the fee credited to a developer or contract address for a
+// single transaction.
+type RewardCredit struct {
+	Address common.Address `json:"address"`
+	Amount  *hexutil.Big   `json:"amount"`
+	TxHash  common.Hash    `json:"txHash"`
+}
+
+// RewardBreakdown describes where the fees collected in a block went. The
+// validator share is the part of the fee not credited to any address, which
+// is the fee paid by contract creations before the CallFeeShare fork.
+type RewardBreakdown struct {
+	Number         uint64          `json:"number"`
+	Hash           common.Hash     `json:"hash"`
+	Validator      common.Address  `json:"validator"`
+	TotalFee       *hexutil.Big    `json:"totalFee"`
+	ValidatorShare *hexutil.Big    `json:"validatorShare"`
+	Credits        []*RewardCredit `json:"credits"`
+}
+
+// newRewardBreakdown assembles the breakdown of the given fee shares.
+func newRewardBreakdown(header *types.Header, shares []*FeeShare) *RewardBreakdown {
+	var (
+		total     = new(big.Int)
+		validator = new(big.Int)
+		credits   = make([]*RewardCredit, 0, len(shares))
+	)
+	for _, share := range shares {
+		total.Add(total, share.Amount)
+		if share.Address == (common.Address{}) {
+			validator.Add(validator, share.Amount)
+			continue
+		}
+		credits = append(credits, &RewardCredit{
+			Address: share.Address,
+			Amount:  (*hexutil.Big)(share.Amount),
+			TxHash:  share.TxHash,
+		})
+	}
+	return &RewardBreakdown{
+		Number:         header.Number.Uint64(),
+		Hash:           header.Hash(),
+		Validator:      header.Coinbase,
+		TotalFee:       (*hexutil.Big)(total),
+		ValidatorShare: (*hexutil.Big)(validator),
+		Credits:        credits,
+	}
+}
+
+// EnableRewardIndex makes the engine store the fee shares of every imported
+// or locally sealed block, so that their reward breakdowns don't need to be
+// recomputed.
+func (c *Congress) EnableRewardIndex() {
+	c.rewardIndex = true
+	c.assembledRewards, _ = lru.New(inmemoryRewards)
+}
+
+// rewardShares computes the fee shares of a block being imported or assembled.
+// It must be called before the fees are moved out of the fee recorder.
+func (c *Congress) rewardShares(header *types.Header, state *state.StateDB, txs []*types.Transaction, receipts []*types.Receipt) ([]*FeeShare, bool) {
+	shares, err := c.blockFeeShares(header, txs, receipts, state.GetBalance(consensus.FeeRecoder))
+	if err != nil {
+		log.Warn("Failed to compute fee shares", "number", header.Number, "err", err)
+		return nil, false
+	}
+	return shares, true
+}
+
+// indexRewards stores the fee shares of a block that is being imported. It must
+// be called before the fees are moved out of the fee recorder.
+func (c *Congress) indexRewards(header *types.Header, state *state.StateDB, txs []*types.Transaction, receipts []*types.Receipt) {
+	if shares, ok := c.rewardShares(header, state, txs, receipts); ok {
+		c.writeIndexedRewards(header, shares)
+	}
+}
+
+// stashRewards keeps the fee shares of an assembled block until it's sealed,
+// its hash being unknown before.
+func (c *Congress) stashRewards(header *types.Header, shares []*FeeShare) {
+	c.assembledRewards.Add(SealHash(header), shares)
+}
+
+// indexSealedRewards stores the fee shares stashed for a block that was just
+// sealed, if any.
+func (c *Congress) indexSealedRewards(header *types.Header) {
+	if c.assembledRewards == nil {
+		return
+	}
+	if shares, ok := c.assembledRewards.Get(SealHash(header)); ok {
+		c.writeIndexedRewards(header, shares.([]*FeeShare))
+	}
+}
+
+// writeIndexedRewards stores the fee shares of a block.
+func (c *Congress) writeIndexedRewards(header *types.Header, shares []*FeeShare) {
+	blob, err := rlp.EncodeToBytes(shares)
+	if err != nil {
+		log.Error("Failed to encode fee shares", "err", err)
+		return
+	}
+	if err := c.db.Put(append([]byte(rewardIndexPrefix), header.Hash().Bytes()...), blob); err != nil {
+		log.Error("Failed to store fee shares", "number", header.Number, "err", err)
+	}
+}
+
+// readIndexedRewards retrieves the indexed fee shares of a block, if any.
+func (c *Congress) readIndexedRewards(hash common.Hash) ([]*FeeShare, bool) {
+	blob, err := c.db.Get(append([]byte(rewardIndexPrefix), hash.Bytes()...))
+	if err != nil {
+		return nil, false
+	}
+	var shares []*FeeShare
+	if err := rlp.DecodeBytes(blob, &shares); err != nil {
+		log.Error("Invalid indexed fee shares", "hash", hash, "err", err)
+		return nil, false
+	}
+	return shares, true
+}
+
+// rewardBreakdown returns the reward breakdown of the given block, either from
+// the index or by recomputing the fee shares from the block receipts and the
+// fee recorder balance left in the parent state. After the CallFeeShare fork
+// the gas consumed by every code isn't stored, so the block is re-executed.
+func (c *Congress) rewardBreakdown(chain consensus.ChainHeaderReader, header *types.Header) (*RewardBreakdown, error) {
+	if shares, ok := c.readIndexedRewards(header.Hash()); ok {
+		return newRewardBreakdown(header, shares), nil
+	}
+	rc, ok := chain.(rewardChain)
+	if !ok {
+		return nil, errMissingBlockBody
+	}
+	number := header.Number.Uint64()
+	block := rc.GetBlock(header.Hash(), number)
+	receipts := rc.GetReceiptsByHash(header.Hash())
+	if block == nil || len(receipts) != len(block.Transactions()) {
+		return nil, errMissingBlockBody
+	}
+	parent := chain.GetHeader(header.ParentHash, number-1)
+	if parent == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	if c.stateFn == nil {
+		return nil, errMissingParentState
+	}
+	statedb, err := c.stateFn(parent.Root)
+	if err != nil {
+		return nil, errMissingParentState
+	}
+	if c.chainConfig.IsCallFeeShare(header.Number) {
+		txs, receipts, err := c.replayTransactions(chain, block, statedb)
+		if err != nil {
+			return nil, err
+		}
+		shares, err := c.blockFeeShares(header, txs, receipts, statedb.GetBalance(consensus.FeeRecoder))
+		if err != nil {
+			return nil, err
+		}
+		return newRewardBreakdown(header, shares), nil
+	}
+	// Every transaction except the system ones pays its tip to the fee recorder
+	var (
+		txs    []*types.Transaction
+		txRcps []*types.Receipt
+		fee    = new(big.Int).Set(statedb.GetBalance(consensus.FeeRecoder))
+	)
+	for i, tx := range block.Transactions() {
+		sender, err := types.Sender(c.signer, tx)
+		if err != nil {
+			return nil, err
+		}
+		if isSys, _ := c.IsSysTransaction(sender, tx, header); isSys {
+			continue
+		}
+		txs = append(txs, tx)
+		txRcps = append(txRcps, receipts[i])
+		fee.Add(fee, new(big.Int).Mul(new(big.Int).SetUint64(receipts[i].GasUsed), tx.EffectiveGasTipValue(header.BaseFee)))
+	}
+	shares, err := c.blockFeeShares(header, txs, txRcps, fee)
+	if err != nil {
+		return nil, err
+	}
+	return newRewardBreakdown(header, shares), nil
+}
+
+// replayTransactions re-executes the non-system transactions of a block on top
+// of its parent state, returning them along with their fresh receipts.
+func (c *Congress) replayTransactions(chain consensus.ChainHeaderReader, block *types.Block, statedb *state.StateDB) ([]*types.Transaction, []*types.Receipt, error) {
+	header := block.Header()
+	if err := c.PreHandle(chain, header, statedb); err != nil {
+		return nil, nil, err
+	}
+	var (
+		txs       []*types.Transaction
+		receipts  []*types.Receipt
+		usedGas   uint64
+		gp        = new(core.GasPool).AddGas(header.GasLimit)
+		validator = c.CreateEvmExtraValidator(header, statedb)
+	)
+	for i, tx := range block.Transactions() {
+		sender, err := types.Sender(c.signer, tx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if isSys, _ := c.IsSysTransaction(sender, tx, header); isSys {
+			continue
+		}
+		statedb.Prepare(tx.Hash(), i)
+		receipt, err := core.ApplyTransaction(c.chainConfig, newChainContext(chain, c), nil, gp, statedb, header, tx, &usedGas, vm.Config{}, validator)
+		if err != nil {
+			return nil, nil, err
+		}
+		txs = append(txs, tx)
+		receipts = append(receipts, receipt)
+	}
+	return txs, receipts, nil
+}
@@ -0,0 +1,102 @@
+package congress
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestRewardIndex(t *testing.T) {
+	config := &params.ChainConfig{ChainID: big.NewInt(1), FeeShareBlock: big.NewInt(0), Congress: &params.CongressConfig{Period: 3, Epoch: 200}}
+	db := rawdb.NewMemoryDatabase()
+	engine := New(config, db)
+	engine.EnableRewardIndex()
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	statedb.AddBalance(consensus.FeeRecoder, big.NewInt(21000*2+53000*3))
+
+	to := common.HexToAddress("0x1000")
+	txs := []*types.Transaction{
+		types.NewTx(&types.LegacyTx{To: &to, Gas: 50000, GasPrice: big.NewInt(2)}),
+		types.NewTx(&types.LegacyTx{Gas: 100000, GasPrice: big.NewInt(3)}),
+	}
+	receipts := []*types.Receipt{{GasUsed: 21000}, {GasUsed: 53000}}
+	header := &types.Header{Number: big.NewInt(10), Coinbase: common.HexToAddress("0xc0ffee"), Difficulty: diffInTurn}
+
+	if _, ok := engine.readIndexedRewards(header.Hash()); ok {
+		t.Fatalf("rewards indexed before import")
+	}
+	engine.indexRewards(header, statedb, txs, receipts)
+
+	breakdown, err := engine.rewardBreakdown(nil, header)
+	if err != nil {
+		t.Fatalf("failed to retrieve indexed breakdown: %v", err)
+	}
+	if breakdown.Hash != header.Hash() || breakdown.Validator != header.Coinbase {
+		t.Errorf("block mismatch: have %x/%x, want %x/%x", breakdown.Hash, breakdown.Validator, header.Hash(), header.Coinbase)
+	}
+	if have := breakdown.TotalFee.ToInt(); have.Int64() != 21000*2+53000*3 {
+		t.Errorf("total fee mismatch: have %v, want %v", have, 21000*2+53000*3)
+	}
+	// The contract creation fee is left to the validator
+	if have := breakdown.ValidatorShare.ToInt(); have.Int64() != 53000*3 {
+		t.Errorf("validator share mismatch: have %v, want %v", have, 53000*3)
+	}
+	if len(breakdown.Credits) != 1 {
+		t.Fatalf("credit count mismatch: have %d, want 1", len(breakdown.Credits))
+	}
+	credit := breakdown.Credits[0]
+	if credit.Address != to || credit.TxHash != txs[0].Hash() || credit.Amount.ToInt().Int64() != 21000*2 {
+		t.Errorf("credit mismatch: have %x/%x/%v", credit.Address, credit.TxHash, credit.Amount)
+	}
+}
+
+func TestLegacyRewardIndex(t *testing.T) {
+	config := &params.ChainConfig{ChainID: big.NewInt(1), Congress: &params.CongressConfig{Period: 3, Epoch: 200}}
+	engine := New(config, rawdb.NewMemoryDatabase())
+	engine.EnableRewardIndex()
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	statedb.AddBalance(consensus.FeeRecoder, big.NewInt(500000))
+
+	to := common.HexToAddress("0x1000")
+	txs := []*types.Transaction{
+		types.NewTx(&types.LegacyTx{To: &to, Gas: 50000, GasPrice: big.NewInt(2)}),
+		types.NewTx(&types.LegacyTx{Gas: 100000, GasPrice: big.NewInt(3)}),
+	}
+	receipts := []*types.Receipt{{GasUsed: 21000}, {GasUsed: 53000}}
+	header := &types.Header{Number: big.NewInt(10), Coinbase: common.HexToAddress("0xc0ffee"), Difficulty: diffInTurn, Extra: make([]byte, extraVanity+extraSeal)}
+
+	// Blocks assembled locally are indexed once sealed
+	shares, ok := engine.rewardShares(header, statedb, txs, receipts)
+	if !ok {
+		t.Fatalf("failed to compute fee shares")
+	}
+	engine.stashRewards(header, shares)
+
+	sealed := types.CopyHeader(header)
+	sealed.Extra[len(sealed.Extra)-1] = 1
+	engine.indexSealedRewards(sealed)
+
+	breakdown, err := engine.rewardBreakdown(nil, sealed)
+	if err != nil {
+		t.Fatalf("failed to retrieve indexed breakdown: %v", err)
+	}
+	if have := breakdown.TotalFee.ToInt(); have.Int64() != 500000 {
+		t.Errorf("total fee mismatch: have %v, want %v", have, 500000)
+	}
+	// The gas limit weights are distributed, the rest of the fee is left to
+	// the validator along with the contract creation weight
+	if have := breakdown.ValidatorShare.ToInt(); have.Int64() != 300000+100000 {
+		t.Errorf("validator share mismatch: have %v, want %v", have, 300000+100000)
+	}
+	if len(breakdown.Credits) != 1 || breakdown.Credits[0].Amount.ToInt().Int64() != 100000 {
+		t.Errorf("credits mismatch: have %v", breakdown.Credits)
+	}
+}
@@ -0,0 +1,74 @@
+// Package signerapi describes the Web3Signer-style HTTP API congress validators
+// sign with when their keys are held by a remote service, and implements a stub
+// of such a signer holding its keys in memory, for tests.
+package signerapi
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Paths of the API served by a remote signer.
+const (
+	SignPath    = "/api/v1/eth1/sign/" // POST {"data": hex}, followed by the signing address, returns the hex signature of keccak256(data)
+	UpcheckPath = "/upcheck"           // GET, succeeds while the signer is able to sign
+)
+
+// Stub is a remote signer holding its keys in memory. Never use it with
+// valuable keys.
+type Stub struct {
+	keys map[common.Address]*ecdsa.PrivateKey
+}
+
+// NewStub creates a stub remote signer signing with the given keys.
+func NewStub(keys ...*ecdsa.PrivateKey) *Stub {
+	s := &Stub{keys: make(map[common.Address]*ecdsa.PrivateKey)}
+	for _, key := range keys {
+		s.keys[crypto.PubkeyToAddress(key.PublicKey)] = key
+	}
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Stub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == UpcheckPath:
+		fmt.Fprint(w, "OK")
+
+	case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, SignPath):
+		address := strings.TrimPrefix(r.URL.Path, SignPath)
+		if !common.IsHexAddress(address) {
+			http.Error(w, "invalid address", http.StatusBadRequest)
+			return
+		}
+		key, ok := s.keys[common.HexToAddress(address)]
+		if !ok {
+			http.Error(w, "unknown key", http.StatusNotFound)
+			return
+		}
+		var req struct {
+			Data hexutil.Bytes `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		sig, err := crypto.Sign(crypto.Keccak256(req.Data), key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sig[crypto.RecoveryIDOffset] += 27 // Web3Signer returns V on the form 27 or 28
+		fmt.Fprint(w, hexutil.Encode(sig))
+
+	default:
+		http.NotFound(w, r)
+	}
+}
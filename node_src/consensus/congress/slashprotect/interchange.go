@@ -0,0 +1,125 @@
+package slashprotect
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+// InterchangeVersion is the version of the interchange format produced by Export.
+const InterchangeVersion = "1"
+
+var (
+	// errInterchangeVersion is returned if an interchange file has an unsupported
+	// format version.
+	errInterchangeVersion = errors.New("unsupported interchange format version")
+
+	// errGenesisMismatch is returned if an interchange file was exported for a
+	// different chain.
+	errGenesisMismatch = errors.New("interchange genesis mismatch")
+)
+
+// Interchange is the JSON document moving the signing history of validators
+// between slashing-protection databases.
+type Interchange struct {
+	Metadata InterchangeMetadata `json:"metadata"`
+	Data     []*ValidatorHistory `json:"data"`
+}
+
+// InterchangeMetadata identifies the format and the chain of an interchange file.
+type InterchangeMetadata struct {
+	Version     string      `json:"interchangeFormatVersion"`
+	GenesisHash common.Hash `json:"genesisHash"`
+}
+
+// ValidatorHistory is the list of headers signed by a validator, in ascending
+// order of height.
+type ValidatorHistory struct {
+	Validator    common.Address `json:"validator"`
+	SignedBlocks []*SignedBlock `json:"signedBlocks"`
+}
+
+// SignedBlock is the seal hash of the header signed at some height.
+type SignedBlock struct {
+	Number   math.HexOrDecimal64 `json:"number"`
+	SealHash common.Hash         `json:"sealHash"`
+}
+
+// Export returns the signing history of every validator in the database, for
+// the chain with the given genesis hash.
+func (db *DB) Export(genesis common.Hash) (*Interchange, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	ic := &Interchange{
+		Metadata: InterchangeMetadata{Version: InterchangeVersion, GenesisHash: genesis},
+		Data:     []*ValidatorHistory{},
+	}
+	it := db.db.NewIterator(signedPrefix, nil)
+	defer it.Release()
+
+	var history *ValidatorHistory
+	for it.Next() {
+		key, value := it.Key(), it.Value()
+		if len(key) != len(signedPrefix)+common.AddressLength+8 || len(value) != common.HashLength {
+			continue
+		}
+		validator := common.BytesToAddress(key[len(signedPrefix) : len(signedPrefix)+common.AddressLength])
+		if history == nil || history.Validator != validator {
+			history = &ValidatorHistory{Validator: validator}
+			ic.Data = append(ic.Data, history)
+		}
+		history.SignedBlocks = append(history.SignedBlocks, &SignedBlock{
+			Number:   math.HexOrDecimal64(binary.BigEndian.Uint64(key[len(signedPrefix)+common.AddressLength:])),
+			SealHash: common.BytesToHash(value),
+		})
+	}
+	return ic, it.Error()
+}
+
+// Import merges the signing history of an interchange file exported for the
+// chain with the given genesis hash, returning the number of new records. The
+// file is rejected as a whole if it conflicts with the history in the database.
+func (db *DB) Import(ic *Interchange, genesis common.Hash) (int, error) {
+	if ic.Metadata.Version != InterchangeVersion {
+		return 0, fmt.Errorf("%w: %q", errInterchangeVersion, ic.Metadata.Version)
+	}
+	if ic.Metadata.GenesisHash != genesis {
+		return 0, fmt.Errorf("%w: have %x, want %x", errGenesisMismatch, ic.Metadata.GenesisHash, genesis)
+	}
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	var (
+		batch   = db.db.NewBatch()
+		records = make(map[string]common.Hash)
+	)
+	for _, history := range ic.Data {
+		for _, block := range history.SignedBlocks {
+			number := uint64(block.Number)
+			key := signedKey(history.Validator, number)
+
+			prev, ok := records[string(key)]
+			if !ok {
+				prev, ok = db.SignedBlock(history.Validator, number)
+			}
+			if ok {
+				if prev != block.SealHash {
+					return 0, fmt.Errorf("%w: validator %x signed both %x and %x at block %d", ErrDoubleSign, history.Validator, prev, block.SealHash, number)
+				}
+				continue
+			}
+			records[string(key)] = block.SealHash
+			if err := batch.Put(key, block.SealHash.Bytes()); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+	return len(records), nil
+}
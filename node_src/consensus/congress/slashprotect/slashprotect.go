@@ -0,0 +1,100 @@
+// Package slashprotect implements the slashing-protection database of a
+// congress validator, recording every header it signed so that it never signs
+// two different headers at the same height, even across restarts or hosts.
+package slashprotect
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+// DirName is the directory of the slashing-protection database, relative to the
+// instance directory of the node. It's kept apart from the chain database so
+// that it survives a resync.
+const DirName = "slashing-protection"
+
+const (
+	dbCache   = 16 // Megabytes of memory allocated to the database cache
+	dbHandles = 16 // Number of files handles allocated to the database
+)
+
+// signedPrefix + validator + number (uint64 big endian) -> seal hash
+var signedPrefix = []byte("signed-")
+
+// ErrDoubleSign is returned if the validator is asked to sign a header at a
+// height where it already signed a different one.
+var ErrDoubleSign = errors.New("refusing to sign conflicting header")
+
+// DB is the slashing-protection database of the local validators.
+type DB struct {
+	db   ethdb.KeyValueStore
+	lock sync.Mutex // Makes the checks and records atomic
+}
+
+// New creates a slashing-protection database on top of the given store.
+func New(db ethdb.KeyValueStore) *DB {
+	return &DB{db: db}
+}
+
+// Open opens the slashing-protection database at the given path, or an
+// in-memory one if the path is empty.
+func Open(path string) (*DB, error) {
+	if path == "" {
+		return New(memorydb.New()), nil
+	}
+	db, err := leveldb.New(path, dbCache, dbHandles, "", false)
+	if err != nil {
+		return nil, err
+	}
+	return New(db), nil
+}
+
+// Close closes the underlying database.
+func (db *DB) Close() error {
+	return db.db.Close()
+}
+
+// signedKey returns the database key of the header signed by the validator at
+// the given height.
+func signedKey(validator common.Address, number uint64) []byte {
+	key := make([]byte, len(signedPrefix)+common.AddressLength+8)
+	copy(key, signedPrefix)
+	copy(key[len(signedPrefix):], validator.Bytes())
+	binary.BigEndian.PutUint64(key[len(signedPrefix)+common.AddressLength:], number)
+	return key
+}
+
+// SignedBlock returns the seal hash of the header signed by the validator at
+// the given height, if any.
+func (db *DB) SignedBlock(validator common.Address, number uint64) (common.Hash, bool) {
+	blob, err := db.db.Get(signedKey(validator, number))
+	if err != nil || len(blob) != common.HashLength {
+		return common.Hash{}, false
+	}
+	return common.BytesToHash(blob), true
+}
+
+// CheckAndRecord records that the validator is about to sign the header with
+// the given seal hash at the given height. It fails with ErrDoubleSign if the
+// validator already signed a different header there, signing the same header
+// again is allowed. The record is persisted before returning, so it must be
+// called before the header is signed.
+func (db *DB) CheckAndRecord(validator common.Address, number uint64, sealHash common.Hash) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if prev, ok := db.SignedBlock(validator, number); ok {
+		if prev != sealHash {
+			return fmt.Errorf("%w: validator %x already signed %x at block %d", ErrDoubleSign, validator, prev, number)
+		}
+		return nil
+	}
+	return db.db.Put(signedKey(validator, number), sealHash.Bytes())
+}
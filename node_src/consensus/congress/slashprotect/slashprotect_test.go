@@ -0,0 +1,93 @@
+package slashprotect
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+func TestCheckAndRecord(t *testing.T) {
+	var (
+		db        = New(memorydb.New())
+		validator = common.HexToAddress("0x1000")
+		other     = common.HexToAddress("0x2000")
+	)
+	if err := db.CheckAndRecord(validator, 10, common.HexToHash("0x01")); err != nil {
+		t.Fatalf("failed to record first header: %v", err)
+	}
+	// Signing the same header again is allowed
+	if err := db.CheckAndRecord(validator, 10, common.HexToHash("0x01")); err != nil {
+		t.Errorf("failed to record same header: %v", err)
+	}
+	// Signing a different header at the same height isn't
+	if err := db.CheckAndRecord(validator, 10, common.HexToHash("0x02")); !errors.Is(err, ErrDoubleSign) {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrDoubleSign)
+	}
+	// Other heights and validators are independent
+	if err := db.CheckAndRecord(validator, 11, common.HexToHash("0x02")); err != nil {
+		t.Errorf("failed to record next header: %v", err)
+	}
+	if err := db.CheckAndRecord(other, 10, common.HexToHash("0x02")); err != nil {
+		t.Errorf("failed to record header of other validator: %v", err)
+	}
+	if hash, ok := db.SignedBlock(validator, 10); !ok || hash != common.HexToHash("0x01") {
+		t.Errorf("signed block mismatch: have %x/%v, want %x", hash, ok, common.HexToHash("0x01"))
+	}
+}
+
+func TestInterchange(t *testing.T) {
+	var (
+		src       = New(memorydb.New())
+		genesis   = common.HexToHash("0xdead")
+		validator = common.HexToAddress("0x1000")
+		other     = common.HexToAddress("0x2000")
+	)
+	src.CheckAndRecord(validator, 300, common.HexToHash("0x03"))
+	src.CheckAndRecord(validator, 1, common.HexToHash("0x01"))
+	src.CheckAndRecord(other, 2, common.HexToHash("0x02"))
+
+	ic, err := src.Export(genesis)
+	if err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+	blob, err := json.Marshal(ic)
+	if err != nil {
+		t.Fatalf("failed to encode interchange: %v", err)
+	}
+	ic = new(Interchange)
+	if err := json.Unmarshal(blob, ic); err != nil {
+		t.Fatalf("failed to decode interchange: %v", err)
+	}
+	if len(ic.Data) != 2 || ic.Data[0].Validator != validator || len(ic.Data[0].SignedBlocks) != 2 || ic.Data[0].SignedBlocks[1].Number != 300 {
+		t.Fatalf("exported history mismatch: %s", blob)
+	}
+	// The history can only be imported on the same chain
+	dst := New(memorydb.New())
+	if _, err := dst.Import(ic, common.HexToHash("0xbeef")); !errors.Is(err, errGenesisMismatch) {
+		t.Errorf("error mismatch: have %v, want %v", err, errGenesisMismatch)
+	}
+	// Conflicting histories are rejected as a whole
+	dst.CheckAndRecord(other, 2, common.HexToHash("0x04"))
+	if _, err := dst.Import(ic, genesis); !errors.Is(err, ErrDoubleSign) {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrDoubleSign)
+	}
+	if _, ok := dst.SignedBlock(validator, 1); ok {
+		t.Errorf("conflicting interchange partially imported")
+	}
+	// Overlapping histories are merged
+	dst = New(memorydb.New())
+	dst.CheckAndRecord(validator, 1, common.HexToHash("0x01"))
+	n, err := dst.Import(ic, genesis)
+	if err != nil {
+		t.Fatalf("failed to import: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("imported record count mismatch: have %d, want %d", n, 2)
+	}
+	if err := dst.CheckAndRecord(validator, 300, common.HexToHash("0x05")); !errors.Is(err, ErrDoubleSign) {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrDoubleSign)
+	}
+}
@@ -21,7 +21,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"sort"
-  "errors"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
@@ -40,6 +39,8 @@ type Snapshot struct {
 	Hash       common.Hash                 `json:"hash"`       // Block hash where the snapshot was created
 	Validators map[common.Address]struct{} `json:"validators"` // Set of authorized validators at this moment
 	Recents    map[uint64]common.Address   `json:"recents"`    // Set of recent validators for spam protections
+
+	Keys map[common.Address]common.Address `json:"keys,omitempty"` // Consensus keys of the validators not signing themselves
 }
 
 // validatorsAscending implements the sort interface to allow sorting a list of addresses
@@ -60,6 +61,7 @@ func newSnapshot(config *params.CongressConfig, sigcache *lru.ARCCache, number u
 		Hash:       hash,
 		Validators: make(map[common.Address]struct{}),
 		Recents:    make(map[uint64]common.Address),
+		Keys:       make(map[common.Address]common.Address),
 	}
 	for _, validator := range validators {
 		snap.Validators[validator] = struct{}{}
@@ -101,6 +103,7 @@ func (s *Snapshot) copy() *Snapshot {
 		Hash:       s.Hash,
 		Validators: make(map[common.Address]struct{}),
 		Recents:    make(map[uint64]common.Address),
+		Keys:       make(map[common.Address]common.Address),
 	}
 	for validator := range s.Validators {
 		cpy.Validators[validator] = struct{}{}
@@ -108,6 +111,9 @@ func (s *Snapshot) copy() *Snapshot {
 	for block, validator := range s.Recents {
 		cpy.Recents[block] = validator
 	}
+	for validator, key := range s.Keys {
+		cpy.Keys[validator] = key
+	}
 
 	return cpy
 }
@@ -132,14 +138,16 @@ func (s *Snapshot) apply(headers []*types.Header, chain consensus.ChainHeaderRea
 	for _, header := range headers {
 		// Remove any votes on checkpoint blocks
 		number := header.Number.Uint64()
-		// Delete the oldest validator from the recent list to allow it signing again
-		var limit uint64
-		if len(snap.Validators) > 21 || len(snap.Validators) == 1 {
-			limit = uint64(len(snap.Validators)/2 + 1)
-		} else { //if number > 9299500 {
-			limit = 2
-		}
-		if number >= limit {
+		// Delete the validators out of the recents window to allow them signing again
+		limit := s.config.RecentsLimit(number, len(snap.Validators))
+		if s.config.IsRecents(number) {
+			for seen := range snap.Recents {
+				if seen+limit <= number {
+					delete(snap.Recents, seen)
+				}
+			}
+		} else if number >= limit {
+			// Legacy window, kept as is for the blocks before the fork
 			for i := uint64(0); i < limit; i++ {
 				delete(snap.Recents, number-limit+i)
 			}
@@ -149,25 +157,26 @@ func (s *Snapshot) apply(headers []*types.Header, chain consensus.ChainHeaderRea
 		if err != nil {
 			return nil, err
 		}
+		// From the consensus key fork the validator is the coinbase, signing
+		// with its consensus key
+		if s.config.IsConsensusKey(number) {
+			if snap.signingKey(header.Coinbase) != validator {
+				return nil, errInvalidConsensusKey
+			}
+			validator = header.Coinbase
+		}
 		if _, ok := snap.Validators[validator]; !ok {
 			return nil, errUnauthorizedValidator
 		}
-		for _, recent := range snap.Recents {
-			if recent == validator {
-				return nil, errors.New("signed recently location 2")
-			}
+		if snap.signedRecently(number, validator) {
+			return nil, errRecentlySigned
 		}
 		snap.Recents[number] = validator
 
 		// update validators at the first block at epoch
-		if number > 0 && number%s.config.Epoch == 0 {
-			checkpointHeader := header
-
+		if number > 0 && number%s.config.EpochAt(number) == 0 {
 			// get validators from headers and use that for new validator set
-			validators := make([]common.Address, (len(checkpointHeader.Extra)-extraVanity-extraSeal)/common.AddressLength)
-			for i := 0; i < len(validators); i++ {
-				copy(validators[i][:], checkpointHeader.Extra[extraVanity+i*common.AddressLength:])
-			}
+			validators, keys := parseCheckpoint(s.config, header)
 
 			newValidators := make(map[common.Address]struct{})
 			for _, validator := range validators {
@@ -175,17 +184,16 @@ func (s *Snapshot) apply(headers []*types.Header, chain consensus.ChainHeaderRea
 			}
 
 			// Need to delete recorded recent seen blocks if necessary, it may pause whole chain when validators length decreases.
-			var epochLimit uint64      
-			if len(newValidators) > 21 || len(newValidators) == 1 {
-				epochLimit = uint64(len(newValidators)/2 + 1)
-			} else { //if number > 9299500 {
-				epochLimit = 2
-			} 
-			for i := 0; i < len(snap.Validators)/2-len(newValidators)/2; i++ {
-				delete(snap.Recents, number-epochLimit-uint64(i))
+			// The recents window drops them on its own, as it shrinks along with the set.
+			if !s.config.IsRecents(number) {
+				epochLimit := s.config.RecentsLimit(number, len(newValidators))
+				for i := 0; i < len(snap.Validators)/2-len(newValidators)/2; i++ {
+					delete(snap.Recents, number-epochLimit-uint64(i))
+				}
 			}
 
 			snap.Validators = newValidators
+			snap.Keys = consensusKeys(validators, keys)
 		}
 	}
 
@@ -196,6 +204,41 @@ func (s *Snapshot) apply(headers []*types.Header, chain consensus.ChainHeaderRea
 }
 
 
+// signedRecently returns whether the validator sealed a block within the recents
+// window of the given block, see params.CongressConfig.RecentsLimit. Within the
+// first blocks of the chain the window covers every block sealed so far.
+func (s *Snapshot) signedRecently(number uint64, validator common.Address) bool {
+	limit := s.config.RecentsLimit(number, len(s.Validators))
+	for seen, recent := range s.Recents {
+		if recent == validator && seen+limit > number {
+			return true
+		}
+	}
+	return false
+}
+
+// signingKey returns the key signing on behalf of the validator, the validator
+// itself unless it set a consensus key.
+func (s *Snapshot) signingKey(validator common.Address) common.Address {
+	if key, ok := s.Keys[validator]; ok {
+		return key
+	}
+	return validator
+}
+
+// validatorOf returns the validator the given key signs on behalf of.
+func (s *Snapshot) validatorOf(key common.Address) (common.Address, bool) {
+	for validator, k := range s.Keys {
+		if k == key {
+			return validator, true
+		}
+	}
+	if _, ok := s.Validators[key]; ok && s.signingKey(key) == key {
+		return key, true
+	}
+	return common.Address{}, false
+}
+
 // validators retrieves the list of authorized validators in ascending order.
 func (s *Snapshot) validators() []common.Address {
 	sigs := make([]common.Address, 0, len(s.Validators))
@@ -0,0 +1,451 @@
+package congress
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/congress/systemcontract"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// newCheckpointHeader creates a header sealed by the key, listing the given
+// validators in its extra-data.
+func newCheckpointHeader(t *testing.T, key *ecdsa.PrivateKey, parent *types.Header, validators []common.Address) *types.Header {
+	return newCoinbaseHeader(t, key, crypto.PubkeyToAddress(key.PublicKey), parent, validators)
+}
+
+// newCoinbaseHeader creates a header of the given coinbase sealed by the key,
+// listing the given entries in its extra-data.
+func newCoinbaseHeader(t *testing.T, key *ecdsa.PrivateKey, coinbase common.Address, parent *types.Header, entries []common.Address) *types.Header {
+	extra := make([]byte, extraVanity, extraVanity+len(entries)*common.AddressLength+extraSeal)
+	for _, entry := range entries {
+		extra = append(extra, entry.Bytes()...)
+	}
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		Difficulty: big.NewInt(2),
+		Time:       parent.Time + 3,
+		Coinbase:   coinbase,
+		Extra:      append(extra, make([]byte, extraSeal)...),
+	}
+	sig, err := crypto.Sign(SealHash(header).Bytes(), key)
+	if err != nil {
+		t.Fatalf("failed to sign header: %v", err)
+	}
+	copy(header.Extra[len(header.Extra)-extraSeal:], sig)
+	return header
+}
+
+func TestValidatorParamsOverride(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	var (
+		validator = crypto.PubkeyToAddress(key.PublicKey)
+		joiner    = common.HexToAddress("0x1000")
+		genesis   = &types.Header{Number: new(big.Int)}
+	)
+	tests := []struct {
+		config     params.CongressConfig
+		epochs     []uint64 // epoch length at blocks 3 and 4
+		maxVals    []int    // validator cap at blocks 3 and 4
+		validators int      // validators after block 6
+	}{
+		// No override, block 6 isn't a checkpoint
+		{params.CongressConfig{Epoch: 4}, []uint64{4, 4}, []int{maxValidators, maxValidators}, 1},
+		// Shorter epochs from block 4, block 6 updates the validators
+		{params.CongressConfig{Epoch: 4, ValidatorParamsBlock: big.NewInt(4), EpochOverride: 2, MaxValidators: 15}, []uint64{4, 2}, []int{maxValidators, 15}, 2},
+	}
+	for i, tt := range tests {
+		engine := New(&params.ChainConfig{ChainID: big.NewInt(1), Congress: &tt.config}, rawdb.NewMemoryDatabase())
+		for j, number := range []uint64{3, 4} {
+			if have := engine.config.EpochAt(number); have != tt.epochs[j] {
+				t.Errorf("test %d: epoch at %d mismatch: have %d, want %d", i, number, have, tt.epochs[j])
+			}
+			if have := engine.maxValidatorsAt(number); have != tt.maxVals[j] {
+				t.Errorf("test %d: max validators at %d mismatch: have %d, want %d", i, number, have, tt.maxVals[j])
+			}
+		}
+		var (
+			headers []*types.Header
+			parent  = genesis
+		)
+		for number := uint64(1); number <= 6; number++ {
+			var validators []common.Address
+			switch number {
+			case 4:
+				validators = []common.Address{validator}
+			case 6:
+				validators = []common.Address{validator, joiner}
+			}
+			parent = newCheckpointHeader(t, key, parent, validators)
+			headers = append(headers, parent)
+		}
+		snap := newSnapshot(engine.config, engine.signatures, 0, genesis.Hash(), []common.Address{validator})
+		snap, err := snap.apply(headers, nil, nil)
+		if err != nil {
+			t.Fatalf("test %d: failed to apply headers: %v", i, err)
+		}
+		if len(snap.Validators) != tt.validators {
+			t.Errorf("test %d: validator count mismatch: have %d, want %d", i, len(snap.Validators), tt.validators)
+		}
+	}
+}
+
+func TestValidatorCapAboveContract(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	spec := &GenesisSpec{ChainID: big.NewInt(28525), Period: 3, Epoch: 4, GasLimit: 30000000, Validators: []common.Address{crypto.PubkeyToAddress(key.PublicKey)}}
+	genesis, err := spec.Genesis()
+	if err != nil {
+		t.Fatalf("failed to assemble genesis: %v", err)
+	}
+	// The validators contract is replaced by one electing 25 validators ahead of
+	// the switch to a cap of 30
+	var elected []common.Address
+	for i := 1; i <= 25; i++ {
+		elected = append(elected, common.BigToAddress(big.NewInt(int64(i))))
+	}
+	payload := append(common.BigToHash(big.NewInt(32)).Bytes(), common.BigToHash(big.NewInt(int64(len(elected)))).Bytes()...)
+	for _, validator := range elected {
+		payload = append(payload, common.BytesToHash(validator.Bytes()).Bytes()...)
+	}
+	code := append([]byte{0x61, byte(len(payload) >> 8), byte(len(payload)), 0x80, 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, 0x00, 0xf3}, payload...)
+
+	config := genesis.Config.Congress
+	config.ValidatorParamsBlock, config.MaxValidators = big.NewInt(4), 30
+	if err := genesis.Config.CheckConfigForkOrder(); err == nil {
+		t.Fatalf("cap above the validators contract accepted without upgrade")
+	}
+	config.Upgrades = []params.SysContractUpgrade{{Block: big.NewInt(2), Address: systemcontract.ValidatorsContractAddr, Code: code}}
+	if err := genesis.Config.CheckConfigForkOrder(); err != nil {
+		t.Fatalf("failed to check config: %v", err)
+	}
+	maker, err := NewChainMaker(rawdb.NewMemoryDatabase(), genesis, key)
+	if err != nil {
+		t.Fatalf("failed to create chain maker: %v", err)
+	}
+	defer maker.Stop()
+
+	if err := maker.MineUntil(3); err != nil {
+		t.Fatalf("failed to mine: %v", err)
+	}
+	head := maker.Chain().CurrentHeader()
+	header := &types.Header{ParentHash: head.Hash(), Number: big.NewInt(4), Coinbase: head.Coinbase}
+	validators, err := maker.Engine().getTopValidators(maker.Chain(), header)
+	if err != nil {
+		t.Fatalf("failed to get top validators: %v", err)
+	}
+	if !reflect.DeepEqual(validators, elected) {
+		t.Errorf("top validators mismatch: have %d %x, want %d", len(validators), validators, len(elected))
+	}
+}
+
+func TestRecentsWindow(t *testing.T) {
+	keys := make([]*ecdsa.PrivateKey, 23)
+	addrs := make([]common.Address, len(keys))
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		addrs[i] = crypto.PubkeyToAddress(keys[i].PublicKey)
+	}
+	tests := []struct {
+		recentsBlock *big.Int
+		validators   []int            // initial validators
+		sealers      []int            // validators sealing blocks 1, 2...
+		checkpoints  map[uint64][]int // validators listed by the checkpoints
+		rejected     uint64           // first block sealed too recently (0 = none)
+	}{
+		// Legacy window of 2 blocks for small sets
+		{nil, []int{0, 1, 2, 3, 4}, []int{0, 1, 0, 0}, nil, 4},
+		// Window of 3 blocks for 5 validators
+		{big.NewInt(1), []int{0, 1, 2, 3, 4}, []int{0, 1, 0}, nil, 3},
+		{big.NewInt(1), []int{0, 1, 2, 3, 4}, []int{0, 1, 2, 0, 1, 2, 3}, map[uint64][]int{4: {0, 1, 2, 3, 4}}, 0},
+		// The window switches at the fork block
+		{big.NewInt(3), []int{0, 1, 2, 3, 4}, []int{0, 1, 2, 1}, nil, 4},
+		// The window shrinks along with the validator set
+		{big.NewInt(1), []int{0, 1, 2, 3, 4}, []int{0, 1, 2, 3, 0, 1, 0}, map[uint64][]int{4: {0, 1}}, 0},
+		// The window grows along with the validator set
+		{big.NewInt(1), []int{0, 1}, []int{0, 1, 0, 1, 0}, map[uint64][]int{4: {0, 1, 2, 3, 4}}, 5},
+		{nil, []int{0, 1}, []int{0, 1, 0, 1, 0}, map[uint64][]int{4: {0, 1, 2, 3, 4}}, 0},
+		// Below the window length every block sealed so far is recent
+		{nil, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22}, []int{0, 1, 0}, nil, 3},
+	}
+	for i, tt := range tests {
+		config := &params.CongressConfig{Epoch: 4, RecentsBlock: tt.recentsBlock}
+		engine := New(&params.ChainConfig{ChainID: big.NewInt(1), Congress: config}, rawdb.NewMemoryDatabase())
+
+		genesis := &types.Header{Number: new(big.Int)}
+		validators := make([]common.Address, len(tt.validators))
+		for j, idx := range tt.validators {
+			validators[j] = addrs[idx]
+		}
+		var (
+			snap     = newSnapshot(engine.config, engine.signatures, 0, genesis.Hash(), validators)
+			parent   = genesis
+			rejected uint64
+		)
+		for _, idx := range tt.sealers {
+			var listed []common.Address
+			for _, v := range tt.checkpoints[parent.Number.Uint64()+1] {
+				listed = append(listed, addrs[v])
+			}
+			header := newCheckpointHeader(t, keys[idx], parent, listed)
+			number := header.Number.Uint64()
+
+			// Seal and verifySeal reject the header if it's sealed too recently
+			if snap.signedRecently(number, addrs[idx]) {
+				rejected = number
+				// The legacy window is pruned from the snapshot once passed
+				if config.IsRecents(number) || number < config.RecentsLimit(number, len(snap.Validators)) {
+					if _, err := snap.apply([]*types.Header{header}, nil, nil); err != errRecentlySigned {
+						t.Errorf("test %d: apply error mismatch at block %d: have %v, want %v", i, number, err, errRecentlySigned)
+					}
+				}
+				break
+			}
+			next, err := snap.apply([]*types.Header{header}, nil, nil)
+			if err != nil {
+				t.Fatalf("test %d: failed to apply block %d: %v", i, number, err)
+			}
+			snap, parent = next, header
+		}
+		if rejected != tt.rejected {
+			t.Errorf("test %d: rejected block mismatch: have %d, want %d", i, rejected, tt.rejected)
+		}
+	}
+}
+
+func TestConsensusKeys(t *testing.T) {
+	var (
+		key1, _   = crypto.GenerateKey()
+		key2, _   = crypto.GenerateKey()
+		hotKey, _ = crypto.GenerateKey()
+		val1      = crypto.PubkeyToAddress(key1.PublicKey)
+		val2      = crypto.PubkeyToAddress(key2.PublicKey)
+		hot       = crypto.PubkeyToAddress(hotKey.PublicKey)
+		genesis   = &types.Header{Number: new(big.Int)}
+	)
+	config := &params.CongressConfig{Epoch: 4, ConsensusKeyBlock: big.NewInt(4)}
+	engine := New(&params.ChainConfig{ChainID: big.NewInt(1), Congress: config}, rawdb.NewMemoryDatabase())
+	snap := newSnapshot(engine.config, engine.signatures, 0, genesis.Hash(), []common.Address{val1, val2})
+
+	// The validators sign themselves until the checkpoint listing the hot key
+	var (
+		parent  = genesis
+		headers []*types.Header
+	)
+	for number := uint64(1); number <= 4; number++ {
+		key := key1
+		if number%2 == 0 {
+			key = key2
+		}
+		var entries []common.Address
+		if number == 4 {
+			entries = []common.Address{val1, val2, hot, val2}
+		}
+		parent = newCheckpointHeader(t, key, parent, entries)
+		headers = append(headers, parent)
+	}
+	snap, err := snap.apply(headers, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to apply headers: %v", err)
+	}
+	if validators, keys := parseCheckpoint(config, parent); len(validators) != 2 || keys[0] != hot || keys[1] != val2 {
+		t.Fatalf("checkpoint mismatch: validators %x, keys %x", validators, keys)
+	}
+	if snap.signingKey(val1) != hot || snap.signingKey(val2) != val2 {
+		t.Errorf("signing keys mismatch: have %x, %x", snap.signingKey(val1), snap.signingKey(val2))
+	}
+	if validator, ok := snap.validatorOf(hot); !ok || validator != val1 {
+		t.Errorf("validator of hot key mismatch: have %x", validator)
+	}
+	if _, ok := snap.validatorOf(val1); ok {
+		t.Errorf("validator still signing with its own key")
+	}
+	// The hot key now signs on behalf of the first validator only
+	tests := []struct {
+		key      *ecdsa.PrivateKey
+		coinbase common.Address
+		err      error
+	}{
+		{key1, val1, errInvalidConsensusKey},
+		{hotKey, val2, errInvalidConsensusKey},
+		{hotKey, hot, errUnauthorizedValidator},
+		{hotKey, val1, nil},
+	}
+	for i, tt := range tests {
+		header := newCoinbaseHeader(t, tt.key, tt.coinbase, parent, nil)
+		next, err := snap.apply([]*types.Header{header}, nil, nil)
+		if err != tt.err {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+		if err == nil && next.Recents[5] != val1 {
+			t.Errorf("test %d: recent validator mismatch: have %x, want %x", i, next.Recents[5], val1)
+		}
+	}
+}
+
+func TestConsensusKeySysTx(t *testing.T) {
+	var (
+		key1, _   = crypto.GenerateKey()
+		hotKey, _ = crypto.GenerateKey()
+		val1      = crypto.PubkeyToAddress(key1.PublicKey)
+		val2      = common.HexToAddress("0x02")
+		hot       = crypto.PubkeyToAddress(hotKey.PublicKey)
+	)
+	config := &params.CongressConfig{Epoch: 4, ConsensusKeyBlock: big.NewInt(4)}
+	engine := New(&params.ChainConfig{ChainID: big.NewInt(1), Congress: config}, rawdb.NewMemoryDatabase())
+
+	// The hot key signs on behalf of the first validator from block 4
+	snap := newSnapshot(engine.config, engine.signatures, 4, common.HexToHash("0x04"), []common.Address{val1, val2})
+	snap.Keys = consensusKeys([]common.Address{val1, val2}, []common.Address{hot, val2})
+	engine.recents.Add(snap.Hash, snap)
+	header := &types.Header{Number: big.NewInt(5), ParentHash: snap.Hash}
+
+	// A node holding the consensus key only signs the system transactions with it
+	signTx := func(accounts.Account, *types.Transaction, *big.Int) (*types.Transaction, error) { return nil, nil }
+	engine.AuthorizeValidator(val1)
+	if _, _, err := engine.sysTxSigner(nil, header); err != errMissingConsensusKey {
+		t.Errorf("error mismatch: have %v, want %v", err, errMissingConsensusKey)
+	}
+	engine.AuthorizeConsensusKey(hot, nil, signTx)
+	if signer, _, err := engine.sysTxSigner(nil, header); err != nil || signer != hot {
+		t.Errorf("system transaction signer mismatch: have %x, want %x, err %v", signer, hot, err)
+	}
+	if _, _, err := engine.signerOf(snap, val2, nil); err != errMissingValidatorKey {
+		t.Errorf("error mismatch: have %v, want %v", err, errMissingValidatorKey)
+	}
+	engine.AuthorizeValidator(val2)
+	if _, _, err := engine.sysTxSigner(nil, header); err != errMissingValidatorKey {
+		t.Errorf("error mismatch: have %v, want %v", err, errMissingValidatorKey)
+	}
+	// The system transactions of a block are sent by its coinbase or by the key
+	// that sealed it on its behalf, from the fork only
+	tests := []struct {
+		parent *types.Header
+		sender common.Address
+		want   bool
+	}{
+		{&types.Header{Number: big.NewInt(4)}, val1, true},
+		{&types.Header{Number: big.NewInt(4)}, hot, true},
+		{&types.Header{Number: big.NewInt(4)}, val2, false},
+		{&types.Header{Number: big.NewInt(2)}, hot, false},
+	}
+	for i, tt := range tests {
+		sealed := newCoinbaseHeader(t, hotKey, val1, tt.parent, nil)
+		if have := engine.isSysTxSender(tt.sender, sealed); have != tt.want {
+			t.Errorf("test %d: system transaction sender mismatch: have %v, want %v", i, have, tt.want)
+		}
+	}
+}
+
+func TestAssignConsensusKeys(t *testing.T) {
+	var (
+		val1 = common.HexToAddress("0x01")
+		val2 = common.HexToAddress("0x02")
+		val3 = common.HexToAddress("0x03")
+		key1 = common.HexToAddress("0x11")
+		key2 = common.HexToAddress("0x12")
+	)
+	tests := []struct {
+		set  map[common.Address]common.Address
+		want []common.Address
+	}{
+		// Validators without key sign themselves
+		{nil, []common.Address{val1, val2, val3}},
+		{map[common.Address]common.Address{val1: key1, val3: key2}, []common.Address{key1, val2, key2}},
+		// Keys taken by validator addresses or by previous validators are ignored
+		{map[common.Address]common.Address{val1: val2, val2: key1}, []common.Address{val1, key1, val3}},
+		{map[common.Address]common.Address{val1: key1, val2: key1, val3: key2}, []common.Address{key1, val2, key2}},
+	}
+	for i, tt := range tests {
+		keys := assignConsensusKeys([]common.Address{val1, val2, val3}, func(validator common.Address) common.Address {
+			return tt.set[validator]
+		})
+		if !reflect.DeepEqual(keys, tt.want) {
+			t.Errorf("test %d: keys mismatch: have %x, want %x", i, keys, tt.want)
+		}
+	}
+}
+
+func TestTrustedCheckpoint(t *testing.T) {
+	var (
+		key, _      = crypto.GenerateKey()
+		forger, _   = crypto.GenerateKey()
+		validator   = crypto.PubkeyToAddress(key.PublicKey)
+		impersonate = crypto.PubkeyToAddress(forger.PublicKey)
+		genesis     = &types.Header{Number: new(big.Int)}
+	)
+	config := &params.CongressConfig{Epoch: 4}
+	engine := New(&params.ChainConfig{ChainID: big.NewInt(1), Congress: config}, rawdb.NewMemoryDatabase())
+
+	// Only epoch blocks can be trusted
+	if err := engine.SetTrustedCheckpoint(&TrustedCheckpoint{Number: 3}); err == nil {
+		t.Fatalf("non-epoch checkpoint trusted")
+	}
+	// The headers of the checkpoint chain aren't verified, whoever sealed them
+	var (
+		parent  = genesis
+		headers []*types.Header
+	)
+	for number := uint64(1); number <= 4; number++ {
+		var validators []common.Address
+		if number == 4 {
+			validators = []common.Address{validator}
+		}
+		parent = newCheckpointHeader(t, forger, parent, validators)
+		headers = append(headers, parent)
+	}
+	if err := engine.SetTrustedCheckpoint(&TrustedCheckpoint{Number: 4, Hash: parent.Hash()}); err != nil {
+		t.Fatalf("failed to trust checkpoint: %v", err)
+	}
+	_, results := engine.VerifyHeaders(nil, headers, nil)
+	for _, header := range headers {
+		if err := <-results; err != nil {
+			t.Errorf("header %d: failed to verify: %v", header.Number, err)
+		}
+	}
+	// The headers of other chains are rejected, or verified if their chain is
+	// unknown yet
+	other := newCheckpointHeader(t, forger, headers[2], []common.Address{impersonate})
+	if err := engine.VerifyHeader(nil, other, false); err != errUntrustedCheckpoint {
+		t.Errorf("error mismatch: have %v, want %v", err, errUntrustedCheckpoint)
+	}
+	side := newCheckpointHeader(t, key, headers[1], nil)
+	_, results = engine.VerifyHeaders(nil, []*types.Header{side, newCheckpointHeader(t, forger, side, []common.Address{impersonate})}, nil)
+	for i := 0; i < 2; i++ {
+		if err := <-results; err != errUntrustedCheckpoint {
+			t.Errorf("side header %d: error mismatch: have %v, want %v", i, err, errUntrustedCheckpoint)
+		}
+	}
+	if trusted, _ := engine.verifyTrusted(nil, headers[2], nil); trusted {
+		t.Errorf("header trusted without a descendant on the checkpoint chain")
+	}
+	chain := &testVoteChain{config: engine.chainConfig, blocks: []*types.Block{types.NewBlockWithHeader(genesis)}}
+	for _, header := range headers {
+		chain.blocks = append(chain.blocks, types.NewBlockWithHeader(header))
+	}
+	if err := engine.VerifyHeader(chain, headers[2], false); err != nil {
+		t.Errorf("canonical header: failed to verify: %v", err)
+	}
+	if err := engine.VerifyHeader(chain, side, false); err != errUntrustedCheckpoint {
+		t.Errorf("error mismatch: have %v, want %v", err, errUntrustedCheckpoint)
+	}
+	// The snapshot on top of the checkpoint holds the validators it lists
+	next := newCheckpointHeader(t, key, parent, nil)
+	snap, err := engine.snapshot(nil, 5, next.Hash(), append(headers, next))
+	if err != nil {
+		t.Fatalf("failed to create snapshot: %v", err)
+	}
+	if _, ok := snap.Validators[validator]; !ok || len(snap.Validators) != 1 || snap.Recents[5] != validator {
+		t.Errorf("snapshot mismatch: validators %v, recents %v", snap.Validators, snap.Recents)
+	}
+	if snap, err := engine.trustedSnapshot(nil, 4, other.Hash(), []*types.Header{other}); snap != nil || err != nil {
+		t.Errorf("snapshot created from an untrusted checkpoint: %v", err)
+	}
+}
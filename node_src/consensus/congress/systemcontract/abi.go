@@ -2542,6 +2542,71 @@ const PunishV1InteractiveABI = `[
     }
 ]`
 
+// SlashingInteractiveABI contains all methods to interactive with the slashing contract.
+const SlashingInteractiveABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{
+				"indexed": true,
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"indexed": true,
+				"internalType": "uint256",
+				"name": "number",
+				"type": "uint256"
+			}
+		],
+		"name": "DoubleSignSlashed",
+		"type": "event"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"internalType": "uint256",
+				"name": "number",
+				"type": "uint256"
+			}
+		],
+		"name": "isSlashed",
+		"outputs": [
+			{
+				"internalType": "bool",
+				"name": "",
+				"type": "bool"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{
+				"internalType": "address",
+				"name": "validator",
+				"type": "address"
+			},
+			{
+				"internalType": "uint256",
+				"name": "number",
+				"type": "uint256"
+			}
+		],
+		"name": "slash",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]`
+
 // DevMappingPosition is the position of the state variable `devs`.
 // Since the state variables are as follow:
 //    bool public initialized;
@@ -2588,6 +2653,11 @@ var (
 	AddressListContractAddr  = common.HexToAddress("0x000000000000000000000000000000000000F004")
 	ValidatorsV1ContractAddr = common.HexToAddress("0x000000000000000000000000000000000000F005")
 	PunishV1ContractAddr     = common.HexToAddress("0x000000000000000000000000000000000000F006")
+
+	// SlashingContractAddr records the double signs punished from the slashing fork
+	SlashingContractName = "slashing"
+	SlashingContractAddr = common.HexToAddress("0x000000000000000000000000000000000000F008")
+
 	// SysGovToAddr is the To address for the system governance transaction, NOT contract address
 	SysGovToAddr = common.HexToAddress("0x000000000000000000000000000000000000ffff")
 
@@ -2611,6 +2681,8 @@ func init() {
 	abiMap[ValidatorsV1ContractName] = tmpABI
 	tmpABI, _ = abi.JSON(strings.NewReader(PunishV1InteractiveABI))
 	abiMap[PunishV1ContractName] = tmpABI
+	tmpABI, _ = abi.JSON(strings.NewReader(SlashingInteractiveABI))
+	abiMap[SlashingContractName] = tmpABI
 }
 
 func GetInteractiveABI() map[string]abi.ABI {
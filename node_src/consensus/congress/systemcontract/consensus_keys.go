@@ -0,0 +1,47 @@
+package systemcontract
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ConsensusKeysPosition is the storage slot of the consensus keys in the
+// validators contract upgraded for the consensus key fork, out of the way of
+// the storage of the contract: keccak256("congress.validators.consensusKeys").
+// The contract inherits contracts/ConsensusKeys.sol, which maps the validators
+// to their keys at this slot and the keys to the validator they confirmed at
+// the next one, following the Solidity storage layout of mappings:
+// https://docs.soliditylang.org/en/v0.8.4/internals/layout_in_storage.html#mappings-and-dynamic-arrays
+var ConsensusKeysPosition = crypto.Keccak256Hash([]byte("congress.validators.consensusKeys"))
+
+// ConsensusKeySlot returns the storage slot of the validators contract holding
+// the key of the given validator, zero if the validator never set one.
+func ConsensusKeySlot(validator common.Address) common.Hash {
+	return crypto.Keccak256Hash(validator.Hash().Bytes(), ConsensusKeysPosition.Bytes())
+}
+
+// ConsensusKeyOwnerSlot returns the storage slot of the validators contract
+// holding the validator the given key confirmed signing for, zero if none.
+func ConsensusKeyOwnerSlot(key common.Address) common.Hash {
+	owners := new(big.Int).Add(ConsensusKeysPosition.Big(), common.Big1)
+	return crypto.Keccak256Hash(key.Hash().Bytes(), common.BigToHash(owners).Bytes())
+}
+
+// ConfirmedConsensusKey returns the key the validator set in the validators
+// contract at the given address, provided the key confirmed signing for this
+// validator, and the zero address otherwise. Requiring the key to confirm the
+// mapping proves it's held by the validator: nobody can claim the key of
+// another validator as its own.
+func ConfirmedConsensusKey(state *state.StateDB, contract common.Address, validator common.Address) common.Address {
+	key := common.BytesToAddress(state.GetState(contract, ConsensusKeySlot(validator)).Bytes())
+	if key == (common.Address{}) {
+		return key
+	}
+	if common.BytesToAddress(state.GetState(contract, ConsensusKeyOwnerSlot(key)).Bytes()) != validator {
+		return common.Address{}
+	}
+	return key
+}
@@ -0,0 +1,54 @@
+package systemcontract
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsensusKeysPosition(t *testing.T) {
+	// The contract source must keep the keys at the slot the engine reads
+	source, err := ioutil.ReadFile("contracts/ConsensusKeys.sol")
+	require.NoError(t, err)
+	require.True(t, strings.Contains(string(source), "CONSENSUS_KEYS_SLOT = "+ConsensusKeysPosition.Hex()+";"))
+}
+
+func TestConfirmedConsensusKey(t *testing.T) {
+	var (
+		validator = common.HexToAddress("0x1000")
+		key       = common.HexToAddress("0x2000")
+		thief     = common.HexToAddress("0x3000")
+	)
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	setKey := func(validator, key common.Address) {
+		statedb.SetState(ValidatorsV1ContractAddr, ConsensusKeySlot(validator), key.Hash())
+	}
+	confirm := func(key, validator common.Address) {
+		statedb.SetState(ValidatorsV1ContractAddr, ConsensusKeyOwnerSlot(key), validator.Hash())
+	}
+	// A validator without key maps to the zero address
+	require.Equal(t, common.Address{}, ConfirmedConsensusKey(statedb, ValidatorsV1ContractAddr, validator))
+
+	// The key is only in effect once it confirmed signing for the validator
+	setKey(validator, key)
+	require.Equal(t, common.Address{}, ConfirmedConsensusKey(statedb, ValidatorsV1ContractAddr, validator))
+	confirm(key, validator)
+	require.Equal(t, key, ConfirmedConsensusKey(statedb, ValidatorsV1ContractAddr, validator))
+
+	// The keys are read from the given validators contract only
+	require.Equal(t, common.Address{}, ConfirmedConsensusKey(statedb, ValidatorsContractAddr, validator))
+
+	// Another validator setting the same key doesn't get it
+	setKey(thief, key)
+	require.Equal(t, common.Address{}, ConfirmedConsensusKey(statedb, ValidatorsV1ContractAddr, thief))
+	require.Equal(t, key, ConfirmedConsensusKey(statedb, ValidatorsV1ContractAddr, validator))
+
+	// The storage of the contract below the namespaced slots is left alone
+	require.NotEqual(t, ConsensusKeySlot(validator), ConsensusKeyOwnerSlot(validator))
+	require.Equal(t, common.Hash{}, statedb.GetState(ValidatorsV1ContractAddr, common.BytesToHash(validator.Bytes())))
+}
@@ -0,0 +1,107 @@
+package systemcontract
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// SysContract is a system contract managed by the congress engine.
+type SysContract struct {
+	Name    string         // Name of the interactive ABI of the contract
+	Address common.Address // Address the contract lives at
+}
+
+// SysContracts lists the system contracts, ordered by address.
+var SysContracts = []SysContract{
+	{ValidatorsContractName, ValidatorsContractAddr},
+	{PunishContractName, PunishContractAddr},
+	{ProposalContractName, ProposalAddr},
+	{SysGovContractName, SysGovContractAddr},
+	{AddressListContractName, AddressListContractAddr},
+	{ValidatorsV1ContractName, ValidatorsV1ContractAddr},
+	{PunishV1ContractName, PunishV1ContractAddr},
+	{SlashingContractName, SlashingContractAddr},
+}
+
+// Names of the forks installing system contract code.
+const (
+	ForkGenesis   = "genesis"
+	ForkRedCoast  = "redCoast"
+	ForkSophon    = "sophon"
+	ForkSlashing  = "slashing"
+	ForkScheduled = "upgrade"
+)
+
+// builtinCodes lists the code installed by every built-in upgrade version.
+var builtinCodes = []struct {
+	version SysContractVersion
+	address common.Address
+	code    string
+}{
+	{SysContractV1, SysGovContractAddr, govCode},
+	{SysContractV1, AddressListContractAddr, addressListCode},
+	{SysContractV1, ValidatorsV1ContractAddr, validatorV1Code},
+	{SysContractV1, PunishV1ContractAddr, punishV1Code},
+	{SysContractV2, AddressListContractAddr, addressListV2Code},
+	{SysContractV2, ValidatorsV1ContractAddr, validatorsV2Code},
+	{SysContractSlashing, SlashingContractAddr, slashingCode},
+}
+
+// SysContractCode is the code a system contract is expected to have.
+type SysContractCode struct {
+	Fork  string      // Fork that installed the code
+	Block *big.Int    // Block the code was installed at
+	Code  []byte      // Expected code, nil if an upgrade only scheduled its hash
+	Hash  common.Hash // Hash of the expected code
+}
+
+// ExpectedCode returns the code the system contract at the given address should
+// have at the given height, or nil if no code is installed there yet. The code
+// of the contracts set up in the genesis block is the one of GenesisContracts.
+func ExpectedCode(config *params.ChainConfig, addr common.Address, number *big.Int) *SysContractCode {
+	var installs []*SysContractCode
+	if account, ok := GenesisContracts()[addr]; ok {
+		installs = append(installs, &SysContractCode{Fork: ForkGenesis, Block: new(big.Int), Code: account.Code, Hash: crypto.Keccak256Hash(account.Code)})
+	}
+	for _, builtin := range builtinCodes {
+		if builtin.address != addr {
+			continue
+		}
+		fork, block := ForkRedCoast, config.RedCoastBlock
+		switch builtin.version {
+		case SysContractV2:
+			fork, block = ForkSophon, config.SophonBlock
+		case SysContractSlashing:
+			fork, block = ForkSlashing, config.SlashingBlock
+		}
+		if block == nil {
+			continue
+		}
+		code := common.FromHex(builtin.code)
+		installs = append(installs, &SysContractCode{Fork: fork, Block: block, Code: code, Hash: crypto.Keccak256Hash(code)})
+	}
+	if config.Congress != nil {
+		for _, upgrade := range config.Congress.Upgrades {
+			if upgrade.Address != addr {
+				continue
+			}
+			installs = append(installs, &SysContractCode{Fork: ForkScheduled, Block: upgrade.Block, Code: upgrade.Code, Hash: upgrade.Hash()})
+		}
+	}
+	// Upgrades at the same block are applied in the order listed above
+	sort.SliceStable(installs, func(i, j int) bool {
+		return installs[i].Block.Cmp(installs[j].Block) < 0
+	})
+	var expected *SysContractCode
+	for _, install := range installs {
+		if install.Block.Cmp(number) > 0 {
+			break
+		}
+		expected = install
+	}
+	return expected
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: GPL-3.0
+pragma solidity ^0.8.4;
+
+/// @title Consensus keys of the congress validators
+/// @notice Inherited by the validators contract upgraded at or before the
+/// consensus key fork. Each validator maps to a key signing the blocks on its
+/// behalf, once the key confirmed signing for this validator. The engine reads
+/// both mappings from the storage of the validators contract, see
+/// systemcontract.ConsensusKeySlot and systemcontract.ConsensusKeyOwnerSlot, so
+/// they live at a fixed slot out of the way of the storage of the contract.
+abstract contract ConsensusKeys {
+    // keccak256("congress.validators.consensusKeys")
+    bytes32 private constant CONSENSUS_KEYS_SLOT = 0x0693222bae47ecfdaabe6f41d6dec2fa963ddc776717ddae44fb84333780542e;
+
+    struct ConsensusKeysStorage {
+        mapping(address => address) keys; // validator => consensus key
+        mapping(address => address) owners; // consensus key => validator it confirmed
+    }
+
+    event ConsensusKeyChanged(address indexed validator, address indexed key);
+    event ConsensusKeyConfirmed(address indexed validator, address indexed key);
+
+    /// @notice Sets the consensus key of the calling validator. It takes effect
+    /// at the next epoch, once the key confirmed signing for the validator.
+    function setConsensusKey(address key) external {
+        _consensusKeys().keys[msg.sender] = key;
+        emit ConsensusKeyChanged(msg.sender, key);
+    }
+
+    /// @notice Confirms the calling key signs for the given validator, proving
+    /// the validator holds the key it set.
+    function confirmConsensusKey(address validator) external {
+        _consensusKeys().owners[msg.sender] = validator;
+        emit ConsensusKeyConfirmed(validator, msg.sender);
+    }
+
+    function consensusKeyOf(address validator) external view returns (address) {
+        return _consensusKeys().keys[validator];
+    }
+
+    function validatorOfConsensusKey(address key) external view returns (address) {
+        return _consensusKeys().owners[key];
+    }
+
+    function _consensusKeys() private pure returns (ConsensusKeysStorage storage s) {
+        bytes32 slot = CONSENSUS_KEYS_SLOT;
+        assembly {
+            s.slot := slot
+        }
+    }
+}
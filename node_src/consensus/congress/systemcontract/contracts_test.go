@@ -0,0 +1,46 @@
+package systemcontract
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpectedCode(t *testing.T) {
+	config := &params.ChainConfig{RedCoastBlock: big.NewInt(10), SophonBlock: big.NewInt(20), Congress: &params.CongressConfig{Upgrades: []params.SysContractUpgrade{
+		{Block: big.NewInt(30), Address: AddressListContractAddr, Code: []byte{0x00}},
+	}}}
+	tests := []struct {
+		addr   common.Address
+		number int64
+		fork   string
+		code   string
+	}{
+		{ValidatorsContractAddr, 0, ForkGenesis, validatorsCode},
+		{ValidatorsContractAddr, 100, ForkGenesis, validatorsCode},
+		{ProposalAddr, 100, ForkGenesis, proposalCode},
+		{SysGovContractAddr, 9, "", ""},
+		{SysGovContractAddr, 10, ForkRedCoast, govCode},
+		{SysGovContractAddr, 100, ForkRedCoast, govCode},
+		{AddressListContractAddr, 19, ForkRedCoast, addressListCode},
+		{AddressListContractAddr, 20, ForkSophon, addressListV2Code},
+		{AddressListContractAddr, 30, ForkScheduled, "0x00"},
+		{ValidatorsV1ContractAddr, 30, ForkSophon, validatorsV2Code},
+		{PunishV1ContractAddr, 30, ForkRedCoast, punishV1Code},
+	}
+	for i, tt := range tests {
+		expected := ExpectedCode(config, tt.addr, big.NewInt(tt.number))
+		if tt.fork == "" {
+			require.Nil(t, expected, "test %d", i)
+			continue
+		}
+		require.NotNil(t, expected, "test %d", i)
+		require.Equal(t, tt.fork, expected.Fork, "test %d", i)
+		require.Equal(t, common.FromHex(tt.code), expected.Code, "test %d", i)
+		require.Equal(t, crypto.Keccak256Hash(common.FromHex(tt.code)), expected.Hash, "test %d", i)
+	}
+}
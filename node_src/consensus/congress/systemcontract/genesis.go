@@ -0,0 +1,27 @@
+package systemcontract
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+)
+
+// Code of the system contracts set up in the genesis block of the network,
+// initialized by the engine at block 1.
+const (
+	validatorsCode = "0x6080604052600436106102c85760003560e01c80638b0e9f3f11610175578063bb1c0310116100dc578063cd779d2111610095578063f0f2f5071161006f578063f0f2f507146108fe578063f2888dbb1461091e578063f2fde38b1461093e578063fca737c01461095e57600080fd5b8063cd779d2114610884578063d571770c1461089a578063db78dd28146108d057600080fd5b8063bb1c0310146107be578063bd10ba76146107d1578063be645692146107f1578063c253c38414610807578063c60033dd14610831578063c967f90f1461085157600080fd5b80639de702581161012e5780639de7025814610703578063a224cee714610725578063a406fcb714610745578063a43569b314610758578063afeea11514610789578063b6c885191461079e57600080fd5b80638b0e9f3f1461064b5780638da5cb5b1461066157806392f2c4371461067f578063966ff650146106b757806398e3b626146106cd5780639d7714ce146106ed57600080fd5b806340550a1c116102345780636846992a116101ed57806372d30a70116101c757806372d30a701461056b5780637f4f95fa1461058b57806382bd3d92146105f95780638a11d7c91461061957600080fd5b80636846992a146105165780636969a25c14610536578063715018a61461055657600080fd5b806340550a1c1461046857806340a141ff1461048857806342127ee7146104aa5780634b3d500b146104c05780635dd09590146104e05780636233be5d1461050057600080fd5b8063222d3b0511610286578063222d3b05146103bc57806326476204146103dc57806337dbc8cc146103ef5780633a061bd3146104055780633ae898ca1461041b5780633b5d441e1461043b57600080fd5b8062362a77146102cd57806301aec704146103025780630976c65d146103485780631303f7cf1461035e578063158ef93e146103745780631b5e358c1461038e575b600080fd5b3480156102d957600080fd5b506102ed6102e836600461460c565b610974565b60405190151581526020015b60405180910390f35b34801561030e57600080fd5b5061033a61031d366004614630565b601160209081526000928352604080842090915290825290205481565b6040519081526020016102f9565b34801561035457600080fd5b5061033a60025481565b34801561036a57600080fd5b5061033a600f5481565b34801561038057600080fd5b506000546102ed9060ff1681565b34801561039a57600080fd5b506103a461f00181565b6040516001600160a01b0390911681526020016102f9565b3480156103c857600080fd5b506102ed6103d736600461460c565b610b96565b6102ed6103ea36600461460c565b610dc4565b3480156103fb57600080fd5b5061033a60055481565b34801561041157600080fd5b506103a461f00081565b34801561042757600080fd5b5061033a610436366004614630565b61120d565b34801561044757600080fd5b5061033a61045636600461460c565b60126020526000908152604090205481565b34801561047457600080fd5b506102ed61048336600461460c565b61131d565b34801561049457600080fd5b506104a86104a336600461460c565b61137d565b005b3480156104b657600080fd5b5061033a60045481565b3480156104cc57600080fd5b506103a46104db366004614669565b611447565b3480156104ec57600080fd5b506104a86104fb36600461460c565b611471565b34801561050c57600080fd5b506103a461f00281565b34801561052257600080fd5b506104a861053136600461475f565b6114c5565b34801561054257600080fd5b506103a4610551366004614669565b611696565b34801561056257600080fd5b506104a86116a6565b34801561057757600080fd5b506102ed61058636600461460c565b6116ba565b34801561059757600080fd5b506105de6105a6366004614630565b6001600160a01b039182166000908152600b602090815260408083209390941682529190915220805460018201546002909201549092565b604080519384526020840192909252908201526060016102f9565b34801561060557600080fd5b506102ed61061436600461460c565b61186a565b34801561062557600080fd5b5061063961063436600461460c565b611aa8565b6040516102f9969594939291906147fd565b34801561065757600080fd5b5061033a600e5481565b34801561066d57600080fd5b506009546001600160a01b03166103a4565b34801561068b57600080fd5b5061033a61069a366004614861565b601360209081526000928352604080842090915290825290205481565b3480156106c357600080fd5b5061033a60085481565b3480156106d957600080fd5b506102ed6106e836600461460c565b611eba565b3480156106f957600080fd5b5061033a60075481565b34801561070f57600080fd5b50610718611f1a565b6040516102f9919061488d565b34801561073157600080fd5b506104a86107403660046148a0565b611f7c565b6102ed610753366004614967565b6123a3565b34801561076457600080fd5b5061077861077336600461460c565b612912565b6040516102f9959493929190614ab3565b34801561079557600080fd5b50610718612d1f565b3480156107aa57600080fd5b506102ed6107b9366004614b83565b612d7f565b6104a86107cc366004614c54565b612f0b565b3480156107dd57600080fd5b506102ed6107ec36600461460c565b6133f8565b3480156107fd57600080fd5b5061033a60015481565b34801561081357600080fd5b5061081c61347e565b604080519283526020830191909152016102f9565b34801561083d57600080fd5b506104a861084c366004614d1c565b613493565b34801561085d57600080fd5b5060005461087190610100900461ffff1681565b60405161ffff90911681526020016102f9565b34801561089057600080fd5b5061033a60035481565b3480156108a657600080fd5b506103a46108b536600461460c565b6010602052600090815260409020546001600160a01b031681565b3480156108dc57600080fd5b506108e661708081565b6040516001600160401b0390911681526020016102f9565b34801561090a57600080fd5b506104a8610919366004614d57565b61353d565b34801561092a57600080fd5b506102ed61093936600461460c565b6135e0565b34801561094a57600080fd5b506104a861095936600461460c565b613a54565b34801561096a57600080fd5b5061033a60065481565b600032816001600160a01b0384166000908152600a6020526040902054600160a01b900460ff1660048111156109ac576109ac6147a3565b036109f45760405162461bcd60e51b815260206004820152601360248201527215985b1a59185d1bdc881b9bdd08195e1a5cdd606a1b60448201526064015b60405180910390fd5b6001600160a01b038381166000908152600a6020526040902054811690821614610a775760405162461bcd60e51b815260206004820152602e60248201527f596f7520617265206e6f742074686520666565207265636569766572206f662060448201526d3a3434b9903b30b634b230ba37b960911b60648201526084016109eb565b6001600160a01b0383166000908152600a602052604090206007015480610ae05760405162461bcd60e51b815260206004820152601a60248201527f596f7520646f6e2774206861766520616e792070726f6669747300000000000060448201526064016109eb565b6001600160a01b0384166000908152600a60205260408120600701558015610b3a576040516001600160a01b0383169082156108fc029083906000818181858888f19350505050158015610b38573d6000803e3d6000fd5b505b610b43846116ba565b50604080518281524260208201526001600160a01b0380851692908716917f51a69b4502f660774c9339825c7b5adbf0b8622289134647e29728ec5d9b3bb9910160405180910390a35060019392505050565b326000818152600b602090815260408083206001600160a01b03861684528252808320600a9092528220549192918390600160a01b900460ff166004811115610be157610be16147a3565b03610c245760405162461bcd60e51b81526020600482015260136024820152721d985b1a59185d1bdc881b9bdd08195e1a5cdd606a1b60448201526064016109eb565b8060010154600003610c785760405162461bcd60e51b815260206004820152601960248201527f596f75206861766520746f20756e7374616b652066697273740000000000000060448201526064016109eb565b60018101544390610c8c9061708090614da9565b1115610ce45760405162461bcd60e51b815260206004820152602160248201527f596f7572207374616b696e6720686176656e277420756e6c6f636b65642079656044820152601d60fa1b60648201526084016109eb565b8054610d2d5760405162461bcd60e51b8152602060048201526018602482015277596f7520646f6e2774206861766520616e79207374616b6560401b60448201526064016109eb565b80546000808355600183018190556040516001600160a01b0385169183156108fc02918491818181858888f19350505050158015610d6f573d6000803e3d6000fd5b50604080518281524260208201526001600160a01b0380881692908616917fa70cd94070cd852339a76b32cf2d95a3c8f2a322269163d276071c1c1495561991015b60405180910390a3506001949350505050565b6000805460ff16610de75760405162461bcd60e51b81526004016109eb90614dbc565b323460016001600160a01b0385166000908152600a6020526040902054600160a01b900460ff166004811115610e1f57610e1f6147a3565b1480610e5e575060026001600160a01b0385166000908152600a6020526040902054600160a01b900460ff166004811115610e5c57610e5c6147a3565b145b610ec05760405162461bcd60e51b815260206004820152602d60248201527f43616e2774207374616b6520746f20612076616c696461746f7220696e20616260448201526c6e6f726d616c2073746174757360981b60648201526084016109eb565b6001600160a01b038083166000908152600b602090815260408083209388168352929052206001015415610f415760405162461bcd60e51b815260206004820152602260248201527f43616e2774207374616b65207768656e20796f752061726520756e7374616b696044820152616e6760f01b60648201526084016109eb565b6001600160a01b038085166000818152600a6020526040902091841603610fc357600154828260010154610f759190614da9565b1015610fbe5760405162461bcd60e51b81526020600482015260186024820152770a6e8c2d6d2dcce40c6ded2dce640dcdee840cadcdeeaced60431b60448201526064016109eb565b611010565b6001548210156110105760405162461bcd60e51b81526020600482015260186024820152770a6e8c2d6d2dcce40c6ded2dce640dcdee840cadcdeeaced60431b60448201526064016109eb565b6001600160a01b038084166000908152600b6020908152604080832093891683529290529081205490036110f7576009810180546001600160a01b038086166000818152600b60209081526040808320948c1680845294825280832060020186905560018601875595825280822090940180546001600160a01b03191690921790915590815260129091529081205490036110c1576001600160a01b03851660009081526012602052604090204290555b6001600160a01b038086166000818152601260209081526040808320549488168352601182528083209383529290522055611102565b611100856116ba565b505b8181600101546111129190614da9565b600182015560028154600160a01b900460ff166004811115611136576111366147a3565b1461114d57805460ff60a01b1916600160a11b1781555b61115b858260010154613aca565b6001600160a01b038084166000908152600b602090815260408083209389168352929052205461118c908390614da9565b6001600160a01b038085166000908152600b60209081526040808320938a1683529290522055600e546111c0908390614da9565b600e55604080518381524260208201526001600160a01b0380881692908616917fb9ba725934532316cffe10975da6eb25ad49c2d1c294d982c46c9f8d684ee0759101610db1565b919050565b6001600160a01b03808316600090815260116020908152604080832093851683529290529081205415611313576001600160a01b03808316600081815260136020908152604080832094881683526011825280832084845282528083205483528482528083205493835260128252808320548352939052918220546112929190614de2565b90508015611311576001600160a01b038085166000908152600b60209081526040808320938716835292815290829020825160608101845281548082526001830154938201939093526002909101549281019290925268056bc75e2d63100000906112fe908490614df5565b6113089190614e22565b92505050611317565b505b5060005b92915050565b6000805b600c5481101561131357826001600160a01b0316600c828154811061134857611348614e36565b6000918252602090912001546001600160a01b03160361136b5750600192915050565b8061137581614e4c565b915050611321565b3361f001146113c55760405162461bcd60e51b815260206004820152601460248201527350756e69736820636f6e7472616374206f6e6c7960601b60448201526064016109eb565b6001600160a01b0381166000908152600a60205260409020600701546113ea82613d9e565b600d5460011015611443576113fe82613eb6565b604080518281524260208201526001600160a01b038416917fa26de7ab324eac08c596549f421e5c8741213d237d2e9a2c9c0ebde0a7a849fe91015b60405180910390a25b5050565b600d818154811061145757600080fd5b6000918252602090912001546001600160a01b0316905081565b3361f001146114b95760405162461bcd60e51b815260206004820152601460248201527350756e69736820636f6e7472616374206f6e6c7960601b60448201526064016109eb565b6114c281613d9e565b50565b3341146115015760405162461bcd60e51b815260206004820152600a6024820152694d696e6572206f6e6c7960b01b60448201526064016109eb565b4360009081526015602090815260408083206001845290915290205460ff161561156d5760405162461bcd60e51b815260206004820152601a60248201527f56616c696461746f727320616c7265616479207570646174656400000000000060448201526064016109eb565b60005460ff1661158f5760405162461bcd60e51b81526004016109eb90614dbc565b8061159a8143614e65565b156115da5760405162461bcd60e51b815260206004820152601060248201526f426c6f636b2065706f6368206f6e6c7960801b60448201526064016109eb565b43600090815260156020908152604080832060018085529252909120805460ff1916909117905582516116465760405162461bcd60e51b815260206004820152601460248201527356616c696461746f722073657420656d7074792160601b60448201526064016109eb565b825161165990600c90602086019061457d565b507feacea8f3c22f06c0b18306bdb04d0a967255129e8ce0094debb0a0ff89d006b583604051611689919061488d565b60405180910390a1505050565b600c818154811061145757600080fd5b6116ae613f26565b6116b86000613f80565b565b3260009081526011602090815260408083206001600160a01b038516845290915281205461171b5760405162461bcd60e51b815260206004820152600e60248201526d1b9bdd1a1a5b99c81cdd185ad95960921b60448201526064016109eb565b326000818152600b602090815260408083206001600160a01b038716808552908352818420601384528285209585526011845282852082865284528285205485528584528285205491855260128452828520548552949092528220546117819190614de2565b90508015611860576001600160a01b0384166000818152601260209081526040808320543284526011835281842094845293909152812091909155825468056bc75e2d63100000906117d4908490614df5565b6117de9190614e22565b604051909150329082156108fc029083906000818181858888f1935050505015801561180e573d6000803e3d6000fd5b50604080513281526001600160a01b03871660208201529081018290524260608201527f7daf1212d032f2f66db6dbb919bbd591be3d954b5b6ee491709cbb5fbe4f51709060800160405180910390a1505b5060019392505050565b60003361f002146118b65760405162461bcd60e51b815260206004820152601660248201527550726f706f73616c20636f6e7472616374206f6e6c7960501b60448201526064016109eb565b60005460ff166118d85760405162461bcd60e51b81526004016109eb90614dbc565b60036001600160a01b0383166000908152600a6020526040902054600160a01b900460ff16600481111561190e5761190e6147a3565b14158015611950575060046001600160a01b0383166000908152600a6020526040902054600160a01b900460ff16600481111561194d5761194d6147a3565b14155b1561195d57506001919050565b60046001600160a01b0383166000908152600a6020526040902054600160a01b900460ff166004811115611993576119936147a3565b03611a43576014546040516363e1d45160e01b81526001600160a01b038481166004830152909116906363e1d451906024016020604051808303816000875af11580156119e4573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190611a089190614e79565b611a435760405162461bcd60e51b815260206004820152600c60248201526b18db19585b8819985a5b195960a21b60448201526064016109eb565b6001600160a01b0382166000818152600a6020908152604091829020805460ff60a01b1916600160a11b17905590514281527fd8b2c426ec1be69ca7583d26b1e893946e3227430d3ebc3bd64d9e1c469cb400910160405180910390a2506001919050565b6001600160a01b038181166000908152600a60209081526040808320815160e0810190925280549485168252929384938493849384936060938593909190830190600160a01b900460ff166004811115611b0457611b046147a3565b6004811115611b1557611b156147a3565b815260200160018201548152602001600282016040518060a0016040529081600082018054611b4390614e9b565b80601f0160208091040260200160405190810160405280929190818152602001828054611b6f90614e9b565b8015611bbc5780601f10611b9157610100808354040283529160200191611bbc565b820191906000526020600020905b815481529060010190602001808311611b9f57829003601f168201915b50505050508152602001600182018054611bd590614e9b565b80601f0160208091040260200160405190810160405280929190818152602001828054611c0190614e9b565b8015611c4e5780601f10611c2357610100808354040283529160200191611c4e565b820191906000526020600020905b815481529060010190602001808311611c3157829003601f168201915b50505050508152602001600282018054611c6790614e9b565b80601f0160208091040260200160405190810160405280929190818152602001828054611c9390614e9b565b8015611ce05780601f10611cb557610100808354040283529160200191611ce0565b820191906000526020600020905b815481529060010190602001808311611cc357829003601f168201915b50505050508152602001600382018054611cf990614e9b565b80601f0160208091040260200160405190810160405280929190818152602001828054611d2590614e9b565b8015611d725780601f10611d4757610100808354040283529160200191611d72565b820191906000526020600020905b815481529060010190602001808311611d5557829003601f168201915b50505050508152602001600482018054611d8b90614e9b565b80601f0160208091040260200160405190810160405280929190818152602001828054611db790614e9b565b8015611e045780601f10611dd957610100808354040283529160200191611e04565b820191906000526020600020905b815481529060010190602001808311611de757829003601f168201915b5050505050815250508152602001600782015481526020016008820154815260200160098201805480602002602001604051908101604052809291908181526020018280548015611e7e57602002820191906000526020600020905b81546001600160a01b03168152600190910190602001808311611e60575b505050919092525050815160208301516040840151608085015160a086015160c090960151939e929d50909b5099509297509550909350505050565b6000805b600d5481101561131357826001600160a01b0316600d8281548110611ee557611ee5614e36565b6000918252602090912001546001600160a01b031603611f085750600192915050565b80611f1281614e4c565b915050611ebe565b6060600c805480602002602001604051908101604052809291908181526020018280548015611f7257602002820191906000526020600020905b81546001600160a01b03168152600190910190602001808311611f54575b5050505050905090565b60005460ff1615611fc55760405162461bcd60e51b8152602060048201526013602482015272105b1c9958591e481a5b9a5d1a585b1a5e9959606a1b60448201526064016109eb565b601480546001600160a01b03191661f001179055611ff6736e64a7f2fa5edca82672fb7916d5313a6ca55341613f80565b60005b8181101561239157600083838381811061201557612015614e36565b905060200201602081019061202a919061460c565b6001600160a01b0316036120805760405162461bcd60e51b815260206004820152601960248201527f496e76616c69642076616c696461746f7220616464726573730000000000000060448201526064016109eb565b426012600085858581811061209757612097614e36565b90506020020160208101906120ac919061460c565b6001600160a01b031681526020810191909152604001600020556120f08383838181106120db576120db614e36565b9050602002016020810190610483919061460c565b61215057600c83838381811061210857612108614e36565b905060200201602081019061211d919061460c565b81546001810183556000928352602090922090910180546001600160a01b0319166001600160a01b039092169190911790555b61217a83838381811061216557612165614e36565b90506020020160208101906106e8919061460c565b6121da57600d83838381811061219257612192614e36565b90506020020160208101906121a7919061460c565b81546001810183556000928352602090922090910180546001600160a01b0319166001600160a01b039092169190911790555b6000600a818585858181106121f1576121f1614e36565b9050602002016020810190612206919061460c565b6001600160a01b03908116825260208201929092526040016000205416036122ac5782828281811061223a5761223a614e36565b905060200201602081019061224f919061460c565b600a600085858581811061226557612265614e36565b905060200201602081019061227a919061460c565b6001600160a01b039081168252602082019290925260400160002080546001600160a01b031916929091169190911790555b6000600a60008585858181106122c4576122c4614e36565b90506020020160208101906122d9919061460c565b6001600160a01b03168152602081019190915260400160002054600160a01b900460ff16600481111561230e5761230e6147a3565b0361237f576002600a600085858581811061232b5761232b614e36565b9050602002016020810190612340919061460c565b6001600160a01b031681526020810191909152604001600020805460ff60a01b1916600160a01b836004811115612379576123796147a3565b02179055505b8061238981614e4c565b915050611ff9565b50506000805460ff1916600117905550565b6000805460ff166123c65760405162461bcd60e51b81526004016109eb90614dbc565b6001600160a01b038c166124125760405162461bcd60e51b8152602060048201526013602482015272496e76616c696420666565206164647265737360681b60448201526064016109eb565b6125218b8b8080601f01602080910402602001604051908101604052809392919081815260200183838082843760009201919091525050604080516020601f8f018190048102820181019092528d815292508d91508c908190840183828082843760009201919091525050604080516020601f8e018190048102820181019092528c815292508c91508b908190840183828082843760009201919091525050604080516020601f8d018190048102820181019092528b815292508b91508a908190840183828082843760009201919091525050604080516020601f8c018190048102820181019092528a815292508a9150899081908401838280828437600092019190915250612d7f92505050565b6125635760405162461bcd60e51b815260206004820152601360248201527224b73b30b634b2103232b9b1b934b83a34b7b760691b60448201526064016109eb565b326000818152600a60205260408120548190600160a01b900460ff166004811115612590576125906147a3565b036125c657506001600160a01b0381166000908152600a60205260409020805460ff60a01b1916600160a01b17905560016125d7565b34156125d757600092505050612903565b6001600160a01b038281166000908152600a60205260409020548116908f1614612649578d600a6000846001600160a01b03166001600160a01b0316815260200190815260200160002060000160006101000a8154816001600160a01b0302191690836001600160a01b031602179055505b6040518060a001604052808e8e8080601f016020809104026020016040519081016040528093929190818152602001838380828437600092019190915250505090825250604080516020601f8f018190048102820181019092528d815291810191908e908e9081908401838280828437600092019190915250505090825250604080516020601f8d018190048102820181019092528b815291810191908c908c9081908401838280828437600092019190915250505090825250604080516020601f8b0181900481028201810190925289815291810191908a908a9081908401838280828437600092019190915250505090825250604080516020601f89018190048102820181019092528781529181019190889088908190840183828082843760009201829052509390945250506001600160a01b0385168152600a6020526040902082516002909101915081906127a29082614f24565b50602082015160018201906127b79082614f24565b50604082015160028201906127cc9082614f24565b50606082015160038201906127e19082614f24565b50608082015160048201906127f69082614f24565b5090505080156128ae576002543410156128525760405162461bcd60e51b815260206004820152601860248201527f496e76616c69642076616c696461746f7220616d6f756e74000000000000000060448201526064016109eb565b61285b82610dc4565b508d6001600160a01b0316826001600160a01b03167f887eec9d757b7247dd8e51198f9d1b8f27979bceb34bdcc1bffd4ec5ec736c22426040516128a191815260200190565b60405180910390a36128fc565b8d6001600160a01b0316826001600160a01b03167fb8421f65501371f54d58de1937ff1e1ccdb76423ef6f84acea1814a0f6362ca0426040516128f391815260200190565b60405180910390a35b6001925050505b9b9a5050505050505050505050565b6001600160a01b038181166000908152600a60209081526040808320815160e081019092528054948516825260609485948594859485949293909291830190600160a01b900460ff16600481111561296c5761296c6147a3565b600481111561297d5761297d6147a3565b815260200160018201548152602001600282016040518060a00160405290816000820180546129ab90614e9b565b80601f01602080910402602001604051908101604052809291908181526020018280546129d790614e9b565b8015612a245780601f106129f957610100808354040283529160200191612a24565b820191906000526020600020905b815481529060010190602001808311612a0757829003601f168201915b50505050508152602001600182018054612a3d90614e9b565b80601f0160208091040260200160405190810160405280929190818152602001828054612a6990614e9b565b8015612ab65780601f10612a8b57610100808354040283529160200191612ab6565b820191906000526020600020905b815481529060010190602001808311612a9957829003601f168201915b50505050508152602001600282018054612acf90614e9b565b80601f0160208091040260200160405190810160405280929190818152602001828054612afb90614e9b565b8015612b485780601f10612b1d57610100808354040283529160200191612b48565b820191906000526020600020905b815481529060010190602001808311612b2b57829003601f168201915b50505050508152602001600382018054612b6190614e9b565b80601f0160208091040260200160405190810160405280929190818152602001828054612b8d90614e9b565b8015612bda5780601f10612baf57610100808354040283529160200191612bda565b820191906000526020600020905b815481529060010190602001808311612bbd57829003601f168201915b50505050508152602001600482018054612bf390614e9b565b80601f0160208091040260200160405190810160405280929190818152602001828054612c1f90614e9b565b8015612c6c5780601f10612c4157610100808354040283529160200191612c6c565b820191906000526020600020905b815481529060010190602001808311612c4f57829003601f168201915b5050505050815250508152602001600782015481526020016008820154815260200160098201805480602002602001604051908101604052809291908181526020018280548015612ce657602002820191906000526020600020905b81546001600160a01b03168152600190910190602001808311612cc8575b5050509190925250505060609081015180516020820151604083015193830151608090930151919b909a50929850909650945092505050565b6060600d805480602002602001604051908101604052809291908181526020018280548015611f72576020028201919060005260206000209081546001600160a01b03168152600190910190602001808311611f54575050505050905090565b6000604686511115612dcc5760405162461bcd60e51b8152602060048201526016602482015275092dcecc2d8d2c840dadedcd2d6cae440d8cadccee8d60531b60448201526064016109eb565b610bb885511115612e1f5760405162461bcd60e51b815260206004820152601760248201527f496e76616c6964206964656e74697479206c656e67746800000000000000000060448201526064016109eb565b608c84511115612e6a5760405162461bcd60e51b8152602060048201526016602482015275092dcecc2d8d2c840eecac4e6d2e8ca40d8cadccee8d60531b60448201526064016109eb565b608c83511115612eb35760405162461bcd60e51b8152602060048201526014602482015273092dcecc2d8d2c840cadac2d2d840d8cadccee8d60631b60448201526064016109eb565b61011882511115612eff5760405162461bcd60e51b8152602060048201526016602482015275092dcecc2d8d2c840c8cae8c2d2d8e640d8cadccee8d60531b60448201526064016109eb565b50600195945050505050565b334114612f475760405162461bcd60e51b815260206004820152600a6024820152694d696e6572206f6e6c7960b01b60448201526064016109eb565b43600090815260156020908152604080832083805290915290205460ff1615612fb25760405162461bcd60e51b815260206004820152601960248201527f426c6f636b20697320616c72656164792072657761726465640000000000000060448201526064016109eb565b60005460ff16612fd45760405162461bcd60e51b81526004016109eb90614dbc565b4360009081526015602090815260408083208380529091528120805460ff1916600117905560045432913491829190620186a0906130129084614df5565b61301c9190614e22565b90506130288183614de2565b91506000620186a06005548561303e9190614df5565b6130489190614e22565b90506007548160085461305b9190614da9565b116130b85761306a8184614de2565b9250806008600082825461307e9190614da9565b909155505080156130b85760405160009082156108fc0290839083818181858288f193505050501580156130b6573d6000803e3d6000fd5b505b865115613267576000620186a0600654866130d39190614df5565b6130dd9190614e22565b90506130e98185614de2565b935060008851826130fa9190614e22565b905080156132645760005b89518110156132625760006001600160a01b03168a828151811061312b5761312b614e36565b60200260200101516001600160a01b031614158015613191575060006001600160a01b0316601060008c848151811061316657613166614e36565b6020908102919091018101516001600160a01b03908116835290820192909252604001600020541614155b80156131bf575060008982815181106131ac576131ac614e36565b60200260200101516001600160401b0316115b1561325057601060008b83815181106131da576131da614e36565b60200260200101516001600160a01b03166001600160a01b0316815260200190815260200160002060009054906101000a90046001600160a01b03166001600160a01b03166108fc839081150290604051600060405180830381858888f1935050505015801561324e573d6000803e3d6000fd5b505b8061325a81614e4c565b915050613105565b505b50505b6001600160a01b0385166000818152601360209081526040808320601283528184208054855290835281842054948452429055600a9091529020600101541561331d576001600160a01b0386166000908152600a60205260409020600101546132d98568056bc75e2d63100000614df5565b6132e39190614e22565b6132ed9082614da9565b6001600160a01b038716600090815260136020908152604080832060128352818420548452909152902055613357565b6001600160a01b03861660009081526013602090815260408083206012835281842054845290915290208190556133548484614da9565b92505b60006001600160a01b0387166000908152600a6020526040902054600160a01b900460ff16600481111561338d5761338d6147a3565b0361339c575050505050505050565b6133a7836000613fd2565b856001600160a01b03167f1d6ce8f9f300ecd90371399ce90da8388bba45019701a1fd6d6ee08ea580077684428b8b6040516133e69493929190614fe3565b60405180910390a25050505050505050565b6001600160a01b03818116600090815260106020526040812054909116156134515760405162461bcd60e51b815260206004820152600c60248201526b1a5b9d985b1a590818d85b1b60a21b60448201526064016109eb565b506001600160a01b0316600090815260106020526040902080546001600160a01b03191632179055600190565b60008061348b6000614306565b915091509091565b61349b613f26565b620186a081836134ab8789614da9565b6134b59190614da9565b6134bf9190614da9565b111561350d5760405162461bcd60e51b815260206004820152601a60248201527f546f74616c20686173206578636565646564206279203130302500000000000060448201526064016109eb565b600485905560058490556003829055600681905561353383670de0b6b3a7640000614df5565b6007555050505050565b613545613f26565b60008361ffff161180156135595750600082115b6135bb5760405162461bcd60e51b815260206004820152602d60248201527f496e636f7272656374204d617856616c696461746f7273206f72204d696e696d60448201526c30b629ba30b5b4b733a1b7b4b760991b60648201526084016109eb565b6000805461ffff9094166101000262ffff001990941693909317909255600155600255565b6000805460ff166136035760405162461bcd60e51b81526004016109eb90614dbc565b3260006001600160a01b0384166000908152600a6020526040902054600160a01b900460ff16600481111561363a5761363a6147a3565b0361367d5760405162461bcd60e51b815260206004820152601360248201527215985b1a59185d1bdc881b9bdd08195e1a5cdd606a1b60448201526064016109eb565b6001600160a01b038082166000908152600b602090815260408083209387168352928152828220600a909152919020815460018301541561370c5760405162461bcd60e51b815260206004820152602360248201527f596f752061726520616c726561647920696e20756e7374616b696e672073746160448201526274757360e81b60648201526084016109eb565b600081116137575760405162461bcd60e51b8152602060048201526018602482015277596f7520646f6e2774206861766520616e79207374616b6560401b60448201526064016109eb565b600d54600114801561376d575061376d86611eba565b801561378957506001548183600101546137879190614de2565b105b1561380a5760405162461bcd60e51b815260206004820152604560248201527f596f752063616e277420756e7374616b652c2076616c696461746f72206c697360448201527f742077696c6c20626520656d7074792061667465722074686973206f7065726160648201526474696f6e2160d81b608482015260a4016109eb565b600982015461381b90600190614de2565b8360020154146139075760098201805461383790600190614de2565b8154811061384757613847614e36565b9060005260206000200160009054906101000a90046001600160a01b03168260090184600201548154811061387e5761387e614e36565b9060005260206000200160006101000a8154816001600160a01b0302191690836001600160a01b031602179055508260020154600b6000846009018660020154815481106138ce576138ce614e36565b60009182526020808320909101546001600160a01b0390811684528382019490945260409283018220938b168252929092529020600201555b8160090180548061391a5761391a61504e565b600082815260209020810160001990810180546001600160a01b0319169055019055600182015461394c908290614de2565b600180840191909155439084015560006002840155600e5461396f908290614de2565b600e5560018054908301541080156139bb575060046001600160a01b0387166000908152600a6020526040902054600160a01b900460ff1660048111156139b8576139b86147a3565b14155b156139da57815460ff60a01b1916600360a01b1782556139da8661441e565b6139e3866116ba565b506001600160a01b038481166000818152601160209081526040808320948b1680845294825280832092909255815185815242918101919091527f449002ae18e748d69a55f38514400d64f966492e593e32d6e9b8b24db98a0bc1910160405180910390a350600195945050505050565b613a5c613f26565b6001600160a01b038116613ac15760405162461bcd60e51b815260206004820152602660248201527f4f776e61626c653a206e6577206f776e657220697320746865207a65726f206160448201526564647265737360d01b60648201526084016109eb565b6114c281613f80565b60005b600d54811015613b2557826001600160a01b0316600d8281548110613af457613af4614e36565b6000918252602090912001546001600160a01b031603613b1357505050565b80613b1d81614e4c565b915050613acd565b50600054600d5461010090910461ffff161115613bba57600d80546001810182556000919091527fd7b6990105719101dabeb77144f2a3385c8033acd3af97e9423a695e81ad1eb50180546001600160a01b0319166001600160a01b0384169081179091556040514281527f1e3310ad6891b30e03874ec3d1422a6386c5da63d9faf595f5d99eeaf443b99a9060200161143a565b6000600a6000600d600081548110613bd457613bd4614e36565b60009182526020808320909101546001600160a01b03168352820192909252604001812060019081015492505b600d54811015613ca85782600a6000600d8481548110613c2357613c23614e36565b60009182526020808320909101546001600160a01b031683528201929092526040019020600101541015613c9657600a6000600d8381548110613c6857613c68614e36565b60009182526020808320909101546001600160a01b0316835282019290925260400190206001015492509050805b80613ca081614e4c565b915050613c01565b50818311613cb65750505050565b836001600160a01b03167f1e3310ad6891b30e03874ec3d1422a6386c5da63d9faf595f5d99eeaf443b99a42604051613cf191815260200190565b60405180910390a2600d8181548110613d0c57613d0c614e36565b600091825260209182902001546040514281526001600160a01b03909116917f7521e44559c870c316e84e60bc4785d9c034a8ab1d6acdce8134ac03f946c6ed910160405180910390a283600d8281548110613d6a57613d6a614e36565b9060005260206000200160006101000a8154816001600160a01b0302191690836001600160a01b0316021790555050505050565b60006001600160a01b0382166000908152600a6020526040902054600160a01b900460ff166004811115613dd457613dd46147a3565b1480613de35750600c54600110155b15613deb5750565b6001600160a01b0381166000908152600a60205260409020600701548015613e7657613e178183613fd2565b80600f54613e259190614da9565b600f556001600160a01b0382166000908152600a6020526040902060080154613e4f908290614da9565b6001600160a01b0383166000908152600a6020526040812060088101929092556007909101555b604080518281524260208201526001600160a01b038416917fe294e9d73f8eee23e21b2e1567960625a6b5d339cb127b55d0d09473a9951235910161143a565b60006001600160a01b0382166000908152600a6020526040902054600160a01b900460ff166004811115613eec57613eec6147a3565b03613ef45750565b6001600160a01b0381166000908152600a60205260409020805460ff60a01b1916600160a21b1790556114c28161441e565b6009546001600160a01b031633146116b85760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e657260448201526064016109eb565b600980546001600160a01b038381166001600160a01b0319831681179093556040519116919082907f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e090600090a35050565b81600003613fde575050565b600080613fea83614306565b90925090506000819003613ffe5750505050565b600080836000036141865760006140158488614e22565b90506140218482614df5565b61402b9088614de2565b925060005b600c5481101561411b576000600c828154811061404f5761404f614e36565b6000918252602090912001546001600160a01b0316905060046001600160a01b0382166000908152600a6020526040902054600160a01b900460ff16600481111561409c5761409c6147a3565b141580156140bc5750876001600160a01b0316816001600160a01b031614155b15614108576001600160a01b0381166000908152600a60205260409020600701546140e8908490614da9565b6001600160a01b0382166000908152600a60205260409020600701559250825b508061411381614e4c565b915050614030565b5060008311801561413457506001600160a01b03821615155b1561417d576001600160a01b0382166000908152600a6020526040902060070154614160908490614da9565b6001600160a01b0383166000908152600a60205260409020600701555b50505050505050565b6000805b600c548110156142b8576000600c82815481106141a9576141a9614e36565b6000918252602090912001546001600160a01b0316905060046001600160a01b0382166000908152600a6020526040902054600160a01b900460ff1660048111156141f6576141f66147a3565b141580156142165750876001600160a01b0316816001600160a01b031614155b156142a5576001600160a01b0381166000908152600a60205260408120600101548890614243908c614df5565b61424d9190614e22565b90506142598185614da9565b6001600160a01b0383166000908152600a602052604090206007015492955093508491614287908290614da9565b6001600160a01b0383166000908152600a6020526040902060070155505b50806142b081614e4c565b91505061418a565b506142c38188614de2565b925060008311801561413457506001600160a01b0382161561417d576001600160a01b0382166000908152600a6020526040902060070154614160908490614da9565b60008060005b600c54811015614418576004600a6000600c848154811061432f5761432f614e36565b60009182526020808320909101546001600160a01b0316835282019290925260400190205460ff600160a01b909104166004811115614370576143706147a3565b141580156143a85750600c818154811061438c5761438c614e36565b6000918252602090912001546001600160a01b03858116911614155b1561440657600a6000600c83815481106143c4576143c4614e36565b60009182526020808320909101546001600160a01b031683528201929092526040019020600101546143f69084614da9565b92508161440281614e4c565b9250505b8061441081614e4c565b91505061430c565b50915091565b60005b600d54811080156144345750600d546001105b1561144357600d818154811061444c5761444c614e36565b6000918252602090912001546001600160a01b039081169083160361456b57600d5461447a90600190614de2565b81146144fb57600d805461449090600190614de2565b815481106144a0576144a0614e36565b600091825260209091200154600d80546001600160a01b0390921691839081106144cc576144cc614e36565b9060005260206000200160006101000a8154816001600160a01b0302191690836001600160a01b031602179055505b600d80548061450c5761450c61504e565b600082815260209020810160001990810180546001600160a01b03191690550190556040516001600160a01b038316907f7521e44559c870c316e84e60bc4785d9c034a8ab1d6acdce8134ac03f946c6ed9061143a9042815260200190565b8061457581614e4c565b915050614421565b8280548282559060005260206000209081019282156145d2579160200282015b828111156145d257825182546001600160a01b0319166001600160a01b0390911617825560209092019160019091019061459d565b506145de9291506145e2565b5090565b5b808211156145de57600081556001016145e3565b6001600160a01b03811681146114c257600080fd5b60006020828403121561461e57600080fd5b8135614629816145f7565b9392505050565b6000806040838503121561464357600080fd5b823561464e816145f7565b9150602083013561465e816145f7565b809150509250929050565b60006020828403121561467b57600080fd5b5035919050565b634e487b7160e01b600052604160045260246000fd5b604051601f8201601f191681016001600160401b03811182821017156146c0576146c0614682565b604052919050565b60006001600160401b038211156146e1576146e1614682565b5060051b60200190565b600082601f8301126146fc57600080fd5b8135602061471161470c836146c8565b614698565b82815260059290921b8401810191818101908684111561473057600080fd5b8286015b84811015614754578035614747816145f7565b8352918301918301614734565b509695505050505050565b6000806040838503121561477257600080fd5b82356001600160401b0381111561478857600080fd5b614794858286016146eb565b95602094909401359450505050565b634e487b7160e01b600052602160045260246000fd5b600081518084526020808501945080840160005b838110156147f25781516001600160a01b0316875295820195908201906001016147cd565b509495945050505050565b6001600160a01b038716815260006005871061482957634e487b7160e01b600052602160045260246000fd5b86602083015285604083015284606083015283608083015260c060a083015261485560c08301846147b9565b98975050505050505050565b6000806040838503121561487457600080fd5b823561487f816145f7565b946020939093013593505050565b60208152600061462960208301846147b9565b600080602083850312156148b357600080fd5b82356001600160401b03808211156148ca57600080fd5b818501915085601f8301126148de57600080fd5b8135818111156148ed57600080fd5b8660208260051b850101111561490257600080fd5b60209290920196919550909350505050565b8035611208816145f7565b60008083601f84011261493157600080fd5b5081356001600160401b0381111561494857600080fd5b60208301915083602082850101111561496057600080fd5b9250929050565b600080600080600080600080600080600060c08c8e03121561498857600080fd5b6149918c614914565b9a506001600160401b038060208e013511156149ac57600080fd5b6149bc8e60208f01358f0161491f565b909b50995060408d01358110156149d257600080fd5b6149e28e60408f01358f0161491f565b909950975060608d01358110156149f857600080fd5b614a088e60608f01358f0161491f565b909750955060808d0135811015614a1e57600080fd5b614a2e8e60808f01358f0161491f565b909550935060a08d0135811015614a4457600080fd5b50614a558d60a08e01358e0161491f565b81935080925050509295989b509295989b9093969950565b6000815180845260005b81811015614a9357602081850181015186830182015201614a77565b506000602082860101526020601f19601f83011685010191505092915050565b60a081526000614ac660a0830188614a6d565b8281036020840152614ad88188614a6d565b90508281036040840152614aec8187614a6d565b90508281036060840152614b008186614a6d565b905082810360808401526148558185614a6d565b600082601f830112614b2557600080fd5b81356001600160401b03811115614b3e57614b3e614682565b614b51601f8201601f1916602001614698565b818152846020838601011115614b6657600080fd5b816020850160208301376000918101602001919091529392505050565b600080600080600060a08688031215614b9b57600080fd5b85356001600160401b0380821115614bb257600080fd5b614bbe89838a01614b14565b96506020880135915080821115614bd457600080fd5b614be089838a01614b14565b95506040880135915080821115614bf657600080fd5b614c0289838a01614b14565b94506060880135915080821115614c1857600080fd5b614c2489838a01614b14565b93506080880135915080821115614c3a57600080fd5b50614c4788828901614b14565b9150509295509295909350565b60008060408385031215614c6757600080fd5b82356001600160401b0380821115614c7e57600080fd5b614c8a868387016146eb565b9350602091508185013581811115614ca157600080fd5b8501601f81018713614cb257600080fd5b8035614cc061470c826146c8565b81815260059190911b82018401908481019089831115614cdf57600080fd5b928501925b82841015614d0c5783358581168114614cfd5760008081fd5b82529285019290850190614ce4565b8096505050505050509250929050565b600080600080600060a08688031215614d3457600080fd5b505083359560208501359550604085013594606081013594506080013592509050565b600080600060608486031215614d6c57600080fd5b833561ffff81168114614d7e57600080fd5b95602085013595506040909401359392505050565b634e487b7160e01b600052601160045260246000fd5b8082018082111561131757611317614d93565b6020808252600c908201526b139bdd081a5b9a5d081e595d60a21b604082015260600190565b8181038181111561131757611317614d93565b808202811582820484141761131757611317614d93565b634e487b7160e01b600052601260045260246000fd5b600082614e3157614e31614e0c565b500490565b634e487b7160e01b600052603260045260246000fd5b600060018201614e5e57614e5e614d93565b5060010190565b600082614e7457614e74614e0c565b500690565b600060208284031215614e8b57600080fd5b8151801515811461462957600080fd5b600181811c90821680614eaf57607f821691505b602082108103614ecf57634e487b7160e01b600052602260045260246000fd5b50919050565b601f821115614f1f57600081815260208120601f850160051c81016020861015614efc5750805b601f850160051c820191505b81811015614f1b57828155600101614f08565b5050505b505050565b81516001600160401b03811115614f3d57614f3d614682565b614f5181614f4b8454614e9b565b84614ed5565b602080601f831160018114614f865760008415614f6e5750858301515b600019600386901b1c1916600185901b178555614f1b565b600085815260208120601f198616915b82811015614fb557888601518255948401946001909101908401614f96565b5085821015614fd35787850151600019600388901b60f8161c191681555b5050505050600190811b01905550565b8481526000602085818401526080604084015261500360808401866147b9565b838103606085015284518082528286019183019060005b8181101561503f5783516001600160401b03168352928401929184019160010161501a565b50909998505050505050505050565b634e487b7160e01b600052603160045260246000fdfea2646970667358221220b197da4f532bf7e625e4bbfd6053b7efb9a26734404b01d55b7c8d3c61236e4564736f6c63430008110033"
+	punishCode     = "0x608060405234801561001057600080fd5b50600436106101585760003560e01c8063966ff650116100c3578063d93d2cb91161007c578063d93d2cb914610286578063db78dd2814610299578063e0d8ea53146102bb578063ea7221a1146102c3578063f62af26c146102d6578063fca737c0146102e957600080fd5b8063966ff650146102335780639d7714ce1461023c578063be64569214610245578063c967f90f1461024e578063cb1ea72514610274578063cd779d211461027d57600080fd5b80633a061bd3116101155780633a061bd3146101f257806342127ee7146101fb57806344c1aa99146102045780636233be5d1461020d57806363e1d451146102165780638129fc1c1461022957600080fd5b80630976c65d1461015d578063158ef93e146101795780631b5e358c146101965780632897183d146101b757806332f3c17f146101c057806337dbc8cc146101e9575b600080fd5b61016660025481565b6040519081526020015b60405180910390f35b6000546101869060ff1681565b6040519015158152602001610170565b61019f61f00181565b6040516001600160a01b039091168152602001610170565b610166600b5481565b6101666101ce366004610b58565b6001600160a01b03166000908152600d602052604090205490565b61016660055481565b61019f61f00081565b61016660045481565b610166600a5481565b61019f61f00281565b610186610224366004610b58565b6102f2565b61023161051e565b005b61016660085481565b61016660075481565b61016660015481565b60005461026190610100900461ffff1681565b60405161ffff9091168152602001610170565b61016660095481565b61016660035481565b610231610294366004610b88565b610599565b6102a261708081565b60405167ffffffffffffffff9091168152602001610170565b600e54610166565b6102316102d1366004610b58565b61082e565b61019f6102e4366004610b88565b610b2e565b61016660065481565b6000805460ff1661031e5760405162461bcd60e51b815260040161031590610ba1565b60405180910390fd5b3361f0001461036f5760405162461bcd60e51b815260206004820152601860248201527f56616c696461746f727320636f6e7472616374206f6e6c7900000000000000006044820152606401610315565b6001600160a01b0382166000908152600d6020526040902054156103a7576001600160a01b0382166000908152600d60205260408120555b6001600160a01b0382166000908152600d602052604090206002015460ff1680156103d35750600e5415155b1561051657600e546103e790600190610bdd565b6001600160a01b0383166000908152600d6020526040902060010154146104b757600e80546000919061041c90600190610bdd565b8154811061042c5761042c610bf6565b60009182526020808320909101546001600160a01b038681168452600d909252604090922060010154600e8054929093169350839291811061047057610470610bf6565b600091825260208083209190910180546001600160a01b0319166001600160a01b039485161790558583168252600d90526040808220600190810154949093168252902001555b600e8054806104c8576104c8610c0c565b60008281526020808220830160001990810180546001600160a01b03191690559092019092556001600160a01b0384168252600d9052604081206001810191909155600201805460ff191690555b506001919050565b60005460ff16156105675760405162461bcd60e51b8152602060048201526013602482015272105b1c9958591e481a5b9a5d1a585b1a5e9959606a1b6044820152606401610315565b600c80546001600160a01b03191661f000179055603060098190556060600a55600b556000805460ff19166001179055565b3341146105d55760405162461bcd60e51b815260206004820152600a6024820152694d696e6572206f6e6c7960b01b6044820152606401610315565b4360009081526010602052604090205460ff16156106295760405162461bcd60e51b8152602060048201526011602482015270105b1c9958591e48191958dc99585cd959607a1b6044820152606401610315565b60005460ff1661064b5760405162461bcd60e51b815260040161031590610ba1565b806106568143610c38565b156106965760405162461bcd60e51b815260206004820152601060248201526f426c6f636b2065706f6368206f6e6c7960801b6044820152606401610315565b436000908152601060205260409020805460ff19166001179055600e541561082a5760005b600e548110156107ff57600b54600a546106d59190610c4c565b600d6000600e84815481106106ec576106ec610bf6565b60009182526020808320909101546001600160a01b0316835282019290925260400190205411156107ae57600b54600a546107279190610c4c565b600d6000600e848154811061073e5761073e610bf6565b60009182526020808320909101546001600160a01b0316835282019290925260400190205461076d9190610bdd565b600d6000600e848154811061078457610784610bf6565b60009182526020808320909101546001600160a01b031683528201929092526040019020556107ed565b6000600d6000600e84815481106107c7576107c7610bf6565b60009182526020808320909101546001600160a01b031683528201929092526040019020555b806107f781610c60565b9150506106bb565b506040517f181d51be54e8e8eaca6eae0eab32d4162099236bd519e7238d015d0870db464190600090a15b5050565b33411461086a5760405162461bcd60e51b815260206004820152600a6024820152694d696e6572206f6e6c7960b01b6044820152606401610315565b60005460ff1661088c5760405162461bcd60e51b815260040161031590610ba1565b436000908152600f602052604090205460ff16156108df5760405162461bcd60e51b815260206004820152601060248201526f105b1c9958591e481c1d5b9a5cda195960821b6044820152606401610315565b436000908152600f60209081526040808320805460ff191660011790556001600160a01b0384168352600d90915290206002015460ff1661098857600e80546001600160a01b0383166000818152600d6020526040812060018082018590558085019095557fbb7b4a454dc3493923482f07822329ed19e8244eff582cc204f8554c3620c3fd90930180546001600160a01b0319168317905552600201805460ff191690911790555b6001600160a01b0381166000908152600d602052604081208054916109ac83610c60565b9091555050600a546001600160a01b0382166000908152600d60205260409020546109d79190610c38565b600003610a5b57600c546040516340a141ff60e01b81526001600160a01b038381166004830152909116906340a141ff90602401600060405180830381600087803b158015610a2557600080fd5b505af1158015610a39573d6000803e3d6000fd5b5050506001600160a01b0382166000908152600d602052604081205550610ae8565b6009546001600160a01b0382166000908152600d6020526040902054610a819190610c38565b600003610ae857600c546040516305dd095960e41b81526001600160a01b03838116600483015290911690635dd0959090602401600060405180830381600087803b158015610acf57600080fd5b505af1158015610ae3573d6000803e3d6000fd5b505050505b806001600160a01b03167f770e0cca42c35d00240986ce8d3ed438be04663c91dac6576b79537d7c180f1e42604051610b2391815260200190565b60405180910390a250565b600e8181548110610b3e57600080fd5b6000918252602090912001546001600160a01b0316905081565b600060208284031215610b6a57600080fd5b81356001600160a01b0381168114610b8157600080fd5b9392505050565b600060208284031215610b9a57600080fd5b5035919050565b6020808252600c908201526b139bdd081a5b9a5d081e595d60a21b604082015260600190565b634e487b7160e01b600052601160045260246000fd5b81810381811115610bf057610bf0610bc7565b92915050565b634e487b7160e01b600052603260045260246000fd5b634e487b7160e01b600052603160045260246000fd5b634e487b7160e01b600052601260045260246000fd5b600082610c4757610c47610c22565b500690565b600082610c5b57610c5b610c22565b500490565b600060018201610c7257610c72610bc7565b506001019056fea26469706673582212204c57e0973db1183a0e59c736997f53c66245e3d092bf062126708020dad7fca664736f6c63430008110033"
+	proposalCode   = "0x608060405234801561001057600080fd5b50600436106101585760003560e01c8063966ff650116100c3578063c967f90f1161007c578063c967f90f14610322578063cd779d2114610348578063da35c66414610351578063db78dd281461035a578063e823c8141461037c578063fca737c01461038557600080fd5b8063966ff650146102715780639d7714ce1461027a5780639f2524ee14610283578063a224cee7146102f1578063bcfb9b6114610306578063be6456921461031957600080fd5b806337dbc8cc1161011557806337dbc8cc146102075780633a061bd31461021057806342127ee7146102195780636233be5d146102225780636e0e16cf1461022b57806382c4b3b21461024e57600080fd5b8063013cf08b1461015d5780630976c65d1461018c578063158ef93e146101a357806315ea2781146101c05780631b5e358c146101d35780631f4f7d29146101f4575b600080fd5b61017061016b366004610fdd565b61038e565b6040516101839796959493929190610ff6565b60405180910390f35b61019560025481565b604051908152602001610183565b6000546101b09060ff1681565b6040519015158152602001610183565b6101b06101ce3660046110a8565b61046c565b6101dc61f00181565b6040516001600160a01b039091168152602001610183565b6101b06102023660046110cc565b610533565b61019560055481565b6101dc61f00081565b61019560045481565b6101dc61f00281565b6101b06102393660046110a8565b600b6020526000908152604090205460ff1681565b6101b061025c3660046110a8565b600a6020526000908152604090205460ff1681565b61019560085481565b61019560075481565b6102ca610291366004611151565b600e6020908152600092835260408084209091529082529020805460018201546002909201546001600160a01b03909116919060ff1683565b604080516001600160a01b0390941684526020840192909252151590820152606001610183565b6103046102ff36600461117d565b6107fc565b005b6101b0610314366004611200565b610963565b61019560015481565b60005461033590610100900461ffff1681565b60405161ffff9091168152602001610183565b61019560035481565b610195600c5481565b61036361708081565b60405167ffffffffffffffff9091168152602001610183565b61019560095481565b61019560065481565b600d602052600090815260409020805460018201546002830180546001600160a01b039384169492909316926103c390611230565b80601f01602080910402602001604051908101604052809291908181526020018280546103ef90611230565b801561043c5780601f106104115761010080835404028352916020019161043c565b820191906000526020600020905b81548152906001019060200180831161041f57829003601f168201915b50505050600383015460049093015491929161ffff80821692506201000082041690640100000000900460ff1687565b60003361f000146104c45760405162461bcd60e51b815260206004820152601860248201527f56616c696461746f727320636f6e7472616374206f6e6c79000000000000000060448201526064015b60405180910390fd5b6001600160a01b0382166000818152600a60209081526040808320805460ff19908116909155600b835292819020805490931690925590514281527f4e0b191f7f5c32b1b5e3704b68874b1a3980147cae00be8ece271bfb5b92c07a910160405180910390a25060015b919050565b6001600160a01b0383166000908152600b602052604081205460ff161561059c5760405162461bcd60e51b815260206004820152601760248201527f416c7265616479206163746976652070726f706f73616c00000000000000000060448201526064016104bb565b600c54610bb88311156105e45760405162461bcd60e51b815260206004820152601060248201526f44657461696c7320746f6f206c6f6e6760801b60448201526064016104bb565b6000818152600d6020526040902060030154156106435760405162461bcd60e51b815260206004820152601760248201527f50726f706f73616c20616c72656164792065786973747300000000000000000060448201526064016104bb565b6040805160e081018252606081830181905260009082018190526080820181905260a0820181905260c08201523381526001600160a01b0387166020808301919091528251601f87018290048202810182019093528583529091908690869081908401838280828437600092018290525060408087019586524260608801526001600160a01b03808d168352600b6020908152828420805460ff191660019081179091558a8552600d825292909320885181549083166001600160a01b031991821617825593890151928101805493909216929093169190911790559351859493506002840192506107369150826112cf565b506060820151600382015560808201516004909101805460a084015160c09094015115156401000000000264ff000000001961ffff958616620100000263ffffffff19909316959094169490941717919091169190911790556040516001600160a01b03871690339084907f83215a16027e8ce9a2151bd2769637d930303c5452124c21e3eec07ebbc678d5906107d09042815260200190565b60405180910390a46001600c60008282546107eb91906113a5565b909155506001979650505050505050565b60005460ff16156108455760405162461bcd60e51b8152602060048201526013602482015272105b1c9958591e481a5b9a5d1a585b1a5e9959606a1b60448201526064016104bb565b62093a80600955600f80546001600160a01b03191661f00017905560005b8181101561095157600083838381811061087f5761087f6113b8565b905060200201602081019061089491906110a8565b6001600160a01b0316036108ea5760405162461bcd60e51b815260206004820152601960248201527f496e76616c69642076616c696461746f7220616464726573730000000000000060448201526064016104bb565b6001600a6000858585818110610902576109026113b8565b905060200201602081019061091791906110a8565b6001600160a01b031681526020810191909152604001600020805460ff191691151591909117905580610949816113ce565b915050610863565b50506000805460ff1916600117905550565b600f54604051631015428760e21b81523360048201526000916001600160a01b0316906340550a1c90602401602060405180830381865afa1580156109ac573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906109d091906113e7565b610a0d5760405162461bcd60e51b815260206004820152600e60248201526d56616c696461746f72206f6e6c7960901b60448201526064016104bb565b6000838152600d60205260408120600301549003610a625760405162461bcd60e51b8152602060048201526012602482015271141c9bdc1bdcd85b081b9bdd08195e1a5cdd60721b60448201526064016104bb565b336000908152600e6020908152604080832086845290915290206001015415610ad95760405162461bcd60e51b815260206004820152602360248201527f596f752063616e277420766f746520666f7220612070726f706f73616c20747760448201526269636560e81b60648201526084016104bb565b6009546000848152600d6020526040902060030154610af891906113a5565b4210610b395760405162461bcd60e51b815260206004820152601060248201526f141c9bdc1bdcd85b08195e1c1a5c995960821b60448201526064016104bb565b336000818152600e60209081526040808320878452825291829020426001820181905581546001600160a01b031916851782556002909101805460ff191687151590811790915583519081529182015285917f974e8310941c2fc01bbd827ebb30c7aabbb5a43a6273b22f9135b5dee7ccc935910160405180910390a38115610c07576000838152600d6020526040902060040154610bdd9061ffff166001611404565b6000848152600d60205260409020600401805461ffff191661ffff92909216919091179055610c5d565b6000838152600d6020526040902060040154610c2e9062010000900461ffff166001611404565b6000848152600d60205260409020600401805461ffff92909216620100000263ffff0000199092169190911790555b6000838152600d60209081526040808320600101546001600160a01b03168352600b90915290205460ff161580610cad57506000838152600d6020526040902060040154640100000000900460ff165b15610cba57506001610fd7565b600f54604080516313bce04b60e31b815290516002926001600160a01b031691639de702589160048083019260009291908290030181865afa158015610d04573d6000803e3d6000fd5b505050506040513d6000823e601f3d908101601f19168201604052610d2c9190810190611431565b51610d3791906114ea565b610d429060016113a5565b6000848152600d602052604090206004015461ffff1610610e98576000838152600d60208181526040808420600180820180546001600160a01b039081168852600a8652848820805460ff191690931790925595899052939092526004918201805464ff000000001916640100000000179055600f549354905163415e9ec960e11b8152908316918101919091529116906382bd3d92906024016020604051808303816000875af1158015610dfb573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190610e1f91906113e7565b506000838152600d6020818152604080842060010180546001600160a01b039081168652600b8452828620805460ff191690559488905292825291548251428152925193169286927f14e3d76a04a11da29be99c4285ea644e3989f96e96f7699686593b40220ea80292908290030190a3506001610fd7565b600f54604080516313bce04b60e31b815290516002926001600160a01b031691639de702589160048083019260009291908290030181865afa158015610ee2573d6000803e3d6000fd5b505050506040513d6000823e601f3d908101601f19168201604052610f0a9190810190611431565b51610f1591906114ea565b610f209060016113a5565b6000848152600d602052604090206004015462010000900461ffff1610610fd3576000838152600d6020818152604080842060048101805464ff00000000191664010000000017905560010180546001600160a01b039081168652600b8452828620805460ff1916905594889052929091529054905191169084907f7132a60e4a157d9a09cdeab65d502a88eb200ddf3f7806eaee511566429ad3c990610fca9042815260200190565b60405180910390a35b5060015b92915050565b600060208284031215610fef57600080fd5b5035919050565b600060018060a01b03808a1683526020818a168185015260e06040850152885191508160e085015260005b8281101561103e5789810182015185820161010001528101611021565b5050610100600081838601015280601f19601f84011685010192505050856060830152611071608083018661ffff169052565b61ffff841660a083015282151560c08301525b98975050505050505050565b6001600160a01b03811681146110a557600080fd5b50565b6000602082840312156110ba57600080fd5b81356110c581611090565b9392505050565b6000806000604084860312156110e157600080fd5b83356110ec81611090565b9250602084013567ffffffffffffffff8082111561110957600080fd5b818601915086601f83011261111d57600080fd5b81358181111561112c57600080fd5b87602082850101111561113e57600080fd5b6020830194508093505050509250925092565b6000806040838503121561116457600080fd5b823561116f81611090565b946020939093013593505050565b6000806020838503121561119057600080fd5b823567ffffffffffffffff808211156111a857600080fd5b818501915085601f8301126111bc57600080fd5b8135818111156111cb57600080fd5b8660208260051b85010111156111e057600080fd5b60209290920196919550909350505050565b80151581146110a557600080fd5b6000806040838503121561121357600080fd5b823591506020830135611225816111f2565b809150509250929050565b600181811c9082168061124457607f821691505b60208210810361126457634e487b7160e01b600052602260045260246000fd5b50919050565b634e487b7160e01b600052604160045260246000fd5b601f8211156112ca57600081815260208120601f850160051c810160208610156112a75750805b601f850160051c820191505b818110156112c6578281556001016112b3565b5050505b505050565b815167ffffffffffffffff8111156112e9576112e961126a565b6112fd816112f78454611230565b84611280565b602080601f831160018114611332576000841561131a5750858301515b600019600386901b1c1916600185901b1785556112c6565b600085815260208120601f198616915b8281101561136157888601518255948401946001909101908401611342565b508582101561137f5787850151600019600388901b60f8161c191681555b5050505050600190811b01905550565b634e487b7160e01b600052601160045260246000fd5b80820180821115610fd757610fd761138f565b634e487b7160e01b600052603260045260246000fd5b6000600182016113e0576113e061138f565b5060010190565b6000602082840312156113f957600080fd5b81516110c5816111f2565b61ffff81811683821601908082111561141f5761141f61138f565b5092915050565b805161052e81611090565b6000602080838503121561144457600080fd5b825167ffffffffffffffff8082111561145c57600080fd5b818501915085601f83011261147057600080fd5b8151818111156114825761148261126a565b8060051b604051601f19603f830116810181811085821117156114a7576114a761126a565b6040529182528482019250838101850191888311156114c557600080fd5b938501935b82851015611084576114db85611426565b845293850193928501926114ca565b60008261150757634e487b7160e01b600052601260045260246000fd5b50049056fea26469706673582212208b56b07374378e295240a511ebe999ad875a018a07ffb19876952d692728ab1764736f6c63430008110033"
+)
+
+// GenesisContracts returns the system contracts to allocate in the genesis block
+// of a new chain: the validators, punish and proposal contracts initialized at
+// block 1. The later contracts are deployed by the forks introducing them.
+func GenesisContracts() core.GenesisAlloc {
+	return core.GenesisAlloc{
+		ValidatorsContractAddr: {Balance: new(big.Int), Code: common.FromHex(validatorsCode)},
+		PunishContractAddr:     {Balance: new(big.Int), Code: common.FromHex(punishCode)},
+		ProposalAddr:           {Balance: new(big.Int), Code: common.FromHex(proposalCode)},
+	}
+}
@@ -0,0 +1,83 @@
+package systemcontract
+
+import (
+	"errors"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/congress/vmcaller"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// errMissingUpgradeCode is returned if the code of a scheduled upgrade is given
+// by hash, but isn't available in the state database.
+var errMissingUpgradeCode = errors.New("missing system contract upgrade code")
+
+// scheduledUpgrade is an upgrade action configured in the congress config.
+type scheduledUpgrade struct {
+	upgrade *params.SysContractUpgrade
+}
+
+func (s *scheduledUpgrade) GetName() string {
+	return s.upgrade.Address.String()
+}
+
+func (s *scheduledUpgrade) Update(config *params.ChainConfig, height *big.Int, state *state.StateDB) (err error) {
+	code := []byte(s.upgrade.Code)
+	if len(code) == 0 {
+		code, err = state.Database().ContractCode(common.Hash{}, s.upgrade.CodeHash)
+		if err != nil || len(code) == 0 {
+			return errMissingUpgradeCode
+		}
+	}
+	state.SetCode(s.upgrade.Address, code)
+	log.Debug("Upgrade code to system contract account", "addr", s.upgrade.Address.String(), "hash", s.upgrade.Hash())
+
+	return
+}
+
+// Execute calls the upgraded contract with the init calldata, if any. A failing
+// call is logged and skipped, its state changes reverted but the new code kept:
+// returning the error would stop every validator at the upgrade block until a
+// new schedule is rolled out, while a later upgrade can still fix the contract.
+func (s *scheduledUpgrade) Execute(state *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig) (err error) {
+	if len(s.upgrade.InitData) == 0 {
+		return
+	}
+	msg := vmcaller.NewLegacyMessage(header.Coinbase, &s.upgrade.Address, 0, new(big.Int), math.MaxUint64, new(big.Int), s.upgrade.InitData, false)
+	if _, err := vmcaller.ExecuteMsg(msg, state, header, chainContext, config); err != nil {
+		log.Error("Skipped failing system contract upgrade init call", "name", s.GetName(), "height", header.Number, "err", err)
+	}
+	return
+}
+
+// ApplyScheduledUpgrades applies the system contract upgrades scheduled in the
+// congress config for the given block, in the configured order.
+func ApplyScheduledUpgrades(state *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig) (err error) {
+	if config == nil || config.Congress == nil || header == nil || state == nil {
+		return
+	}
+	for i := range config.Congress.Upgrades {
+		upgrade := &config.Congress.Upgrades[i]
+		if upgrade.Block.Cmp(header.Number) != 0 {
+			continue
+		}
+		action := &scheduledUpgrade{upgrade: upgrade}
+		log.Info("system contract upgrade", "name", action.GetName(), "height", header.Number, "hash", upgrade.Hash(), "chainId", config.ChainID.String())
+
+		if err = action.Update(config, header.Number, state); err != nil {
+			log.Error("Upgrade system contract update error", "name", action.GetName(), "err", err)
+			return
+		}
+		if err = action.Execute(state, header, chainContext, config); err != nil {
+			log.Error("Upgrade system contract execute error", "name", action.GetName(), "err", err)
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,81 @@
+package systemcontract
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyScheduledUpgrades(t *testing.T) {
+	var (
+		inline   = common.HexToAddress("0x000000000000000000000000000000000000f000")
+		byHash   = common.HexToAddress("0x000000000000000000000000000000000000f001")
+		deployed = common.HexToAddress("0x1000")
+		code     = []byte{0x60, 0x01, 0x60, 0x00, 0x55}
+	)
+	db := state.NewDatabase(rawdb.NewMemoryDatabase())
+	statedb, _ := state.New(common.Hash{}, db, nil)
+	statedb.SetCode(deployed, code)
+	root, err := statedb.Commit(false)
+	require.NoError(t, err)
+	statedb, _ = state.New(root, db, nil)
+
+	config := &params.ChainConfig{ChainID: big.NewInt(1), Congress: &params.CongressConfig{Upgrades: []params.SysContractUpgrade{
+		{Block: big.NewInt(10), Address: inline, Code: []byte{0x00}},
+		{Block: big.NewInt(20), Address: byHash, CodeHash: crypto.Keccak256Hash(code)},
+		{Block: big.NewInt(30), Address: byHash, CodeHash: common.HexToHash("0x01")},
+	}}}
+	// Nothing is scheduled at this block
+	require.NoError(t, ApplyScheduledUpgrades(statedb, &types.Header{Number: big.NewInt(15)}, nil, config))
+	require.Empty(t, statedb.GetCode(inline))
+
+	require.NoError(t, ApplyScheduledUpgrades(statedb, &types.Header{Number: big.NewInt(10)}, nil, config))
+	require.Equal(t, []byte{0x00}, statedb.GetCode(inline))
+	require.Empty(t, statedb.GetCode(byHash))
+
+	require.NoError(t, ApplyScheduledUpgrades(statedb, &types.Header{Number: big.NewInt(20)}, nil, config))
+	require.Equal(t, code, statedb.GetCode(byHash))
+
+	// Unknown code is rejected
+	require.Equal(t, errMissingUpgradeCode, ApplyScheduledUpgrades(statedb, &types.Header{Number: big.NewInt(30)}, nil, config))
+}
+
+// testChainContext is the chain context of the upgrade init calls.
+type testChainContext struct{}
+
+func (testChainContext) Engine() consensus.Engine                    { return ethash.NewFaker() }
+func (testChainContext) GetHeader(common.Hash, uint64) *types.Header { return nil }
+
+func TestScheduledUpgradeInit(t *testing.T) {
+	var (
+		contract = common.HexToAddress("0x000000000000000000000000000000000000f000")
+		// Sets slot 0 to 1 when called with initialize(), reverts otherwise
+		code = common.FromHex("0x60003560e01c638129fc1c14601357600080fd5b600160005500")
+		init = common.FromHex("0x8129fc1c")
+	)
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	config := params.ChainConfig{ChainID: big.NewInt(1), ConstantinopleBlock: big.NewInt(0), Congress: &params.CongressConfig{Upgrades: []params.SysContractUpgrade{
+		{Block: big.NewInt(10), Address: contract, Code: code, InitData: []byte{0xde, 0xad, 0xbe, 0xef}},
+		{Block: big.NewInt(20), Address: contract, Code: code, InitData: init},
+	}}}
+	header := func(number int64) *types.Header {
+		return &types.Header{Number: big.NewInt(number), Difficulty: big.NewInt(2), GasLimit: 8000000}
+	}
+	// A reverting init call is skipped, the code is upgraded nonetheless
+	require.NoError(t, ApplyScheduledUpgrades(statedb, header(10), testChainContext{}, &config))
+	require.Equal(t, code, statedb.GetCode(contract))
+	require.Equal(t, common.Hash{}, statedb.GetState(contract, common.Hash{}))
+
+	// The init call runs against the upgraded code
+	require.NoError(t, ApplyScheduledUpgrades(statedb, header(20), testChainContext{}, &config))
+	require.Equal(t, common.BigToHash(common.Big1), statedb.GetState(contract, common.Hash{}))
+}
@@ -0,0 +1,67 @@
+package systemcontract
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// slashingCode is the runtime code of the slashing contract. The miner slashes a
+// validator once per double-signed height, through the evidence system
+// transactions verified by the engine, and each slash is kept in the slot given
+// by DoubleSignSlot so the engine checks it without calling the contract.
+//
+//	00 PUSH1 0x04 CALLDATASIZE LT PUSH1 0x21 JUMPI         ; revert without selector
+//	07 PUSH1 0x00 CALLDATALOAD PUSH1 0xe0 SHR              ; selector
+//	0d DUP1 PUSH4 0x02fb4d85 EQ PUSH1 0x26 JUMPI           ; slash(address,uint256)
+//	17 DUP1 PUSH4 0x2333be09 EQ PUSH1 0x83 JUMPI           ; isSlashed(address,uint256)
+//	21 JUMPDEST PUSH1 0x00 DUP1 REVERT
+//	26 JUMPDEST CALLVALUE PUSH1 0x21 JUMPI                 ; slash: not payable
+//	2b COINBASE CALLER EQ ISZERO PUSH1 0x21 JUMPI          ; only by the miner
+//	32 PUSH1 0x44 CALLDATASIZE LT PUSH1 0x21 JUMPI
+//	39 PUSH1 0x04 CALLDATALOAD DUP1 PUSH1 0xa0 SHR PUSH1 0x21 JUMPI ; validator must be an address
+//	43 DUP1 PUSH1 0x00 MSTORE PUSH1 0x24 CALLDATALOAD DUP1 PUSH1 0x20 MSTORE
+//	4e PUSH1 0x40 PUSH1 0x00 SHA3                          ; keccak256(validator, number)
+//	53 DUP1 SLOAD PUSH1 0x21 JUMPI                         ; once per height
+//	58 PUSH1 0x01 SWAP1 SSTORE                             ; slashed[validator][number] = true
+//	5c SWAP1 PUSH32 DoubleSignSlashed PUSH1 0x00 DUP1 LOG3 STOP
+//	83 JUMPDEST CALLVALUE PUSH1 0x21 JUMPI                 ; isSlashed: not payable
+//	88 PUSH1 0x44 CALLDATASIZE LT PUSH1 0x21 JUMPI
+//	8f PUSH1 0x04 CALLDATALOAD PUSH1 0x00 MSTORE PUSH1 0x24 CALLDATALOAD PUSH1 0x20 MSTORE
+//	9b PUSH1 0x40 PUSH1 0x00 SHA3 SLOAD                    ; slashed[validator][number]
+//	a1 PUSH1 0x00 MSTORE PUSH1 0x20 PUSH1 0x00 RETURN
+const slashingCode = "0x6004361060215760003560e01c806302fb4d851460265780632333be09146083575b600080fd5b3460215741331415602157604436106021576004358060a01c60215780600052602435806020526040600020805460215760019055907f0d04b87d9ba96a6821e24260d21909498d56a5c29f680343a79667af68e77e91600080a3005b346021576044361060215760043560005260243560205260406000205460005260206000f3"
+
+// DoubleSignSlot returns the storage slot of the slashing contract recording
+// whether the double sign of the validator at the given height was slashed.
+func DoubleSignSlot(validator common.Address, number uint64) common.Hash {
+	return crypto.Keccak256Hash(common.BytesToHash(validator.Bytes()).Bytes(), common.BigToHash(new(big.Int).SetUint64(number)).Bytes())
+}
+
+type hardForkSlashing struct {
+}
+
+func (s *hardForkSlashing) GetName() string {
+	return SlashingContractName
+}
+
+func (s *hardForkSlashing) Update(config *params.ChainConfig, height *big.Int, state *state.StateDB) (err error) {
+	contractCode := common.FromHex(slashingCode)
+
+	//write code to sys contract
+	state.SetCode(SlashingContractAddr, contractCode)
+	log.Debug("Write code to system contract account", "addr", SlashingContractAddr.String(), "code", slashingCode)
+
+	return
+}
+
+func (s *hardForkSlashing) Execute(state *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig) (err error) {
+	// the contract has no state to initialize, the slashes are recorded by the miner
+	return
+}
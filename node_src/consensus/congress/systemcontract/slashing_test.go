@@ -0,0 +1,79 @@
+package systemcontract
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlashingContract(t *testing.T) {
+	var (
+		miner     = common.HexToAddress("0x1000")
+		validator = common.HexToAddress("0x2000")
+		number    = big.NewInt(42)
+		abi       = GetInteractiveABI()[SlashingContractName]
+	)
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	config := &params.ChainConfig{ChainID: big.NewInt(1), SlashingBlock: big.NewInt(10), Congress: &params.CongressConfig{Epoch: 10}}
+	require.NoError(t, ApplySystemContractUpgrade(SysContractSlashing, statedb, &types.Header{Number: big.NewInt(10)}, nil, config))
+
+	evm := vm.NewEVM(vm.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		Coinbase:    miner,
+		BlockNumber: big.NewInt(10),
+		Difficulty:  big.NewInt(2),
+	}, vm.TxContext{}, statedb, params.AllEthashProtocolChanges, vm.Config{})
+
+	raw := func(from common.Address, data []byte) ([]byte, error) {
+		ret, _, err := evm.Call(vm.AccountRef(from), SlashingContractAddr, data, 100000, new(big.Int))
+		return ret, err
+	}
+	call := func(from common.Address, method string, args ...interface{}) ([]byte, error) {
+		data, err := abi.Pack(method, args...)
+		require.NoError(t, err)
+		return raw(from, data)
+	}
+	// Nobody is slashed at first
+	ret, err := call(validator, "isSlashed", validator, number)
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{}.Bytes(), ret)
+
+	// Only the miner slashes, once per height, and the slash is read back from its slot
+	_, err = call(validator, "slash", validator, number)
+	require.Equal(t, vm.ErrExecutionReverted, err)
+	_, err = call(miner, "slash", validator, number)
+	require.NoError(t, err)
+	require.Equal(t, common.BigToHash(common.Big1), statedb.GetState(SlashingContractAddr, DoubleSignSlot(validator, number.Uint64())))
+	_, err = call(miner, "slash", validator, number)
+	require.Equal(t, vm.ErrExecutionReverted, err)
+
+	ret, err = call(validator, "isSlashed", validator, number)
+	require.NoError(t, err)
+	require.Equal(t, common.BigToHash(common.Big1).Bytes(), ret)
+	ret, err = call(validator, "isSlashed", validator, new(big.Int).Add(number, common.Big1))
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{}.Bytes(), ret)
+
+	logs := statedb.Logs()
+	require.Len(t, logs, 1)
+	require.Equal(t, []common.Hash{abi.Events["DoubleSignSlashed"].ID, common.BytesToHash(validator.Bytes()), common.BigToHash(number)}, logs[0].Topics)
+
+	// Short calldata, unknown methods and values out of the address range are rejected
+	_, err = raw(miner, abi.Methods["slash"].ID)
+	require.Equal(t, vm.ErrExecutionReverted, err)
+	data, _ := abi.Pack("slash", validator, number)
+	data[4] = 0x01
+	_, err = raw(miner, data)
+	require.Equal(t, vm.ErrExecutionReverted, err)
+	_, err = raw(miner, []byte{0xde, 0xad, 0xbe, 0xef})
+	require.Equal(t, vm.ErrExecutionReverted, err)
+}
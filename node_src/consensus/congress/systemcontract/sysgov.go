@@ -50,7 +50,7 @@ func (s *hardForkSysGov) getAdminByChainId(chainId *big.Int) common.Address {
 func (s *hardForkSysGov) Execute(state *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig) (err error) {
 
 	method := "initialize"
-	data, err := GetInteractiveABI()[SysGovContractName].Pack(method, s.getAdminByChainId(config.ChainID))
+	data, err := GetInteractiveABI()[SysGovContractName].Pack(method, configuredAdmin(config, s.getAdminByChainId(config.ChainID)))
 	if err != nil {
 		log.Error("Can't pack data for initialize", "error", err)
 		return err
@@ -3,6 +3,7 @@ package systemcontract
 import (
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -13,6 +14,7 @@ import (
 const (
 	SysContractV1 SysContractVersion = iota + 1
 	SysContractV2
+	SysContractSlashing
 )
 
 type SysContractVersion int
@@ -43,6 +45,10 @@ func ApplySystemContractUpgrade(version SysContractVersion, state *state.StateDB
 			&hardForkAddressListV2{},
 			&hardForkValidatorsV2{},
 		}
+	case SysContractSlashing:
+		sysContracts = []IUpgradeAction{
+			&hardForkSlashing{},
+		}
 	default:
 		log.Crit("unsupported SysContractVersion", "version", version)
 	}
@@ -67,3 +73,12 @@ func ApplySystemContractUpgrade(version SysContractVersion, state *state.StateDB
 
 	return
 }
+
+// configuredAdmin returns the admin set in the congress config of the chain, or
+// the given built-in admin if there is none.
+func configuredAdmin(config *params.ChainConfig, builtin common.Address) common.Address {
+	if config.Congress != nil && config.Congress.Admin != nil {
+		return *config.Congress.Admin
+	}
+	return builtin
+}
@@ -67,7 +67,7 @@ func (s *hardForkValidatorsV1) Execute(state *state.StateDB, header *types.Heade
 
 	// initialize v1 contract
 	method := "initialize"
-	data, err := GetInteractiveABI()[ValidatorsV1ContractName].Pack(method, topVals, managers, s.getAdminByChainId(config.ChainID))
+	data, err := GetInteractiveABI()[ValidatorsV1ContractName].Pack(method, topVals, managers, configuredAdmin(config, s.getAdminByChainId(config.ChainID)))
 	if err != nil {
 		log.Error("Can't pack data for initialize", "error", err)
 		return err
@@ -0,0 +1,94 @@
+package congress
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// errUntrustedCheckpoint is returned if a header at or below the height of the
+// trusted checkpoint is known not to be on the chain of the checkpoint.
+var errUntrustedCheckpoint = errors.New("header off the trusted checkpoint chain")
+
+// TrustedCheckpoint is an epoch checkpoint the node trusts, seeding the validator
+// set from its extra-data instead of verifying the headers up to it.
+type TrustedCheckpoint struct {
+	Number uint64
+	Hash   common.Hash
+}
+
+// SetTrustedCheckpoint makes the engine trust the given epoch checkpoint: the
+// headers of its chain up to it are accepted without verification, and the
+// snapshot at the checkpoint is taken from the validators it lists. The recents
+// of the validators start empty at the checkpoint.
+func (c *Congress) SetTrustedCheckpoint(checkpoint *TrustedCheckpoint) error {
+	if checkpoint.Number == 0 || checkpoint.Number%c.config.EpochAt(checkpoint.Number) != 0 {
+		return fmt.Errorf("trusted checkpoint %d is not an epoch block", checkpoint.Number)
+	}
+	c.trusted = checkpoint
+	return nil
+}
+
+// verifyTrusted returns whether the header is at or below the trusted checkpoint
+// and known to be on its chain or off it, in which case it's not verified any
+// further. A header below the checkpoint is on its chain if the descendants of
+// the header being verified with it, in ascending order, link it to the
+// checkpoint, or if the checkpoint is canonical and the header is too. Any
+// other header below the checkpoint goes through the full verification.
+func (c *Congress) verifyTrusted(chain consensus.ChainHeaderReader, header *types.Header, descendants []*types.Header) (bool, error) {
+	if c.trusted == nil || header.Number == nil || header.Number.Uint64() > c.trusted.Number {
+		return false, nil
+	}
+	number, hash := header.Number.Uint64(), header.Hash()
+	for _, descendant := range descendants {
+		if number == c.trusted.Number || descendant.ParentHash != hash {
+			break
+		}
+		number, hash = descendant.Number.Uint64(), descendant.Hash()
+	}
+	if number == c.trusted.Number {
+		if hash != c.trusted.Hash {
+			return true, errUntrustedCheckpoint
+		}
+		return true, nil
+	}
+	if chain == nil {
+		return false, nil
+	}
+	if checkpoint := chain.GetHeaderByNumber(c.trusted.Number); checkpoint == nil || checkpoint.Hash() != c.trusted.Hash {
+		return false, nil
+	}
+	if canonical := chain.GetHeaderByNumber(number); canonical == nil || canonical.Hash() != hash {
+		return true, errUntrustedCheckpoint
+	}
+	return true, nil
+}
+
+// trustedSnapshot creates the snapshot at the trusted checkpoint if it's the
+// requested block, out of the validators it lists.
+func (c *Congress) trustedSnapshot(chain consensus.ChainHeaderReader, number uint64, hash common.Hash, parents []*types.Header) (*Snapshot, error) {
+	if c.trusted == nil || c.trusted.Number != number || c.trusted.Hash != hash {
+		return nil, nil
+	}
+	var checkpoint *types.Header
+	if len(parents) > 0 && parents[len(parents)-1].Hash() == hash {
+		checkpoint = parents[len(parents)-1]
+	} else if checkpoint = chain.GetHeader(hash, number); checkpoint == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	validators, keys := parseCheckpoint(c.config, checkpoint)
+	if len(validators) == 0 {
+		return nil, errInvalidValidatorsLength
+	}
+	snap := newSnapshot(c.config, c.signatures, number, hash, validators)
+	snap.Keys = consensusKeys(validators, keys)
+	if err := snap.store(c.db); err != nil {
+		return nil, err
+	}
+	log.Info("Stored trusted checkpoint snapshot to disk", "number", number, "hash", hash)
+	return snap, nil
+}
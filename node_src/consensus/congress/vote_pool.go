@@ -0,0 +1,433 @@
+package congress
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	voteKeepDistance   = 256  // Number of blocks below the head for which votes are still accepted
+	voteFutureDistance = 16   // Number of blocks above the head for which votes are buffered
+	maxFutureVotes     = 1024 // Maximum number of votes buffered for blocks not yet imported
+
+	chainHeadChanSize = 10
+)
+
+var (
+	// errVoteTooOld is returned if a vote attests a block too far below the head.
+	errVoteTooOld = errors.New("vote too old")
+
+	// errVoteTooFar is returned if a vote attests a block too far above the head.
+	errVoteTooFar = errors.New("vote too far in the future")
+
+	// ErrVoteInvalidSignature is returned if the signature of a vote is malformed.
+	ErrVoteInvalidSignature = errors.New("invalid vote signature")
+
+	// ErrVoteUnauthorized is returned if a vote is signed by a non-validator.
+	ErrVoteUnauthorized = errors.New("vote signed by unauthorized validator")
+
+	// errVoteUnknownSigner is returned if a vote for a block not yet imported is
+	// signed by an account outside the validator set of the head, which happens
+	// around the epoch checkpoints changing the set.
+	errVoteUnknownSigner = errors.New("vote signed by unknown validator")
+
+	// errVoteFutureFull is returned if a vote for a block not yet imported can't
+	// be buffered.
+	errVoteFutureFull = errors.New("future vote buffer full")
+)
+
+// VoteChain defines the blockchain methods needed by the vote pool to verify
+// votes and to advance the safe and finalized blocks.
+type VoteChain interface {
+	consensus.ChainReader
+
+	CurrentBlock() *types.Block
+	CurrentSafeBlock() *types.Block
+	CurrentFinalizedBlock() *types.Block
+	SetSafe(block *types.Block)
+	SetFinalized(block *types.Block)
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+}
+
+// futureVote is a vote buffered until the block it attests is imported.
+type futureVote struct {
+	vote   *types.Vote
+	signer common.Address
+}
+
+// futureSlot identifies the height a validator voted for ahead of the chain. A
+// validator votes once per height, so a single vote is buffered per slot.
+type futureSlot struct {
+	signer common.Address
+	number uint64
+}
+
+// blockVotes is the set of votes collected for a single block.
+type blockVotes struct {
+	number    uint64
+	votes     map[common.Address]*types.Vote
+	justified bool
+}
+
+// VotePool collects the votes of the validators for recent blocks. A block voted
+// by more than 2/3 of the validators is justified and becomes the safe block, a
+// justified block whose direct child is justified too becomes finalized.
+//
+// If the engine is authorized and voting is enabled, the pool also votes for
+// every new chain head on behalf of the local validator, at most once per height.
+type VotePool struct {
+	chain  VoteChain
+	engine *Congress
+
+	blocks map[common.Hash]*blockVotes // Votes collected per attested block
+	known  map[common.Hash]uint64      // Hashes of the accepted votes, mapped to the attested number
+	future map[common.Hash]*futureVote // Votes for blocks not yet imported
+	slots  map[futureSlot]common.Hash  // Buffered future votes per validator and height
+	signed uint64                      // Highest block number the local validator voted for
+	voting func() bool                 // Whether the local validator may vote, nil if it may not
+	lock   sync.Mutex
+
+	voteFeed event.Feed
+	scope    event.SubscriptionScope
+
+	chainHeadCh  chan core.ChainHeadEvent
+	chainHeadSub event.Subscription
+	quit         chan struct{}
+	wg           sync.WaitGroup
+}
+
+// NewVotePool creates a vote pool tracking the given chain and starts its
+// event loop.
+func NewVotePool(chain VoteChain, engine *Congress) *VotePool {
+	pool := &VotePool{
+		chain:       chain,
+		engine:      engine,
+		blocks:      make(map[common.Hash]*blockVotes),
+		known:       make(map[common.Hash]uint64),
+		future:      make(map[common.Hash]*futureVote),
+		slots:       make(map[futureSlot]common.Hash),
+		chainHeadCh: make(chan core.ChainHeadEvent, chainHeadChanSize),
+		quit:        make(chan struct{}),
+	}
+	pool.chainHeadSub = chain.SubscribeChainHeadEvent(pool.chainHeadCh)
+
+	pool.wg.Add(1)
+	go pool.loop()
+	return pool
+}
+
+// Stop terminates the vote pool.
+func (pool *VotePool) Stop() {
+	pool.scope.Close()
+	pool.chainHeadSub.Unsubscribe()
+	close(pool.quit)
+	pool.wg.Wait()
+	log.Info("Vote pool stopped")
+}
+
+// SetVoting sets the function reporting whether the local validator may vote
+// for the new chain heads, typically while the node is synced and sealing. The
+// pool doesn't vote until it's set.
+func (pool *VotePool) SetVoting(fn func() bool) {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	pool.voting = fn
+}
+
+// SubscribeNewVoteEvent registers a subscription of NewVoteEvent, fired for every
+// vote newly accepted into the pool.
+func (pool *VotePool) SubscribeNewVoteEvent(ch chan<- core.NewVoteEvent) event.Subscription {
+	return pool.scope.Track(pool.voteFeed.Subscribe(ch))
+}
+
+// loop reacts to chain head changes, voting for the new head if authorized and
+// pruning stale votes.
+func (pool *VotePool) loop() {
+	defer pool.wg.Done()
+
+	for {
+		select {
+		case ev := <-pool.chainHeadCh:
+			if ev.Block == nil {
+				continue
+			}
+			pool.lock.Lock()
+			pool.reset(ev.Block)
+			pool.lock.Unlock()
+
+			pool.vote(ev.Block.Header())
+
+		case <-pool.chainHeadSub.Err():
+			return
+		case <-pool.quit:
+			return
+		}
+	}
+}
+
+// reset prunes the votes falling out of the acceptance window, retries the
+// buffered future votes and re-evaluates finality after a head change.
+func (pool *VotePool) reset(head *types.Block) {
+	number := head.NumberU64()
+	if number > voteKeepDistance {
+		for hash, votes := range pool.blocks {
+			if votes.number+voteKeepDistance < number {
+				delete(pool.blocks, hash)
+			}
+		}
+		for hash, voted := range pool.known {
+			if voted+voteKeepDistance < number {
+				delete(pool.known, hash)
+			}
+		}
+	}
+	for hash, future := range pool.future {
+		vote := future.vote
+		if vote.Data.Number+voteKeepDistance < number {
+			pool.dropFuture(hash)
+			continue
+		}
+		if pool.chain.GetHeader(vote.Data.Hash, vote.Data.Number) != nil {
+			pool.dropFuture(hash)
+			if err := pool.add(vote); err != nil {
+				log.Debug("Discarded buffered vote", "number", vote.Data.Number, "hash", vote.Data.Hash, "err", err)
+			}
+		}
+	}
+	// Justified blocks may have become canonical after a reorg
+	for hash, votes := range pool.blocks {
+		if votes.justified {
+			pool.markJustified(hash, votes.number)
+		}
+	}
+}
+
+// dropFuture removes a buffered future vote. The caller must hold the lock.
+func (pool *VotePool) dropFuture(hash common.Hash) {
+	if future, ok := pool.future[hash]; ok {
+		delete(pool.slots, futureSlot{signer: future.signer, number: future.vote.Data.Number})
+		delete(pool.future, hash)
+	}
+}
+
+// vote signs and adds a vote for the given head if voting is enabled, the local
+// validator is authorized for it and has not voted at this height yet. Heads at
+// or below the safe block, such as the ones left by a rewind, aren't voted for.
+func (pool *VotePool) vote(header *types.Header) {
+	pool.engine.lock.RLock()
+	val, signFn := pool.engine.validator, pool.engine.signFn
+	pool.engine.lock.RUnlock()
+
+	if val == (common.Address{}) {
+		return
+	}
+	number := header.Number.Uint64()
+
+	pool.lock.Lock()
+	if pool.voting == nil || !pool.voting() || number <= pool.signed {
+		pool.lock.Unlock()
+		return
+	}
+	pool.lock.Unlock()
+
+	if safe := pool.chain.CurrentSafeBlock(); safe != nil && number <= safe.NumberU64() {
+		return
+	}
+
+	snap, err := pool.engine.snapshot(pool.chain, number, header.Hash(), nil)
+	if err != nil {
+		log.Warn("Failed to retrieve snapshot for voting", "number", number, "err", err)
+		return
+	}
+	if _, authorized := snap.Validators[val]; !authorized {
+		return
+	}
+	signer, signFn, err := pool.engine.signerOf(snap, val, signFn)
+	if err != nil {
+		log.Warn("Failed to sign vote", "number", number, "err", err)
+		return
+	}
+	data := types.VoteData{Number: number, Hash: header.Hash()}
+	blob, err := rlp.EncodeToBytes(&data)
+	if err != nil {
+		log.Error("Failed to encode vote", "err", err)
+		return
+	}
+	sig, err := signFn(accounts.Account{Address: signer}, accounts.MimetypeCongressVote, blob)
+	if err != nil {
+		log.Warn("Failed to sign vote", "number", number, "err", err)
+		return
+	}
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	// Another head of the same height might have been voted for meanwhile
+	if number <= pool.signed {
+		return
+	}
+	pool.signed = number
+	if err := pool.add(&types.Vote{Data: data, Signature: sig}); err != nil {
+		log.Warn("Failed to add local vote", "number", number, "err", err)
+	}
+}
+
+// AddVote verifies a vote received from the network and adds it to the pool.
+func (pool *VotePool) AddVote(vote *types.Vote) error {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	return pool.add(vote)
+}
+
+// add verifies and stores a vote, advancing the safe and finalized blocks if a
+// supermajority has been reached. The caller must hold the lock.
+func (pool *VotePool) add(vote *types.Vote) error {
+	hash := vote.Hash()
+	if _, ok := pool.known[hash]; ok {
+		return nil
+	}
+	if _, ok := pool.future[hash]; ok {
+		return nil
+	}
+	number := vote.Data.Number
+	head := pool.chain.CurrentBlock().NumberU64()
+	if number+voteKeepDistance < head {
+		return errVoteTooOld
+	}
+	if number > head+voteFutureDistance {
+		return errVoteTooFar
+	}
+	if len(vote.Signature) != crypto.SignatureLength {
+		return ErrVoteInvalidSignature
+	}
+	pubkey, err := crypto.Ecrecover(vote.Data.SigHash().Bytes(), vote.Signature)
+	if err != nil {
+		return ErrVoteInvalidSignature
+	}
+	var key common.Address
+	copy(key[:], crypto.Keccak256(pubkey[1:])[12:])
+
+	// Buffer the vote until the attested block is imported, if signed by a
+	// validator of the head which has no other vote buffered at this height
+	if pool.chain.GetHeader(vote.Data.Hash, number) == nil {
+		current := pool.chain.CurrentHeader()
+		snap, err := pool.engine.snapshot(pool.chain, current.Number.Uint64(), current.Hash(), nil)
+		if err != nil {
+			return err
+		}
+		signer, ok := snap.validatorOf(key)
+		if !ok {
+			return errVoteUnknownSigner
+		}
+		slot := futureSlot{signer: signer, number: number}
+		if _, ok := pool.slots[slot]; ok || len(pool.future) >= maxFutureVotes {
+			return errVoteFutureFull
+		}
+		pool.future[hash] = &futureVote{vote: vote, signer: signer}
+		pool.slots[slot] = hash
+		return nil
+	}
+	snap, err := pool.engine.snapshot(pool.chain, number, vote.Data.Hash, nil)
+	if err != nil {
+		return err
+	}
+	// Votes are signed by the consensus keys of the validators, if any
+	signer, ok := snap.validatorOf(key)
+	if !ok {
+		return ErrVoteUnauthorized
+	}
+	votes := pool.blocks[vote.Data.Hash]
+	if votes == nil {
+		votes = &blockVotes{number: number, votes: make(map[common.Address]*types.Vote)}
+		pool.blocks[vote.Data.Hash] = votes
+	}
+	if _, ok := votes.votes[signer]; ok {
+		return nil
+	}
+	votes.votes[signer] = vote
+	pool.known[hash] = number
+	pool.voteFeed.Send(core.NewVoteEvent{Vote: vote})
+
+	if !votes.justified && len(votes.votes)*3 > len(snap.Validators)*2 {
+		votes.justified = true
+		log.Debug("Block justified by votes", "number", number, "hash", vote.Data.Hash, "votes", len(votes.votes))
+		pool.markJustified(vote.Data.Hash, number)
+	}
+	return nil
+}
+
+// markJustified advances the safe block to the given justified block, and the
+// finalized block to it or its parent if they form a chain of two consecutive
+// justified blocks. Only blocks on the canonical chain are considered.
+func (pool *VotePool) markJustified(hash common.Hash, number uint64) {
+	if !pool.isCanonical(hash, number) {
+		return
+	}
+	if safe := pool.chain.CurrentSafeBlock(); safe == nil || safe.NumberU64() < number {
+		if block := pool.chain.GetBlock(hash, number); block != nil {
+			pool.chain.SetSafe(block)
+		}
+	}
+	// Justified parent: finalize the parent
+	if number > 0 {
+		parent := pool.chain.GetHeaderByNumber(number - 1)
+		if parent != nil && pool.isJustified(parent.Hash()) {
+			pool.finalize(parent.Hash(), number-1)
+		}
+	}
+	// Justified child: finalize this block
+	if child := pool.chain.GetHeaderByNumber(number + 1); child != nil && child.ParentHash == hash && pool.isJustified(child.Hash()) {
+		pool.finalize(hash, number)
+	}
+}
+
+// finalize marks the given canonical block as finalized if it is above the
+// current finalized block.
+func (pool *VotePool) finalize(hash common.Hash, number uint64) {
+	if finalized := pool.chain.CurrentFinalizedBlock(); finalized != nil && finalized.NumberU64() >= number {
+		return
+	}
+	block := pool.chain.GetBlock(hash, number)
+	if block == nil {
+		return
+	}
+	pool.chain.SetFinalized(block)
+	log.Info("Finalized block by votes", "number", number, "hash", hash)
+}
+
+func (pool *VotePool) isJustified(hash common.Hash) bool {
+	votes, ok := pool.blocks[hash]
+	return ok && votes.justified
+}
+
+func (pool *VotePool) isCanonical(hash common.Hash, number uint64) bool {
+	header := pool.chain.GetHeaderByNumber(number)
+	return header != nil && header.Hash() == hash
+}
+
+// Votes returns the votes collected for the given block.
+func (pool *VotePool) Votes(hash common.Hash) []*types.Vote {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	votes, ok := pool.blocks[hash]
+	if !ok {
+		return nil
+	}
+	list := make([]*types.Vote, 0, len(votes.votes))
+	for _, vote := range votes.votes {
+		list = append(list, vote)
+	}
+	return list
+}
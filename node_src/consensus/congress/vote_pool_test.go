@@ -0,0 +1,266 @@
+package congress
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// testVoteChain is a minimal canonical chain implementing VoteChain.
+type testVoteChain struct {
+	config    *params.ChainConfig
+	blocks    []*types.Block
+	safe      *types.Block
+	finalized *types.Block
+	headFeed  event.Feed
+}
+
+func newTestVoteChain(config *params.ChainConfig, n int) *testVoteChain {
+	chain := &testVoteChain{config: config}
+	parent := common.Hash{}
+	for i := 0; i <= n; i++ {
+		header := &types.Header{
+			ParentHash: parent,
+			Number:     big.NewInt(int64(i)),
+			Difficulty: big.NewInt(2),
+			Extra:      make([]byte, extraVanity+extraSeal),
+		}
+		block := types.NewBlockWithHeader(header)
+		chain.blocks = append(chain.blocks, block)
+		parent = block.Hash()
+	}
+	return chain
+}
+
+func (c *testVoteChain) Config() *params.ChainConfig    { return c.config }
+func (c *testVoteChain) CurrentHeader() *types.Header   { return c.CurrentBlock().Header() }
+func (c *testVoteChain) CurrentBlock() *types.Block     { return c.blocks[len(c.blocks)-1] }
+func (c *testVoteChain) CurrentSafeBlock() *types.Block { return c.safe }
+func (c *testVoteChain) CurrentFinalizedBlock() *types.Block {
+	return c.finalized
+}
+func (c *testVoteChain) SetSafe(block *types.Block)      { c.safe = block }
+func (c *testVoteChain) SetFinalized(block *types.Block) { c.finalized = block }
+
+func (c *testVoteChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	if number < uint64(len(c.blocks)) && c.blocks[number].Hash() == hash {
+		return c.blocks[number]
+	}
+	return nil
+}
+
+func (c *testVoteChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if block := c.GetBlock(hash, number); block != nil {
+		return block.Header()
+	}
+	return nil
+}
+
+func (c *testVoteChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number < uint64(len(c.blocks)) {
+		return c.blocks[number].Header()
+	}
+	return nil
+}
+
+func (c *testVoteChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	for _, block := range c.blocks {
+		if block.Hash() == hash {
+			return block.Header()
+		}
+	}
+	return nil
+}
+
+func (c *testVoteChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
+	return c.headFeed.Subscribe(ch)
+}
+
+func signTestVote(t *testing.T, key *ecdsa.PrivateKey, block *types.Block) *types.Vote {
+	data := types.VoteData{Number: block.NumberU64(), Hash: block.Hash()}
+	sig, err := crypto.Sign(data.SigHash().Bytes(), key)
+	if err != nil {
+		t.Fatalf("failed to sign vote: %v", err)
+	}
+	return &types.Vote{Data: data, Signature: sig}
+}
+
+func TestVotePoolFinality(t *testing.T) {
+	config := &params.ChainConfig{ChainID: big.NewInt(1), Congress: &params.CongressConfig{Period: 3, Epoch: 200}}
+	engine := New(config, rawdb.NewMemoryDatabase())
+	chain := newTestVoteChain(config, 4)
+
+	keys := make([]*ecdsa.PrivateKey, 4)
+	validators := make([]common.Address, len(keys))
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		validators[i] = crypto.PubkeyToAddress(keys[i].PublicKey)
+	}
+	for _, block := range chain.blocks {
+		engine.recents.Add(block.Hash(), newSnapshot(engine.config, engine.signatures, block.NumberU64(), block.Hash(), validators))
+	}
+	pool := NewVotePool(chain, engine)
+	defer pool.Stop()
+
+	// Votes of non-validators and malformed votes are rejected
+	outsider, _ := crypto.GenerateKey()
+	if err := pool.AddVote(signTestVote(t, outsider, chain.blocks[1])); err != ErrVoteUnauthorized {
+		t.Fatalf("outsider vote error mismatch: have %v, want %v", err, ErrVoteUnauthorized)
+	}
+	if err := pool.AddVote(&types.Vote{Data: types.VoteData{Number: 1, Hash: chain.blocks[1].Hash()}}); err != ErrVoteInvalidSignature {
+		t.Fatalf("unsigned vote error mismatch: have %v, want %v", err, ErrVoteInvalidSignature)
+	}
+	// Two out of four votes, including a duplicate, are no supermajority
+	for _, key := range []*ecdsa.PrivateKey{keys[0], keys[1], keys[1]} {
+		if err := pool.AddVote(signTestVote(t, key, chain.blocks[1])); err != nil {
+			t.Fatalf("failed to add vote: %v", err)
+		}
+	}
+	if chain.safe != nil {
+		t.Fatalf("block justified without supermajority")
+	}
+	// The third vote justifies block 1
+	if err := pool.AddVote(signTestVote(t, keys[2], chain.blocks[1])); err != nil {
+		t.Fatalf("failed to add vote: %v", err)
+	}
+	if chain.safe == nil || chain.safe.NumberU64() != 1 {
+		t.Fatalf("safe block mismatch: have %v, want 1", chain.safe)
+	}
+	if chain.finalized != nil {
+		t.Fatalf("block finalized without justified child")
+	}
+	if have := len(pool.Votes(chain.blocks[1].Hash())); have != 3 {
+		t.Fatalf("vote count mismatch: have %d, want 3", have)
+	}
+	// Justifying block 2 finalizes block 1
+	for _, key := range keys[1:] {
+		if err := pool.AddVote(signTestVote(t, key, chain.blocks[2])); err != nil {
+			t.Fatalf("failed to add vote: %v", err)
+		}
+	}
+	if chain.safe.NumberU64() != 2 {
+		t.Fatalf("safe block mismatch: have %d, want 2", chain.safe.NumberU64())
+	}
+	if chain.finalized == nil || chain.finalized.Hash() != chain.blocks[1].Hash() {
+		t.Fatalf("finalized block mismatch: have %v, want 1", chain.finalized)
+	}
+	// Votes arriving out of order finalize the parent too
+	for _, key := range keys[:3] {
+		if err := pool.AddVote(signTestVote(t, key, chain.blocks[4])); err != nil {
+			t.Fatalf("failed to add vote: %v", err)
+		}
+	}
+	for _, key := range keys[:3] {
+		if err := pool.AddVote(signTestVote(t, key, chain.blocks[3])); err != nil {
+			t.Fatalf("failed to add vote: %v", err)
+		}
+	}
+	if chain.finalized.NumberU64() != 3 {
+		t.Fatalf("finalized block mismatch: have %d, want 3", chain.finalized.NumberU64())
+	}
+	if chain.safe.NumberU64() != 4 {
+		t.Fatalf("safe block mismatch: have %d, want 4", chain.safe.NumberU64())
+	}
+}
+
+func TestVotePoolFutureVotes(t *testing.T) {
+	config := &params.ChainConfig{ChainID: big.NewInt(1), Congress: &params.CongressConfig{Period: 3, Epoch: 200}}
+	engine := New(config, rawdb.NewMemoryDatabase())
+	chain := newTestVoteChain(config, 3)
+
+	keys := make([]*ecdsa.PrivateKey, 4)
+	validators := make([]common.Address, len(keys))
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		validators[i] = crypto.PubkeyToAddress(keys[i].PublicKey)
+	}
+	for _, block := range chain.blocks {
+		engine.recents.Add(block.Hash(), newSnapshot(engine.config, engine.signatures, block.NumberU64(), block.Hash(), validators))
+	}
+	// Hide the head, votes for it are buffered until it's imported
+	head := chain.blocks[3]
+	chain.blocks = chain.blocks[:3]
+
+	pool := NewVotePool(chain, engine)
+	defer pool.Stop()
+
+	outsider, _ := crypto.GenerateKey()
+	if err := pool.AddVote(signTestVote(t, outsider, head)); err != errVoteUnknownSigner {
+		t.Fatalf("outsider vote error mismatch: have %v, want %v", err, errVoteUnknownSigner)
+	}
+	if err := pool.AddVote(&types.Vote{Data: types.VoteData{Number: 3, Hash: head.Hash()}, Signature: make([]byte, 65)}); err != ErrVoteInvalidSignature {
+		t.Fatalf("malformed vote error mismatch: have %v, want %v", err, ErrVoteInvalidSignature)
+	}
+	for _, key := range keys[:3] {
+		if err := pool.AddVote(signTestVote(t, key, head)); err != nil {
+			t.Fatalf("failed to buffer vote: %v", err)
+		}
+	}
+	// A single vote is buffered per validator and height
+	other := types.NewBlockWithHeader(&types.Header{ParentHash: head.ParentHash(), Number: big.NewInt(3), Extra: []byte{1}})
+	if err := pool.AddVote(signTestVote(t, keys[0], other)); err != errVoteFutureFull {
+		t.Fatalf("second vote error mismatch: have %v, want %v", err, errVoteFutureFull)
+	}
+	if have := len(pool.future); have != 3 {
+		t.Fatalf("buffered vote count mismatch: have %d, want 3", have)
+	}
+	// Importing the block justifies it with the buffered votes
+	chain.blocks = append(chain.blocks, head)
+	pool.lock.Lock()
+	pool.reset(head)
+	pool.lock.Unlock()
+
+	if len(pool.future) != 0 || len(pool.slots) != 0 {
+		t.Fatalf("buffered votes left: %d votes, %d slots", len(pool.future), len(pool.slots))
+	}
+	if chain.safe == nil || chain.safe.Hash() != head.Hash() {
+		t.Fatalf("safe block mismatch: have %v, want 3", chain.safe)
+	}
+}
+
+func TestVotePoolLocalVote(t *testing.T) {
+	config := &params.ChainConfig{ChainID: big.NewInt(1), Congress: &params.CongressConfig{Period: 3, Epoch: 200}}
+	engine := New(config, rawdb.NewMemoryDatabase())
+	chain := newTestVoteChain(config, 3)
+
+	key, _ := crypto.GenerateKey()
+	validator := crypto.PubkeyToAddress(key.PublicKey)
+	for _, block := range chain.blocks {
+		engine.recents.Add(block.Hash(), newSnapshot(engine.config, engine.signatures, block.NumberU64(), block.Hash(), []common.Address{validator}))
+	}
+	engine.Authorize(validator, func(account accounts.Account, mimeType string, message []byte) ([]byte, error) {
+		return crypto.Sign(crypto.Keccak256(message), key)
+	}, nil)
+
+	pool := NewVotePool(chain, engine)
+	defer pool.Stop()
+
+	// Nothing is voted for until voting is enabled
+	synced := false
+	pool.vote(chain.blocks[1].Header())
+	pool.SetVoting(func() bool { return synced })
+	pool.vote(chain.blocks[1].Header())
+	if have := len(pool.Votes(chain.blocks[1].Hash())); have != 0 {
+		t.Fatalf("vote count before sync mismatch: have %d, want 0", have)
+	}
+	// Heads at or below the safe block are skipped
+	synced = true
+	chain.safe = chain.blocks[2]
+	pool.vote(chain.blocks[2].Header())
+	if have := len(pool.Votes(chain.blocks[2].Hash())); have != 0 {
+		t.Fatalf("vote count below safe block mismatch: have %d, want 0", have)
+	}
+	pool.vote(chain.blocks[3].Header())
+	if have := len(pool.Votes(chain.blocks[3].Hash())); have != 1 {
+		t.Fatalf("vote count mismatch: have %d, want 1", have)
+	}
+}
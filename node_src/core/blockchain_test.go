@@ -384,6 +384,43 @@ func testReorgShort(t *testing.T, full bool) {
 	testReorg(t, easy, diff, 12615120+params.GenesisDifficulty.Int64(), full)
 }
 
+// Tests that a heavier side chain forking off below the finalized block is not
+// accepted as the new canonical chain.
+func TestReorgBelowFinalized(t *testing.T) {
+	db, blockchain, err := newCanonical(ethash.NewFaker(), 0, true)
+	if err != nil {
+		t.Fatalf("failed to create pristine chain: %v", err)
+	}
+	defer blockchain.Stop()
+
+	canon, _ := GenerateChain(params.TestChainConfig, blockchain.CurrentBlock(), ethash.NewFaker(), db, 5, func(i int, b *BlockGen) {})
+	if _, err := blockchain.InsertChain(canon); err != nil {
+		t.Fatalf("failed to insert canonical chain: %v", err)
+	}
+	blockchain.SetFinalized(canon[2])
+
+	fork, _ := GenerateChain(params.TestChainConfig, blockchain.Genesis(), ethash.NewFaker(), db, 8, func(i int, b *BlockGen) {
+		b.SetCoinbase(common.Address{1})
+	})
+	if _, err := blockchain.InsertChain(fork); err != nil {
+		t.Fatalf("failed to insert side chain: %v", err)
+	}
+	if head := blockchain.CurrentBlock(); head.Hash() != canon[4].Hash() {
+		t.Fatalf("head mismatch: have #%d [%x], want #%d [%x]", head.NumberU64(), head.Hash(), canon[4].NumberU64(), canon[4].Hash())
+	}
+	if finalized := blockchain.CurrentFinalizedBlock(); finalized.Hash() != canon[2].Hash() {
+		t.Fatalf("finalized mismatch: have %x, want %x", finalized.Hash(), canon[2].Hash())
+	}
+	// Extending the canonical chain must still work
+	more, _ := GenerateChain(params.TestChainConfig, canon[4], ethash.NewFaker(), db, 1, func(i int, b *BlockGen) {})
+	if _, err := blockchain.InsertChain(more); err != nil {
+		t.Fatalf("failed to extend canonical chain: %v", err)
+	}
+	if head := blockchain.CurrentBlock(); head.Hash() != more[0].Hash() {
+		t.Fatalf("head mismatch: have %x, want %x", head.Hash(), more[0].Hash())
+	}
+}
+
 func testReorg(t *testing.T, first, second []int64, td int64, full bool) {
 	// Create a pristine chain and database
 	db, blockchain, err := newCanonical(ethash.NewFaker(), 0, full)
@@ -72,6 +72,10 @@ var (
 	// is higher than the balance of the meta fee address's account.
 	ErrInsufficientMetaFunds = errors.New("meta address insufficient funds for gas * price + value")
 
+	// ErrMaxInitCodeSizeExceeded is returned if creation transaction provides the init code bigger
+	// than init code size limit.
+	ErrMaxInitCodeSizeExceeded = errors.New("max initcode size exceeded")
+
 	// ErrGasUintOverflow is returned when calculating gas usage.
 	ErrGasUintOverflow = errors.New("gas uint64 overflow")
 
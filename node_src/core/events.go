@@ -25,9 +25,16 @@ import (
 // NewTxsEvent is posted when a batch of transactions enter the transaction pool.
 type NewTxsEvent struct{ Txs []*types.Transaction }
 
+// DeniedTxsEvent is posted when transactions are evicted from the transaction
+// pool because their sender or recipient got blacklisted.
+type DeniedTxsEvent struct{ Txs []*types.Transaction }
+
 // NewMinedBlockEvent is posted when a block has been imported.
 type NewMinedBlockEvent struct{ Block *types.Block }
 
+// NewVoteEvent is posted when a vote enters the vote pool.
+type NewVoteEvent struct{ Vote *types.Vote }
+
 // RemovedLogsEvent is posted when a reorg happens
 type RemovedLogsEvent struct{ Logs []*types.Log }
 
@@ -20,6 +20,7 @@ package forkid
 import (
 	"bytes"
 	"math"
+	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -184,3 +185,25 @@ func TestEncoding(t *testing.T) {
 		}
 	}
 }
+
+// Tests that the Cancun fork block is part of the fork identifier.
+func TestCancunFork(t *testing.T) {
+	config := &params.ChainConfig{
+		ChainID:        big.NewInt(1),
+		HomesteadBlock: big.NewInt(0),
+		BerlinBlock:    big.NewInt(0),
+		LondonBlock:    big.NewInt(1_000),
+		CancunBlock:    big.NewInt(2_000),
+	}
+	if forks := gatherForks(config); len(forks) != 2 || forks[1] != 2_000 {
+		t.Fatalf("cancun fork not gathered: %v", forks)
+	}
+	genesis := common.HexToHash("0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3")
+	before, after := NewID(config, genesis, 1_999), NewID(config, genesis, 2_000)
+	if before.Next != 2_000 {
+		t.Errorf("next fork mismatch: have %d, want %d", before.Next, 2_000)
+	}
+	if before.Hash == after.Hash || after.Next != 0 {
+		t.Errorf("fork checksum not updated: before %v, after %v", before, after)
+	}
+}
@@ -145,6 +145,15 @@ type (
 		prevcode, prevhash []byte
 		prevroot           common.Hash
 	}
+
+	// Changes to the transaction scoped state (EIP-1153, EIP-6780)
+	transientStorageChange struct {
+		account       *common.Address
+		key, prevalue common.Hash
+	}
+	createContractChange struct {
+		account *common.Address
+	}
 )
 
 func (ch createObjectChange) revert(s *StateDB) {
@@ -283,3 +292,19 @@ func (ch eraseChange) revert(s *StateDB) {
 func (ch eraseChange) dirtied() *common.Address {
 	return ch.account
 }
+
+func (ch transientStorageChange) revert(s *StateDB) {
+	s.setTransientState(*ch.account, ch.key, ch.prevalue)
+}
+
+func (ch transientStorageChange) dirtied() *common.Address {
+	return nil
+}
+
+func (ch createContractChange) revert(s *StateDB) {
+	delete(s.newContracts, *ch.account)
+}
+
+func (ch createContractChange) dirtied() *common.Address {
+	return nil
+}
@@ -103,6 +103,11 @@ type StateDB struct {
 	// Per-transaction access list
 	accessList *accessList
 
+	// Per-transaction transient storage (EIP-1153) and contracts created by
+	// the transaction (EIP-6780)
+	transientStorage transientStorage
+	newContracts     map[common.Address]struct{}
+
 	// Journal of state modifications. This is the backbone of
 	// Snapshot and RevertToSnapshot.
 	journal        *journal
@@ -146,6 +151,8 @@ func New(root common.Hash, db Database, snaps *snapshot.Tree) (*StateDB, error)
 		preimages:           make(map[common.Hash][]byte),
 		journal:             newJournal(),
 		accessList:          newAccessList(),
+		transientStorage:    newTransientStorage(),
+		newContracts:        make(map[common.Address]struct{}),
 		hasher:              crypto.NewKeccakState(),
 	}
 	if sdb.snaps != nil {
@@ -447,6 +454,50 @@ func (s *StateDB) SetStorage(addr common.Address, storage map[common.Hash]common
 	}
 }
 
+// SetTransientState sets the transient storage of an account (EIP-1153).
+func (s *StateDB) SetTransientState(addr common.Address, key, value common.Hash) {
+	prev := s.GetTransientState(addr, key)
+	if prev == value {
+		return
+	}
+	s.journal.append(transientStorageChange{
+		account:  &addr,
+		key:      key,
+		prevalue: prev,
+	})
+	s.setTransientState(addr, key, value)
+}
+
+// setTransientState is a lower level setter for transient storage. It is
+// called during a revert to prevent modifications to the journal.
+func (s *StateDB) setTransientState(addr common.Address, key, value common.Hash) {
+	s.transientStorage.Set(addr, key, value)
+}
+
+// GetTransientState gets the transient storage of an account (EIP-1153).
+func (s *StateDB) GetTransientState(addr common.Address, key common.Hash) common.Hash {
+	return s.transientStorage.Get(addr, key)
+}
+
+// CreateContract marks the given account as a contract created by the current
+// transaction, which may still be destroyed by SELFDESTRUCT after EIP-6780.
+func (s *StateDB) CreateContract(addr common.Address) {
+	if _, ok := s.newContracts[addr]; ok {
+		return
+	}
+	s.journal.append(createContractChange{account: &addr})
+	s.newContracts[addr] = struct{}{}
+}
+
+// Suicide6780 marks the given account as suicided only if it was created by
+// the current transaction (EIP-6780). Its balance is expected to have been
+// transferred already.
+func (s *StateDB) Suicide6780(addr common.Address) {
+	if _, ok := s.newContracts[addr]; ok {
+		s.Suicide(addr)
+	}
+}
+
 // Suicide marks the given account as suicided.
 // This clears the account balance.
 //
@@ -834,6 +885,11 @@ func (s *StateDB) Copy() *StateDB {
 	// However, it doesn't cost us much to copy an empty list, so we do it anyway
 	// to not blow up if we ever decide copy it in the middle of a transaction
 	state.accessList = s.accessList.Copy()
+	state.transientStorage = s.transientStorage.Copy()
+	state.newContracts = make(map[common.Address]struct{}, len(s.newContracts))
+	for addr := range s.newContracts {
+		state.newContracts[addr] = struct{}{}
+	}
 
 	// If there's a prefetcher running, make an inactive copy of it that can
 	// only access data but does not actively preload (since the user will not
@@ -940,6 +996,9 @@ func (s *StateDB) Finalise(deleteEmptyObjects bool) {
 	if s.prefetcher != nil && len(addressesToPrefetch) > 0 {
 		s.prefetcher.prefetch(s.originalRoot, addressesToPrefetch)
 	}
+	// The contracts created by the transaction are no longer new to the next one
+	s.newContracts = make(map[common.Address]struct{})
+
 	// Invalidate journal because reverting across transactions is not allowed.
 	s.clearJournalAndRefund()
 }
@@ -1025,11 +1084,13 @@ func (s *StateDB) IntermediateRoot(deleteEmptyObjects bool) common.Hash {
 }
 
 // Prepare sets the current transaction hash and index which are
-// used when the EVM emits new state logs.
+// used when the EVM emits new state logs. The access list and the transient
+// storage of the previous transaction are discarded.
 func (s *StateDB) Prepare(thash common.Hash, ti int) {
 	s.thash = thash
 	s.txIndex = ti
 	s.accessList = newAccessList()
+	s.transientStorage = newTransientStorage()
 }
 
 func (s *StateDB) clearJournalAndRefund() {
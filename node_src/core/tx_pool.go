@@ -19,6 +19,7 @@ package core
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"math/big"
 	"sort"
@@ -99,6 +100,7 @@ var (
 	pendingReplaceMeter   = metrics.NewRegisteredMeter("txpool/pending/replace", nil)
 	pendingRateLimitMeter = metrics.NewRegisteredMeter("txpool/pending/ratelimit", nil) // Dropped due to rate limiting
 	pendingNofundsMeter   = metrics.NewRegisteredMeter("txpool/pending/nofunds", nil)   // Dropped due to out-of-funds
+	pendingDeniedMeter    = metrics.NewRegisteredMeter("txpool/pending/denied", nil)    // Dropped due to the blacklist
 
 	// Metrics for the queued pool
 	queuedDiscardMeter   = metrics.NewRegisteredMeter("txpool/queued/discard", nil)
@@ -106,6 +108,7 @@ var (
 	queuedRateLimitMeter = metrics.NewRegisteredMeter("txpool/queued/ratelimit", nil) // Dropped due to rate limiting
 	queuedNofundsMeter   = metrics.NewRegisteredMeter("txpool/queued/nofunds", nil)   // Dropped due to out-of-funds
 	queuedEvictionMeter  = metrics.NewRegisteredMeter("txpool/queued/eviction", nil)  // Dropped due to lifetime
+	queuedDeniedMeter    = metrics.NewRegisteredMeter("txpool/queued/denied", nil)    // Dropped due to the blacklist
 
 	// General tx metrics
 	knownTxMeter       = metrics.NewRegisteredMeter("txpool/known", nil)
@@ -154,6 +157,15 @@ type exTxValidator interface {
 	ValidateTx(sender common.Address, tx *types.Transaction, header *types.Header, parentState *state.StateDB) error
 }
 
+// exTxBlacklist is implemented by extra validators denying transactions based on
+// a blacklist stored in the state, so that the pool can re-check its content
+// whenever the blacklist changes.
+type exTxBlacklist interface {
+	// BlacklistUpdatedNumber returns the number of the block the blacklist was
+	// last updated in, as seen in the given state.
+	BlacklistUpdatedNumber(state *state.StateDB) uint64
+}
+
 // TxPoolConfig are the configuration parameters of the transaction pool.
 type TxPoolConfig struct {
 	Locals    []common.Address // Addresses that should be treated by default as local
@@ -245,6 +257,7 @@ type TxPool struct {
 	chain       blockChain
 	gasPrice    *big.Int
 	txFeed      event.Feed
+	deniedFeed  event.Feed
 	scope       event.SubscriptionScope
 	signer      types.Signer
 	mu          sync.RWMutex
@@ -252,6 +265,7 @@ type TxPool struct {
 	istanbul bool // Fork indicator whether we are in the istanbul stage.
 	eip2718  bool // Fork indicator whether we are using EIP-2718 type transactions.
 	eip1559  bool // Fork indicator whether we are using EIP-1559 type transactions.
+	cancun   bool // Fork indicator whether we are in the cancun stage.
 
 	currentState  *state.StateDB // Current state in the blockchain head
 	pendingNonces *txNoncer      // Pending state tracking virtual nonces
@@ -275,6 +289,7 @@ type TxPool struct {
 	// during a large chain insertion, the ChainHeadEvent will not be fired in time, then some old trie-nodes
 	// will be discarded due to GC, and it will cause failure to get blacklist.
 	disableExValidate bool
+	blacklistUpdated  uint64 // Block the blacklist was last updated in, as of the current state
 
 	chainHeadCh     chan ChainHeadEvent
 	chainHeadSub    event.Subscription
@@ -460,6 +475,12 @@ func (pool *TxPool) SubscribeNewTxsEvent(ch chan<- NewTxsEvent) event.Subscripti
 	return pool.scope.Track(pool.txFeed.Subscribe(ch))
 }
 
+// SubscribeDeniedTxsEvent registers a subscription of DeniedTxsEvent and
+// starts sending event to the given channel.
+func (pool *TxPool) SubscribeDeniedTxsEvent(ch chan<- DeniedTxsEvent) event.Subscription {
+	return pool.scope.Track(pool.deniedFeed.Subscribe(ch))
+}
+
 // GasPrice returns the current gas price enforced by the transaction pool.
 func (pool *TxPool) GasPrice() *big.Int {
 	pool.mu.RLock()
@@ -630,6 +651,10 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 	if uint64(tx.Size()) > txMaxSize {
 		return ErrOversizedData
 	}
+	// Check whether the init code size has been exceeded.
+	if pool.cancun && tx.To() == nil && len(tx.Data()) > params.MaxInitCodeSize {
+		return fmt.Errorf("%w: code size %v limit %v", ErrMaxInitCodeSizeExceeded, len(tx.Data()), params.MaxInitCodeSize)
+	}
 	// Transactions can't be negative. This may never happen using RLP decoded
 	// transactions but may occur if you create a transaction using the RPC.
 	if tx.Value().Sign() < 0 {
@@ -670,7 +695,7 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 		return ErrInsufficientFunds
 	}
 	// Ensure the transaction has more gas than the basic tx fee.
-	intrGas, err := IntrinsicGas(tx.Data(), tx.AccessList(), tx.To() == nil, true, pool.istanbul)
+	intrGas, err := IntrinsicGas(tx.Data(), tx.AccessList(), tx.To() == nil, true, pool.istanbul, pool.cancun)
 	if err != nil {
 		return err
 	}
@@ -1222,6 +1247,11 @@ func (pool *TxPool) runReorg(done chan struct{}, reset *txpoolResetRequest, dirt
 			promoteAddrs = append(promoteAddrs, addr)
 		}
 	}
+	// Drop the transactions denied by an updated blacklist before promotion
+	var denied []*types.Transaction
+	if reset != nil {
+		denied = pool.evictDenied()
+	}
 	// Check for pending transactions for every account that sent new ones
 	promoted := pool.promoteExecutables(promoteAddrs)
 
@@ -1265,6 +1295,9 @@ func (pool *TxPool) runReorg(done chan struct{}, reset *txpoolResetRequest, dirt
 		}
 		pool.txFeed.Send(NewTxsEvent{txs})
 	}
+	if len(denied) > 0 {
+		pool.deniedFeed.Send(DeniedTxsEvent{denied})
+	}
 }
 
 // reset retrieves the current state of the blockchain and ensures the content
@@ -1361,7 +1394,60 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 	pool.istanbul = pool.chainconfig.IsIstanbul(next)
 	pool.eip2718 = pool.chainconfig.IsBerlin(next)
 	pool.eip1559 = pool.chainconfig.IsLondon(next)
+	pool.cancun = pool.chainconfig.IsCancun(next)
+}
 
+// evictDenied re-validates the content of the pool with the extra validator if
+// the blacklist was updated since the last check, and drops the transactions
+// it denies. The dropped transactions are returned.
+func (pool *TxPool) evictDenied() []*types.Transaction {
+	blacklist, ok := pool.txValidator.(exTxBlacklist)
+	if !ok || pool.disableExValidate {
+		return nil
+	}
+	updated := blacklist.BlacklistUpdatedNumber(pool.currentState)
+	if updated == pool.blacklistUpdated {
+		return nil
+	}
+
+	collect := func(lists map[common.Address]*txList) ([]*types.Transaction, bool) {
+		var denied []*types.Transaction
+		for addr, list := range lists {
+			for _, tx := range list.Flatten() {
+				err := pool.txValidator.ValidateTx(addr, tx, pool.nextFakeHeader, pool.currentState)
+				if err == types.ErrAddressDenied {
+					denied = append(denied, tx)
+					continue
+				}
+				if err != nil {
+					log.Info("ValidateTx error", "err", err)
+					pool.disableExValidate = true
+					return nil, false
+				}
+			}
+		}
+		return denied, true
+	}
+	pending, ok := collect(pool.pending)
+	if !ok {
+		return nil
+	}
+	queued, ok := collect(pool.queue)
+	if !ok {
+		return nil
+	}
+	pool.blacklistUpdated = updated
+	denied := append(pending, queued...)
+	for _, tx := range denied {
+		pool.removeTx(tx.Hash(), true)
+	}
+	pendingDeniedMeter.Mark(int64(len(pending)))
+	queuedDeniedMeter.Mark(int64(len(queued)))
+
+	if len(denied) > 0 {
+		log.Info("Evicted blacklisted transactions", "count", len(denied), "updated", updated)
+	}
+	return denied
 }
 
 func (pool *TxPool) makeFakeHeader(currHead *types.Header) {
@@ -1094,6 +1094,81 @@ func testTransactionQueueTimeLimiting(t *testing.T, nolocals bool) {
 	}
 }
 
+// testBlacklist is an extra validator denying the transactions of blacklisted
+// senders.
+type testBlacklist struct {
+	denied  map[common.Address]bool
+	updated uint64
+}
+
+func (b *testBlacklist) ValidateTx(sender common.Address, tx *types.Transaction, header *types.Header, parentState *state.StateDB) error {
+	if b.denied[sender] {
+		return types.ErrAddressDenied
+	}
+	return nil
+}
+
+func (b *testBlacklist) BlacklistUpdatedNumber(state *state.StateDB) uint64 {
+	return b.updated
+}
+
+// Tests that the transactions of newly blacklisted accounts are evicted from
+// both the pending and the queued pools once the blacklist is updated.
+func TestTransactionBlacklistEviction(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	blacklist := &testBlacklist{denied: make(map[common.Address]bool)}
+	pool.InitExTxValidator(blacklist)
+
+	other, _ := crypto.GenerateKey()
+	account, otherAccount := crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(other.PublicKey)
+	testAddBalance(pool, account, big.NewInt(1000000))
+	testAddBalance(pool, otherAccount, big.NewInt(1000000))
+
+	events := make(chan DeniedTxsEvent, 1)
+	sub := pool.SubscribeDeniedTxsEvent(events)
+	defer sub.Unsubscribe()
+
+	txs := []*types.Transaction{transaction(0, 100000, key), transaction(1, 100000, key), transaction(3, 100000, key), transaction(0, 100000, other)}
+	for i, err := range pool.AddRemotesSync(txs) {
+		if err != nil {
+			t.Fatalf("tx %d: failed to add transaction: %v", i, err)
+		}
+	}
+	if pending, queued := pool.Stats(); pending != 3 || queued != 1 {
+		t.Fatalf("pool size mismatch: have %d/%d, want 3/1", pending, queued)
+	}
+	// Blacklisting without an update of the block number doesn't trigger a check
+	blacklist.denied[account] = true
+	<-pool.requestReset(nil, nil)
+	if pending, queued := pool.Stats(); pending != 3 || queued != 1 {
+		t.Fatalf("pool size mismatch before update: have %d/%d, want 3/1", pending, queued)
+	}
+	// Once the blacklist is updated, all the transactions of the account are dropped
+	blacklist.updated = 5
+	<-pool.requestReset(nil, nil)
+	if pending, queued := pool.Stats(); pending != 1 || queued != 0 {
+		t.Fatalf("pool size mismatch after update: have %d/%d, want 1/0", pending, queued)
+	}
+	if pool.Get(txs[3].Hash()) == nil {
+		t.Errorf("transaction of other account evicted")
+	}
+	select {
+	case ev := <-events:
+		if len(ev.Txs) != 3 {
+			t.Errorf("evicted transaction count mismatch: have %d, want 3", len(ev.Txs))
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("eviction event not fired")
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
 // Tests that even if the transaction count belonging to a single account goes
 // above some threshold, as long as the transactions are executable, they are
 // accepted.
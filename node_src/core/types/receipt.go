@@ -71,6 +71,17 @@ type Receipt struct {
 	BlockHash        common.Hash `json:"blockHash,omitempty"`
 	BlockNumber      *big.Int    `json:"blockNumber,omitempty"`
 	TransactionIndex uint        `json:"transactionIndex"`
+
+	// Execution information: These fields are only set on the receipts of freshly
+	// executed transactions, they are neither part of the consensus encoding nor stored.
+	CodeGas []CodeGas `json:"-"`
+}
+
+// CodeGas is the gas consumed by the code of a single account while executing
+// a transaction, excluding the gas consumed by the calls it made.
+type CodeGas struct {
+	Address common.Address
+	Gas     uint64
 }
 
 type receiptMarshaling struct {
@@ -0,0 +1,49 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+// Enhanced blockchain implementation by Circle Layer <https://circlelayer.com>
+
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// VoteData is the attestation content a validator signs for a recent block.
+type VoteData struct {
+	Number uint64      `json:"number"` // Number of the attested block
+	Hash   common.Hash `json:"hash"`   // Hash of the attested block
+}
+
+// SigHash returns the hash which is signed by the voting validator.
+func (d *VoteData) SigHash() common.Hash {
+	return rlpHash(d)
+}
+
+// Vote is a signed attestation of a validator for a recent block, gossiped
+// between nodes to reach fast finality.
+type Vote struct {
+	Data      VoteData      `json:"data"`
+	Signature hexutil.Bytes `json:"signature"` // 65 byte secp256k1 signature over Data.SigHash()
+}
+
+// Hash returns the unique identifier of the vote, covering the signature too.
+func (v *Vote) Hash() common.Hash {
+	return rlpHash(v)
+}
+
+// Votes is a slice of Vote.
+type Votes []*Vote
@@ -0,0 +1,68 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+// Enhanced blockchain implementation by Circle Layer <https://circlelayer.com>
+
+package vm
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// CodeGas returns the gas consumed by every code executed since the last reset,
+// in the order of their first execution. The gas consumed by a call is only
+// attributed to the callee, precompiles are attributed to their caller.
+func (evm *EVM) CodeGas() []types.CodeGas {
+	return evm.codeGas
+}
+
+// enterCode opens the gas accounting of a new call frame.
+func (evm *EVM) enterCode() {
+	evm.codeFrames = append(evm.codeFrames, 0)
+}
+
+// exitCode closes the gas accounting of the current call frame, attributing
+// the gas it consumed, minus the gas consumed by its calls, to the executed
+// code. Like the callers of the interpreter, any error but a revert consumes
+// all the gas of the frame.
+func (evm *EVM) exitCode(contract *Contract, startGas uint64, err error) {
+	used := startGas - contract.Gas
+	if err != nil && err != ErrExecutionReverted {
+		used = startGas
+	}
+	last := len(evm.codeFrames) - 1
+	calls := evm.codeFrames[last]
+	evm.codeFrames = evm.codeFrames[:last]
+	if last > 0 {
+		evm.codeFrames[last-1] += used
+	}
+	if calls >= used {
+		return
+	}
+	addr := contract.Address()
+	if contract.CodeAddr != nil {
+		addr = *contract.CodeAddr
+	}
+	if evm.codeIndex == nil {
+		evm.codeIndex = make(map[common.Address]int)
+	}
+	if i, ok := evm.codeIndex[addr]; ok {
+		evm.codeGas[i].Gas += used - calls
+		return
+	}
+	evm.codeIndex[addr] = len(evm.codeGas)
+	evm.codeGas = append(evm.codeGas, types.CodeGas{Address: addr, Gas: used - calls})
+}
@@ -128,6 +128,12 @@ type EVM struct {
 	// available gas is calculated in gasCall* according to the 63/64 rule and later
 	// applied in opCall*.
 	callGasTemp uint64
+	// codeGas records the gas consumed by every executed code if the
+	// CallFeeShare rules are active, codeFrames holds the gas consumed by
+	// the calls of every running frame.
+	codeGas    []types.CodeGas
+	codeIndex  map[common.Address]int
+	codeFrames []uint64
 }
 
 // NewEVM returns a new EVM. The returned EVM is not thread safe and should
@@ -150,6 +156,7 @@ func NewEVM(blockCtx BlockContext, txCtx TxContext, statedb StateDB, chainConfig
 func (evm *EVM) Reset(txCtx TxContext, statedb StateDB) {
 	evm.TxContext = txCtx
 	evm.StateDB = statedb
+	evm.codeGas, evm.codeIndex, evm.codeFrames = nil, nil, nil
 }
 
 // Cancel cancels any running EVM operation. This may be called concurrently and
@@ -492,6 +499,7 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 	// Create a new account on the state
 	snapshot := evm.StateDB.Snapshot()
 	evm.StateDB.CreateAccount(address)
+	evm.StateDB.CreateContract(address)
 	if evm.chainRules.IsEIP158 {
 		evm.StateDB.SetNonce(address, 1)
 	}
@@ -48,9 +48,18 @@ type StateDB interface {
 	GetState(common.Address, common.Hash) common.Hash
 	SetState(common.Address, common.Hash, common.Hash)
 
+	GetTransientState(addr common.Address, key common.Hash) common.Hash
+	SetTransientState(addr common.Address, key, value common.Hash)
+
 	Suicide(common.Address) bool
 	HasSuicided(common.Address) bool
 
+	// CreateContract marks the account as created by the current transaction.
+	CreateContract(common.Address)
+	// Suicide6780 only destroys the account if it was created by the current
+	// transaction (EIP-6780).
+	Suicide6780(common.Address)
+
 	// Exist reports whether the given account exists in state.
 	// Notably this should also return true for suicided accounts.
 	Exist(common.Address) bool
@@ -83,6 +83,8 @@ func NewEVMInterpreter(evm *EVM, cfg Config) *EVMInterpreter {
 	if cfg.JumpTable[STOP] == nil {
 		var jt JumpTable
 		switch {
+		case evm.chainRules.IsCancun:
+			jt = cancunInstructionSet
 		case evm.chainRules.IsLondon:
 			jt = londonInstructionSet
 		case evm.chainRules.IsBerlin:
@@ -149,6 +151,13 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 	if len(contract.Code) == 0 {
 		return nil, nil
 	}
+	// Record the gas consumed by the code for the fee attribution
+	if in.evm.chainRules.IsCallFeeShare {
+		in.evm.enterCode()
+		defer func(startGas uint64) {
+			in.evm.exitCode(contract, startGas, err)
+		}(contract.Gas)
+	}
 
 	var (
 		op          OpCode        // current opcode
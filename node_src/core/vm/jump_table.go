@@ -59,11 +59,25 @@ var (
 	istanbulInstructionSet         = newIstanbulInstructionSet()
 	berlinInstructionSet           = newBerlinInstructionSet()
 	londonInstructionSet           = newLondonInstructionSet()
+	cancunInstructionSet           = newCancunInstructionSet()
 )
 
 // JumpTable contains the EVM opcodes supported at a given fork.
 type JumpTable [256]*operation
 
+// newCancunInstructionSet returns the frontier, homestead, byzantium,
+// contantinople, istanbul, petersburg, berlin, london and cancun instructions,
+// cancun bundling the Shanghai opcode changes.
+func newCancunInstructionSet() JumpTable {
+	instructionSet := newLondonInstructionSet()
+	enable3855(&instructionSet) // PUSH0 instruction https://eips.ethereum.org/EIPS/eip-3855
+	enable3860(&instructionSet) // Limit and meter initcode https://eips.ethereum.org/EIPS/eip-3860
+	enable1153(&instructionSet) // Transient storage opcodes https://eips.ethereum.org/EIPS/eip-1153
+	enable5656(&instructionSet) // MCOPY opcode https://eips.ethereum.org/EIPS/eip-5656
+	enable6780(&instructionSet) // SELFDESTRUCT only in same transaction https://eips.ethereum.org/EIPS/eip-6780
+	return instructionSet
+}
+
 // newLondonInstructionSet returns the frontier, homestead, byzantium,
 // contantinople, istanbul, petersburg, berlin and london instructions.
 func newLondonInstructionSet() JumpTable {
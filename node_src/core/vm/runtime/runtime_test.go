@@ -941,3 +941,147 @@ func BenchmarkTracerStepVsCallFrame(b *testing.B) {
 	benchmarkNonModifyingCode(10000000, code, "tracer-step-10M", stepTracer, b)
 	benchmarkNonModifyingCode(10000000, code, "tracer-call-frame-10M", callFrameTracer, b)
 }
+
+func TestCodeGas(t *testing.T) {
+	var (
+		router = common.HexToAddress("0xaa")
+		target = common.HexToAddress("0xbb")
+		config = *params.AllEthashProtocolChanges
+	)
+	config.CallFeeShareBlock = big.NewInt(0)
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	// The router forwards all its gas to the target, which stores a value
+	statedb.SetCode(router, []byte{
+		byte(vm.PUSH1), 0x0, byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1),
+		byte(vm.PUSH1), 0xbb, byte(vm.GAS), byte(vm.CALL), byte(vm.POP), byte(vm.STOP),
+	})
+	statedb.SetCode(target, []byte{
+		byte(vm.PUSH1), 0x1, byte(vm.PUSH1), 0x0, byte(vm.SSTORE), byte(vm.STOP),
+	})
+	cfg := &Config{ChainConfig: &config, State: statedb, GasLimit: 100000}
+	setDefaults(cfg)
+	vmenv := NewEnv(cfg)
+	statedb.PrepareAccessList(cfg.Origin, &router, vm.ActivePrecompiles(config.Rules(cfg.BlockNumber)), nil)
+
+	_, leftOver, err := vmenv.Call(vm.AccountRef(cfg.Origin), router, nil, cfg.GasLimit, new(big.Int))
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	codeGas := vmenv.CodeGas()
+	if len(codeGas) != 2 || codeGas[0].Address != target || codeGas[1].Address != router {
+		t.Fatalf("executed codes mismatch: have %v", codeGas)
+	}
+	// Two pushes and a cold SSTORE of a new value
+	if want := uint64(3 + 3 + 22100); codeGas[0].Gas != want {
+		t.Errorf("target gas mismatch: have %d, want %d", codeGas[0].Gas, want)
+	}
+	if have, want := codeGas[0].Gas+codeGas[1].Gas, cfg.GasLimit-leftOver; have != want {
+		t.Errorf("total gas mismatch: have %d, want %d", have, want)
+	}
+	// Nothing is recorded without the CallFeeShare rules
+	vmenv = NewEnv(&Config{ChainConfig: params.AllEthashProtocolChanges, State: statedb, GasLimit: 100000, BlockNumber: cfg.BlockNumber, Time: cfg.Time, Difficulty: cfg.Difficulty, GetHashFn: cfg.GetHashFn})
+	if _, _, err := vmenv.Call(vm.AccountRef(cfg.Origin), router, nil, cfg.GasLimit, new(big.Int)); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if codeGas := vmenv.CodeGas(); len(codeGas) != 0 {
+		t.Errorf("code gas recorded without fork: %v", codeGas)
+	}
+}
+
+func TestCancunOpcodes(t *testing.T) {
+	config := *params.AllEthashProtocolChanges
+	config.CancunBlock = big.NewInt(0)
+
+	// Store 42 in transient storage, load it into memory at 32, copy it to 0 and return it
+	code := []byte{
+		byte(vm.PUSH1), 42, byte(vm.PUSH0), byte(vm.TSTORE),
+		byte(vm.PUSH0), byte(vm.TLOAD), byte(vm.PUSH1), 32, byte(vm.MSTORE),
+		byte(vm.PUSH1), 32, byte(vm.PUSH1), 32, byte(vm.PUSH0), byte(vm.MCOPY),
+		byte(vm.PUSH1), 32, byte(vm.PUSH0), byte(vm.RETURN),
+	}
+	ret, statedb, err := Execute(code, nil, &Config{ChainConfig: &config})
+	if err != nil {
+		t.Fatalf("execution failed: %v", err)
+	}
+	if have := new(big.Int).SetBytes(ret); have.Int64() != 42 {
+		t.Errorf("return value mismatch: have %v, want 42", have)
+	}
+	address := common.BytesToAddress([]byte("contract"))
+	if have := statedb.GetTransientState(address, common.Hash{}); have != common.BigToHash(big.NewInt(42)) {
+		t.Errorf("transient storage mismatch: have %x", have)
+	}
+	if have := statedb.GetState(address, common.Hash{}); have != (common.Hash{}) {
+		t.Errorf("persistent storage written: have %x", have)
+	}
+	// The transient storage doesn't survive the transaction
+	statedb.Prepare(common.Hash{0x01}, 1)
+	if have := statedb.GetTransientState(address, common.Hash{}); have != (common.Hash{}) {
+		t.Errorf("transient storage not cleared: have %x", have)
+	}
+	// None of the opcodes exist before the fork
+	if _, _, err := Execute(code, nil, &Config{ChainConfig: params.AllEthashProtocolChanges}); err == nil {
+		t.Errorf("PUSH0 executed before the fork")
+	}
+}
+
+func TestCancunSelfdestruct(t *testing.T) {
+	var (
+		beneficiary = common.HexToAddress("0xbb")
+		config      = *params.AllEthashProtocolChanges
+	)
+	config.CancunBlock = big.NewInt(0)
+	code := []byte{byte(vm.PUSH1), 0xbb, byte(vm.SELFDESTRUCT)}
+
+	for _, tt := range []struct {
+		config  *params.ChainConfig
+		removed bool
+	}{
+		{params.AllEthashProtocolChanges, true},
+		{&config, false},
+	} {
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		address := common.BytesToAddress([]byte("contract"))
+		statedb.AddBalance(address, big.NewInt(10))
+
+		if _, _, err := Execute(code, nil, &Config{ChainConfig: tt.config, State: statedb}); err != nil {
+			t.Fatalf("execution failed: %v", err)
+		}
+		if have := statedb.HasSuicided(address); have != tt.removed {
+			t.Errorf("cancun %v: existing contract destruction mismatch: have %v, want %v", tt.config.CancunBlock != nil, have, tt.removed)
+		}
+		if have := statedb.GetBalance(beneficiary); have.Int64() != 10 {
+			t.Errorf("cancun %v: beneficiary balance mismatch: have %v, want 10", tt.config.CancunBlock != nil, have)
+		}
+		// Contracts created by the same transaction are always destroyed
+		_, created, _, err := Create(code, &Config{ChainConfig: tt.config, State: statedb})
+		if err != nil {
+			t.Fatalf("creation failed: %v", err)
+		}
+		if !statedb.HasSuicided(created) {
+			t.Errorf("cancun %v: created contract not destroyed", tt.config.CancunBlock != nil)
+		}
+	}
+}
+
+func TestCancunInitCodeLimit(t *testing.T) {
+	config := *params.AllEthashProtocolChanges
+	config.CancunBlock = big.NewInt(0)
+
+	create := func(size uint16) []byte {
+		return []byte{byte(vm.PUSH2), byte(size >> 8), byte(size), byte(vm.PUSH0), byte(vm.PUSH0), byte(vm.CREATE), byte(vm.STOP)}
+	}
+	if _, _, err := Execute(create(params.MaxInitCodeSize), nil, &Config{ChainConfig: &config}); err != nil {
+		t.Errorf("initcode at the limit rejected: %v", err)
+	}
+	if _, _, err := Execute(create(params.MaxInitCodeSize+1), nil, &Config{ChainConfig: &config}); err == nil {
+		t.Errorf("initcode above the limit accepted")
+	}
+	// Creation transactions pay for every word of their initcode
+	gas, err := core.IntrinsicGas(make([]byte, 64), nil, true, true, true, true)
+	if err != nil {
+		t.Fatalf("failed to compute intrinsic gas: %v", err)
+	}
+	if want := params.TxGasContractCreation + 64*params.TxDataZeroGas + 2*params.InitCodeWordGas; gas != want {
+		t.Errorf("intrinsic gas mismatch: have %d, want %d", gas, want)
+	}
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/consensus/congress"
+	"github.com/ethereum/go-ethereum/consensus/congress/slashprotect"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/bloombits"
 	"github.com/ethereum/go-ethereum/core/rawdb"
@@ -45,6 +46,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
+	"github.com/ethereum/go-ethereum/eth/protocols/vote"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
@@ -69,17 +71,22 @@ type Ethereum struct {
 
 	// Handlers
 	txPool             *core.TxPool
+	votePool           *congress.VotePool
 	blockchain         *core.BlockChain
 	handler            *handler
 	ethDialCandidates  enode.Iterator
 	snapDialCandidates enode.Iterator
 
 	// DB interfaces
-	chainDb ethdb.Database // Block chain database
+	chainDb      ethdb.Database   // Block chain database
+	slashingDB   *slashprotect.DB // Headers signed by the local validator, opened once sealing
+	slashingPath string           // Location of the slashing-protection database
+	slashingOff  bool             // Whether the slashing protection is disabled (developer chains)
 
 	eventMux       *event.TypeMux
 	engine         consensus.Engine
 	accountManager *accounts.Manager
+	remoteSigner   *congress.RemoteSigner // Remote signer holding the validator keys, if any
 
 	isPoSA bool
 	posa   consensus.PoSA
@@ -219,6 +226,44 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		eth.txPool.InitExTxValidator(congressEngine)
 		//
 		congressEngine.SetChain(eth.blockchain)
+		// index the fee distribution of imported blocks if requested
+		if config.CongressRewardIndex {
+			congressEngine.EnableRewardIndex()
+		}
+		// collect block votes for fast finality
+		eth.votePool = congress.NewVotePool(eth.blockchain, congressEngine)
+		// never sign two different headers at the same height, once sealing
+		eth.slashingPath = stack.ResolvePath(slashprotect.DirName)
+		// only seal while holding the lease shared with the standby hosts
+		if config.CongressLeaseFile != "" {
+			host := config.CongressLeaseHost
+			if host == "" {
+				host = congress.DefaultLeaseHost(stack.Config().DataDir)
+			}
+			log.Info("Validator failover enabled", "lease", config.CongressLeaseFile, "host", host, "ttl", config.CongressLeaseTTL)
+			congressEngine.SetLease(congress.NewFileLease(config.CongressLeaseFile, config.CongressLeaseTTL), host, config.CongressLeaseTTL)
+		}
+		// sign with the keys held by a remote signer instead of local accounts
+		if config.CongressRemoteSigner != "" {
+			eth.remoteSigner, err = congress.NewRemoteSigner(congress.RemoteSignerConfig{
+				URL:      config.CongressRemoteSigner,
+				Timeout:  config.CongressRemoteSignerTimeout,
+				CertFile: config.CongressRemoteSignerCert,
+				KeyFile:  config.CongressRemoteSignerKey,
+				CAFile:   config.CongressRemoteSignerCA,
+			})
+			if err != nil {
+				return nil, err
+			}
+			log.Info("Using remote signer for the validator keys", "url", config.CongressRemoteSigner)
+		}
+		// trust an epoch checkpoint instead of verifying the headers up to it
+		if cp := config.CongressCheckpoint; cp != nil {
+			if err := congressEngine.SetTrustedCheckpoint(cp); err != nil {
+				return nil, err
+			}
+			log.Info("Trusting congress checkpoint", "number", cp.Number, "hash", cp.Hash)
+		}
 	}
 
 	// Permit the downloader to use the trie cache allowance during fast sync
@@ -227,7 +272,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if checkpoint == nil {
 		checkpoint = params.TrustedCheckpoints[genesisHash]
 	}
-	if eth.handler, err = newHandler(&handlerConfig{
+	hconfig := &handlerConfig{
 		Database:   chainDb,
 		Chain:      eth.blockchain,
 		TxPool:     eth.txPool,
@@ -237,12 +282,29 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		EventMux:   eth.eventMux,
 		Checkpoint: checkpoint,
 		Whitelist:  config.Whitelist,
-	}); err != nil {
+	}
+	if eth.votePool != nil {
+		hconfig.VotePool = eth.votePool
+	}
+	// Drop the peers that aren't on the chain of the trusted checkpoint
+	if _, ok := eth.engine.(*congress.Congress); ok && config.CongressCheckpoint != nil {
+		cp := config.CongressCheckpoint
+		hconfig.Whitelist = make(map[uint64]common.Hash, len(config.Whitelist)+1)
+		for number, hash := range config.Whitelist {
+			hconfig.Whitelist[number] = hash
+		}
+		hconfig.Whitelist[cp.Number] = cp.Hash
+	}
+	if eth.handler, err = newHandler(hconfig); err != nil {
 		return nil, err
 	}
 
 	eth.miner = miner.New(eth, &config.Miner, chainConfig, eth.EventMux(), eth.engine, eth.isLocalBlock)
 	eth.miner.SetExtra(makeExtraData(config.Miner.ExtraData))
+	// vote for the new heads only once synced and while sealing
+	if eth.votePool != nil {
+		eth.votePool.SetVoting(func() bool { return eth.Synced() && eth.IsMining() })
+	}
 
 	eth.APIBackend = &EthAPIBackend{stack.Config().ExtRPCEnabled(), stack.Config().AllowUnprotectedTxs, eth, nil, nil}
 	if eth.APIBackend.allowUnprotectedTxs {
@@ -537,13 +599,46 @@ func (s *Ethereum) StartMining(threads int) error {
 			}
 			clique.Authorize(eb, wallet.SignData)
 		}
-		if congress, ok := s.engine.(*congress.Congress); ok {
+		if congress, ok := s.engine.(*congress.Congress); ok && s.remoteSigner != nil {
+			if err := s.openSlashingProtection(congress); err != nil {
+				log.Error("Cannot open slashing-protection database", "err", err)
+				return fmt.Errorf("slashing protection: %v", err)
+			}
+			// The remote signer holds both the validator and the consensus keys
+			congress.AuthorizeRemote(eb, s.remoteSigner)
+			if key := s.config.CongressConsensusKey; key != (common.Address{}) {
+				congress.AuthorizeConsensusKey(key, s.remoteSigner.SignData, s.remoteSigner.SignTx)
+			}
+		} else if ok {
+			if err := s.openSlashingProtection(congress); err != nil {
+				log.Error("Cannot open slashing-protection database", "err", err)
+				return fmt.Errorf("slashing protection: %v", err)
+			}
+			// From the consensus key fork, the validator key may be kept off the
+			// node, the consensus key signing everything once registered
+			key := s.config.CongressConsensusKey
+			keyOnly := key != (common.Address{}) && s.blockchain.Config().Congress.ConsensusKeyBlock != nil
+
 			wallet, err := s.accountManager.Find(accounts.Account{Address: eb})
-			if wallet == nil || err != nil {
+			switch {
+			case wallet != nil && err == nil:
+				congress.Authorize(eb, wallet.SignData, wallet.SignTx)
+			case keyOnly:
+				log.Info("Etherbase account unavailable locally, sealing with the consensus key only", "validator", eb, "key", key)
+				congress.AuthorizeValidator(eb)
+			default:
 				log.Error("Etherbase account unavailable locally", "err", err)
 				return fmt.Errorf("signer missing: %v", err)
 			}
-			congress.Authorize(eb, wallet.SignData, wallet.SignTx)
+			// The consensus key signs the blocks once registered for the validator
+			if key != (common.Address{}) {
+				wallet, err := s.accountManager.Find(accounts.Account{Address: key})
+				if wallet == nil || err != nil {
+					log.Error("Consensus key unavailable locally", "err", err)
+					return fmt.Errorf("consensus key missing: %v", err)
+				}
+				congress.AuthorizeConsensusKey(key, wallet.SignData, wallet.SignTx)
+			}
 		}
 		// If mining is started, we can disable the transaction rejection mechanism
 		// introduced to speed sync times.
@@ -554,6 +649,42 @@ func (s *Ethereum) StartMining(threads int) error {
 	return nil
 }
 
+// openSlashingProtection opens the slashing-protection database of the congress
+// validator the first time it starts sealing, leaving it to the offline tools
+// on nodes that never seal.
+func (s *Ethereum) openSlashingProtection(engine *congress.Congress) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.slashingDB != nil || s.slashingOff {
+		return nil
+	}
+	db, err := slashprotect.Open(s.slashingPath)
+	if err != nil {
+		return err
+	}
+	s.slashingDB = db
+	engine.SetSlashingProtection(db)
+	return nil
+}
+
+// DisableSlashingProtection turns off the slashing protection of the congress
+// validator. Developer chains rewind and reseal heights on request, which would
+// otherwise be refused as double signs.
+func (s *Ethereum) DisableSlashingProtection() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.slashingDB != nil {
+		s.slashingDB.Close()
+		s.slashingDB = nil
+	}
+	s.slashingOff = true
+	if congress, ok := s.engine.(*congress.Congress); ok {
+		congress.SetSlashingProtection(nil)
+	}
+}
+
 // StopMining terminates the miner, both at the consensus engine level as well as
 // at the block creation level.
 func (s *Ethereum) StopMining() {
@@ -590,6 +721,9 @@ func (s *Ethereum) Protocols() []p2p.Protocol {
 	if s.config.SnapshotCache > 0 {
 		protos = append(protos, snap.MakeProtocols((*snapHandler)(s.handler), s.snapDialCandidates)...)
 	}
+	if s.votePool != nil {
+		protos = append(protos, vote.MakeProtocols((*voteHandler)(s.handler))...)
+	}
 	return protos
 }
 
@@ -626,9 +760,17 @@ func (s *Ethereum) Stop() error {
 	s.bloomIndexer.Close()
 	close(s.closeBloomHandler)
 	s.txPool.Stop()
+	if s.votePool != nil {
+		s.votePool.Stop()
+	}
 	s.miner.Close()
 	s.blockchain.Stop()
 	s.engine.Close()
+	s.lock.RLock()
+	if s.slashingDB != nil {
+		s.slashingDB.Close()
+	}
+	s.lock.RUnlock()
 	rawdb.PopUncleanShutdownMarker(s.chainDb)
 	s.chainDb.Close()
 	s.eventMux.Stop()
@@ -0,0 +1,246 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package devapi implements the dev RPC namespace, controlling the chain of a
+// congress node in developer mode the way local test chains do: mining blocks on
+// demand, moving the clock, reverting the chain and overriding its state.
+package devapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/congress"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// mineTimeout is the time a single block is waited for when mining.
+const mineTimeout = 10 * time.Second
+
+var (
+	errNoCongress    = errors.New("dev APIs need the congress engine")
+	errNotMining     = errors.New("miner not running")
+	errMineTimeout   = errors.New("timeout waiting for the block to be sealed")
+	errPastTimestamp = errors.New("timestamp too early after the head block")
+)
+
+// Register adds the dev APIs to the node of a developer chain.
+func Register(stack *node.Node, backend *eth.Ethereum) error {
+	engine, ok := backend.Engine().(*congress.Congress)
+	if !ok {
+		return errNoCongress
+	}
+	impersonator := newImpersonator()
+	stack.AccountManager().AddBackend(impersonator)
+
+	// Reverting reseals the rewound heights, which isn't a double sign here
+	backend.DisableSlashingProtection()
+
+	log.Warn("Dev APIs enabled, the chain state can be overridden")
+	stack.RegisterAPIs([]rpc.API{
+		{
+			Namespace: "dev",
+			Version:   "1.0",
+			Service:   newDevAPI(backend, engine, impersonator),
+			Public:    false,
+		},
+	})
+	return nil
+}
+
+// snapshot is the chain head and clock recorded by Snapshot.
+type snapshot struct {
+	id     uint64
+	number uint64
+	offset int64
+}
+
+// devAPI controls the chain of a node in developer mode. The state overrides
+// are applied in a block mined right away, they can't be reproduced by nodes
+// importing the chain.
+type devAPI struct {
+	eth          *eth.Ethereum
+	engine       *congress.Congress
+	impersonator *impersonator
+
+	lock      sync.Mutex // Serializes the calls changing the chain
+	snapshots []snapshot
+	lastID    uint64
+}
+
+// newDevAPI creates the dev APIs of the given node.
+func newDevAPI(eth *eth.Ethereum, engine *congress.Congress, impersonator *impersonator) *devAPI {
+	return &devAPI{eth: eth, engine: engine, impersonator: impersonator}
+}
+
+// Mine seals the given number of blocks, one if not set, even if they hold no
+// transactions. On chains with a block period it waits for them to be sealed.
+func (api *devAPI) Mine(ctx context.Context, blocks *hexutil.Uint64) error {
+	n := uint64(1)
+	if blocks != nil {
+		n = uint64(*blocks)
+	}
+	api.lock.Lock()
+	defer api.lock.Unlock()
+
+	return api.mine(ctx, n)
+}
+
+func (api *devAPI) mine(ctx context.Context, n uint64) error {
+	if !api.eth.IsMining() {
+		return errNotMining
+	}
+	chain := api.eth.BlockChain()
+
+	heads := make(chan core.ChainHeadEvent, 16)
+	sub := chain.SubscribeChainHeadEvent(heads)
+	defer sub.Unsubscribe()
+
+	// Only 0-period chains need empty blocks to be requested
+	if err := api.engine.RequestEmptyBlocks(int(n)); err == nil {
+		defer api.engine.RequestEmptyBlocks(0)
+	}
+	target := chain.CurrentBlock().NumberU64() + n
+	api.eth.Miner().CommitWork()
+
+	for chain.CurrentBlock().NumberU64() < target {
+		select {
+		case <-heads:
+		case err := <-sub.Err():
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(mineTimeout + time.Duration(chain.Config().Congress.Period)*time.Second):
+			return errMineTimeout
+		}
+	}
+	return nil
+}
+
+// SetNextBlockTimestamp sets the timestamp of the next block, the blocks after it
+// following on from it.
+func (api *devAPI) SetNextBlockTimestamp(timestamp hexutil.Uint64) error {
+	api.lock.Lock()
+	defer api.lock.Unlock()
+
+	head := api.eth.BlockChain().CurrentHeader()
+	if min := head.Time + api.eth.BlockChain().Config().Congress.Period; uint64(timestamp) < min {
+		return fmt.Errorf("%w: have %d, want at least %d", errPastTimestamp, timestamp, min)
+	}
+	api.engine.SetNextBlockTimestamp(uint64(timestamp))
+	return nil
+}
+
+// IncreaseTime moves the clock of the chain forward by the given number of
+// seconds, and returns the total number of seconds it is shifted by.
+func (api *devAPI) IncreaseTime(seconds hexutil.Uint64) int64 {
+	return api.engine.IncreaseTime(uint64(seconds))
+}
+
+// Snapshot records the current head and clock of the chain, and returns the id
+// to revert to them.
+func (api *devAPI) Snapshot() hexutil.Uint64 {
+	api.lock.Lock()
+	defer api.lock.Unlock()
+
+	api.lastID++
+	api.snapshots = append(api.snapshots, snapshot{
+		id:     api.lastID,
+		number: api.eth.BlockChain().CurrentBlock().NumberU64(),
+		offset: api.engine.TimeOffset(),
+	})
+	return hexutil.Uint64(api.lastID)
+}
+
+// Revert rewinds the chain to the head and clock of the given snapshot, dropping
+// it and the ones taken after it. It returns false if there is no such snapshot.
+func (api *devAPI) Revert(id hexutil.Uint64) (bool, error) {
+	api.lock.Lock()
+	defer api.lock.Unlock()
+
+	for i, snap := range api.snapshots {
+		if snap.id != uint64(id) {
+			continue
+		}
+		chain := api.eth.BlockChain()
+		if err := chain.SetHead(snap.number); err != nil {
+			return false, err
+		}
+		api.engine.SetTimeOffset(snap.offset)
+		// Announce the head, so the pool drops the reverted state and the miner
+		// restarts its work on top of the snapshot
+		chain.SendChainHeadEvent()
+		api.snapshots = api.snapshots[:i]
+		return true, nil
+	}
+	return false, nil
+}
+
+// SetBalance sets the balance of the account in a new block.
+func (api *devAPI) SetBalance(ctx context.Context, address common.Address, balance hexutil.Big) error {
+	return api.override(ctx, func(statedb *state.StateDB) {
+		statedb.SetBalance(address, (*big.Int)(&balance))
+	})
+}
+
+// SetCode sets the code of the account in a new block.
+func (api *devAPI) SetCode(ctx context.Context, address common.Address, code hexutil.Bytes) error {
+	return api.override(ctx, func(statedb *state.StateDB) {
+		statedb.SetCode(address, code)
+	})
+}
+
+// SetStorageAt sets a storage slot of the account in a new block.
+func (api *devAPI) SetStorageAt(ctx context.Context, address common.Address, slot common.Hash, value common.Hash) error {
+	return api.override(ctx, func(statedb *state.StateDB) {
+		statedb.SetState(address, slot, value)
+	})
+}
+
+// override applies the state override in a new block.
+func (api *devAPI) override(ctx context.Context, override func(*state.StateDB)) error {
+	api.lock.Lock()
+	defer api.lock.Unlock()
+
+	if !api.eth.IsMining() {
+		return errNotMining
+	}
+	api.eth.Miner().AddStateOverride(override)
+	return api.mine(ctx, 1)
+}
+
+// ImpersonateAccount lets eth_sendTransaction send transactions on behalf of the
+// account without its key. Their sender can't be recovered from their signature
+// once they are decoded from the database.
+func (api *devAPI) ImpersonateAccount(address common.Address) {
+	api.impersonator.add(address)
+}
+
+// StopImpersonatingAccount stops sending transactions on behalf of the account.
+func (api *devAPI) StopImpersonatingAccount(address common.Address) {
+	api.impersonator.remove(address)
+}
@@ -0,0 +1,243 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package devapi
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/congress"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	// testKey is a private key of the developer account.
+	testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+
+	// testAddr is the address of the developer account.
+	testAddr = crypto.PubkeyToAddress(testKey.PublicKey)
+)
+
+// startDevNode starts a developer chain mining with the developer account.
+func startDevNode(t *testing.T) (*node.Node, *eth.Ethereum, *devAPI) {
+	t.Helper()
+
+	n, err := node.New(&node.Config{})
+	if err != nil {
+		t.Fatal("can't create node:", err)
+	}
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	n.AccountManager().AddBackend(ks)
+	account, err := ks.ImportECDSA(testKey, "")
+	if err != nil {
+		t.Fatal("can't import key:", err)
+	}
+	if err := ks.Unlock(account, ""); err != nil {
+		t.Fatal("can't unlock key:", err)
+	}
+	ethcfg := ethconfig.Defaults
+	ethcfg.Genesis = congress.DeveloperGenesisBlock(0, 11500000, testAddr)
+	ethcfg.Miner.Etherbase = testAddr
+	ethcfg.Miner.GasPrice = big.NewInt(1)
+	ethservice, err := eth.New(n, &ethcfg)
+	if err != nil {
+		t.Fatal("can't create eth service:", err)
+	}
+	if err := Register(n, ethservice); err != nil {
+		t.Fatal("can't register dev APIs:", err)
+	}
+	if err := n.Start(); err != nil {
+		t.Fatal("can't start node:", err)
+	}
+	if err := ethservice.StartMining(1); err != nil {
+		n.Close()
+		t.Fatal("can't start mining:", err)
+	}
+	for i := 0; i < 100 && !ethservice.IsMining(); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	api := newDevAPI(ethservice, ethservice.Engine().(*congress.Congress), nil)
+	for _, backend := range n.AccountManager().Backends(reflect.TypeOf(&impersonator{})) {
+		api.impersonator = backend.(*impersonator)
+	}
+	return n, ethservice, api
+}
+
+func TestMine(t *testing.T) {
+	n, ethservice, api := startDevNode(t)
+	defer n.Close()
+
+	chain := ethservice.BlockChain()
+	blocks := hexutil.Uint64(3)
+	if err := api.Mine(context.Background(), &blocks); err != nil {
+		t.Fatalf("failed to mine: %v", err)
+	}
+	if head := chain.CurrentBlock().NumberU64(); head < 3 {
+		t.Fatalf("head mismatch: have %d, want at least 3", head)
+	}
+	// Blocks follow on from the set timestamp
+	next := chain.CurrentHeader().Time + 1000
+	if err := api.SetNextBlockTimestamp(hexutil.Uint64(chain.CurrentHeader().Time - 1)); err == nil {
+		t.Errorf("timestamp before the head accepted")
+	}
+	if err := api.SetNextBlockTimestamp(hexutil.Uint64(next)); err != nil {
+		t.Fatalf("failed to set timestamp: %v", err)
+	}
+	if err := api.Mine(context.Background(), nil); err != nil {
+		t.Fatalf("failed to mine: %v", err)
+	}
+	if have := chain.CurrentHeader().Time; have != next {
+		t.Errorf("timestamp mismatch: have %d, want %d", have, next)
+	}
+	// Reverting rewinds the chain and the clock
+	id := api.Snapshot()
+	offset := api.engine.TimeOffset()
+	number := chain.CurrentBlock().NumberU64()
+
+	api.IncreaseTime(3600)
+	if err := api.Mine(context.Background(), &blocks); err != nil {
+		t.Fatalf("failed to mine: %v", err)
+	}
+	if ok, err := api.Revert(id); !ok || err != nil {
+		t.Fatalf("failed to revert: %v, %v", ok, err)
+	}
+	if have := chain.CurrentBlock().NumberU64(); have != number {
+		t.Errorf("head mismatch: have %d, want %d", have, number)
+	}
+	if have := api.engine.TimeOffset(); have != offset {
+		t.Errorf("time offset mismatch: have %d, want %d", have, offset)
+	}
+	if ok, _ := api.Revert(id); ok {
+		t.Errorf("snapshot reverted twice")
+	}
+	// The rewound heights can be mined again
+	if err := api.Mine(context.Background(), &blocks); err != nil {
+		t.Fatalf("failed to mine after revert: %v", err)
+	}
+	if have := chain.CurrentBlock().NumberU64(); have != number+uint64(blocks) {
+		t.Errorf("head mismatch: have %d, want %d", have, number+uint64(blocks))
+	}
+}
+
+func TestStateOverrides(t *testing.T) {
+	n, ethservice, api := startDevNode(t)
+	defer n.Close()
+
+	var (
+		ctx     = context.Background()
+		addr    = common.HexToAddress("0x1234")
+		balance = big.NewInt(1e18)
+		code    = []byte{0x60, 0x00}
+		slot    = common.HexToHash("0x01")
+		value   = common.HexToHash("0x02")
+	)
+	if err := api.SetBalance(ctx, addr, hexutil.Big(*balance)); err != nil {
+		t.Fatalf("failed to set balance: %v", err)
+	}
+	if err := api.SetCode(ctx, addr, code); err != nil {
+		t.Fatalf("failed to set code: %v", err)
+	}
+	if err := api.SetStorageAt(ctx, addr, slot, value); err != nil {
+		t.Fatalf("failed to set storage: %v", err)
+	}
+	statedb, err := ethservice.BlockChain().State()
+	if err != nil {
+		t.Fatalf("failed to get state: %v", err)
+	}
+	if have := statedb.GetBalance(addr); have.Cmp(balance) != 0 {
+		t.Errorf("balance mismatch: have %v, want %v", have, balance)
+	}
+	if have := statedb.GetCode(addr); string(have) != string(code) {
+		t.Errorf("code mismatch: have %x, want %x", have, code)
+	}
+	if have := statedb.GetState(addr, slot); have != value {
+		t.Errorf("storage mismatch: have %x, want %x", have, value)
+	}
+	checkDevBlock(t, ethservice)
+}
+
+// checkDevBlock checks that the head block is marked as built by the dev RPCs,
+// and refused by the engine.
+func checkDevBlock(t *testing.T, ethservice *eth.Ethereum) {
+	t.Helper()
+
+	chain := ethservice.BlockChain()
+	head := chain.CurrentHeader()
+	if head.Nonce == (types.BlockNonce{}) {
+		t.Fatalf("block %d not marked", head.Number)
+	}
+	if err := ethservice.Engine().VerifyHeader(chain, head, false); err == nil || err.Error() != "non-zero nonce" {
+		t.Errorf("verification error mismatch: have %v, want non-zero nonce", err)
+	}
+}
+
+func TestImpersonateAccount(t *testing.T) {
+	n, ethservice, api := startDevNode(t)
+	defer n.Close()
+
+	ctx := context.Background()
+	sender, recipient := common.HexToAddress("0xdead"), common.HexToAddress("0xbeef")
+	if err := api.SetBalance(ctx, sender, hexutil.Big(*big.NewInt(1e18))); err != nil {
+		t.Fatalf("failed to set balance: %v", err)
+	}
+	if _, err := n.AccountManager().Find(accounts.Account{Address: sender}); err == nil {
+		t.Fatalf("account found before being impersonated")
+	}
+	api.ImpersonateAccount(sender)
+	wallet, err := n.AccountManager().Find(accounts.Account{Address: sender})
+	if err != nil {
+		t.Fatalf("impersonated account not found: %v", err)
+	}
+	tx := types.NewTransaction(0, recipient, big.NewInt(1000), params.TxGas, big.NewInt(params.InitialBaseFee), nil)
+	signed, err := wallet.SignTx(accounts.Account{Address: sender}, tx, ethservice.BlockChain().Config().ChainID)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	// The pool picks up the balance once it's reset to the new head
+	err = ethservice.TxPool().AddLocal(signed)
+	for i := 0; i < 100 && err != nil; i++ {
+		time.Sleep(10 * time.Millisecond)
+		err = ethservice.TxPool().AddLocal(signed)
+	}
+	if err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	if err := api.Mine(ctx, nil); err != nil {
+		t.Fatalf("failed to mine: %v", err)
+	}
+	statedb, _ := ethservice.BlockChain().State()
+	if have := statedb.GetBalance(recipient); have.Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("recipient balance mismatch: have %v, want 1000", have)
+	}
+	checkDevBlock(t, ethservice)
+	api.StopImpersonatingAccount(sender)
+	if _, err := n.AccountManager().Find(accounts.Account{Address: sender}); err == nil {
+		t.Errorf("account found after impersonation stopped")
+	}
+}
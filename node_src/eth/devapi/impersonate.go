@@ -0,0 +1,152 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package devapi
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// impersonator is an account backend made of a single wallet, holding the
+// impersonated accounts. Their transactions carry an empty signature and their
+// sender is cached instead. The accounts aren't listed, like the ones of other
+// wallets, to keep the developer account first.
+type impersonator struct {
+	lock     sync.RWMutex
+	accounts map[common.Address]struct{}
+}
+
+func newImpersonator() *impersonator {
+	return &impersonator{accounts: make(map[common.Address]struct{})}
+}
+
+func (w *impersonator) add(address common.Address) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.accounts[address] = struct{}{}
+}
+
+func (w *impersonator) remove(address common.Address) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	delete(w.accounts, address)
+}
+
+// Wallets implements accounts.Backend.
+func (w *impersonator) Wallets() []accounts.Wallet {
+	return []accounts.Wallet{w}
+}
+
+// Subscribe implements accounts.Backend, the single wallet never changes.
+func (w *impersonator) Subscribe(sink chan<- accounts.WalletEvent) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+}
+
+func (w *impersonator) URL() accounts.URL {
+	return accounts.URL{Scheme: "impersonator"}
+}
+
+func (w *impersonator) Status() (string, error) {
+	return "ok", nil
+}
+
+func (w *impersonator) Open(passphrase string) error {
+	return nil
+}
+
+func (w *impersonator) Close() error {
+	return nil
+}
+
+func (w *impersonator) Accounts() []accounts.Account {
+	return nil
+}
+
+func (w *impersonator) Contains(account accounts.Account) bool {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	_, ok := w.accounts[account.Address]
+	return ok
+}
+
+func (w *impersonator) Derive(path accounts.DerivationPath, pin bool) (accounts.Account, error) {
+	return accounts.Account{}, accounts.ErrNotSupported
+}
+
+func (w *impersonator) SelfDerive(bases []accounts.DerivationPath, chain ethereum.ChainStateReader) {
+}
+
+func (w *impersonator) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	return nil, accounts.ErrNotSupported
+}
+
+func (w *impersonator) SignDataWithPassphrase(account accounts.Account, passphrase, mimeType string, data []byte) ([]byte, error) {
+	return nil, accounts.ErrNotSupported
+}
+
+func (w *impersonator) SignText(account accounts.Account, text []byte) ([]byte, error) {
+	return nil, accounts.ErrNotSupported
+}
+
+func (w *impersonator) SignTextWithPassphrase(account accounts.Account, passphrase string, hash []byte) ([]byte, error) {
+	return nil, accounts.ErrNotSupported
+}
+
+// SignTx returns the transaction with an empty signature, its sender cached as
+// the impersonated account.
+func (w *impersonator) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if !w.Contains(account) {
+		return nil, accounts.ErrUnknownAccount
+	}
+	signer := impersonatingSigner{types.LatestSignerForChainID(chainID), account.Address}
+	signed, err := tx.WithSignature(signer, make([]byte, crypto.SignatureLength))
+	if err != nil {
+		return nil, err
+	}
+	// Cache the sender, the signers equal to the wrapped one (the ones of the pool
+	// and of the miner) then read it instead of recovering it
+	if _, err := types.Sender(signer, signed); err != nil {
+		return nil, err
+	}
+	return signed, nil
+}
+
+// impersonatingSigner is a signer returning the impersonated account as the
+// sender of any transaction. It's only used to seed the sender cache of the
+// transactions signed by the impersonator, it equals the signer it wraps.
+type impersonatingSigner struct {
+	types.Signer
+	from common.Address
+}
+
+func (s impersonatingSigner) Sender(tx *types.Transaction) (common.Address, error) {
+	return s.from, nil
+}
+
+func (w *impersonator) SignTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return w.SignTx(account, tx, chainID)
+}
@@ -106,11 +106,12 @@ var Defaults = Config{
 		GasPrice: big.NewInt(params.GWei),
 		Recommit: 3 * time.Second,
 	},
-	TxPool:        core.DefaultTxPoolConfig,
-	RPCGasCap:     50000000,
-	RPCEVMTimeout: 5 * time.Second,
-	GPO:           FullNodeGPO,
-	RPCTxFeeCap:   1, // 1 ether
+	CongressLeaseTTL: 30 * time.Second,
+	TxPool:           core.DefaultTxPoolConfig,
+	RPCGasCap:        50000000,
+	RPCEVMTimeout:    5 * time.Second,
+	GPO:              FullNodeGPO,
+	RPCTxFeeCap:      1, // 1 ether
 }
 
 func init() {
@@ -193,6 +194,22 @@ type Config struct {
 	// Ethash options
 	Ethash ethash.Config
 
+	// Congress options
+	CongressRewardIndex bool          `toml:",omitempty"` // Whether to index the fee shares of imported blocks
+	CongressLeaseFile   string        `toml:",omitempty"` // Lease file shared with the standby hosts of the validator (empty = no failover)
+	CongressLeaseTTL    time.Duration `toml:",omitempty"` // Period the validator lease stays valid without renewal
+	CongressLeaseHost   string        `toml:",omitempty"` // Identity of the local host in the validator lease
+
+	CongressConsensusKey common.Address `toml:",omitempty"` // Account signing on behalf of the validator once registered as its consensus key
+
+	CongressRemoteSigner        string        `toml:",omitempty"` // URL of the remote signer holding the validator keys (empty = local accounts)
+	CongressRemoteSignerTimeout time.Duration `toml:",omitempty"` // Timeout of each request to the remote signer (0 = default)
+	CongressRemoteSignerCert    string        `toml:",omitempty"` // TLS client certificate to authenticate with the remote signer
+	CongressRemoteSignerKey     string        `toml:",omitempty"` // TLS client private key
+	CongressRemoteSignerCA      string        `toml:",omitempty"` // CA certificates to verify the remote signer with
+
+	CongressCheckpoint *congress.TrustedCheckpoint `toml:",omitempty"` // Epoch checkpoint trusted without verifying the headers up to it
+
 	// Transaction pool options
 	TxPool core.TxPoolConfig
 
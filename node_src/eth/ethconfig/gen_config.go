@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/congress"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/eth/downloader"
@@ -18,48 +19,59 @@ import (
 // MarshalTOML marshals as TOML.
 func (c Config) MarshalTOML() (interface{}, error) {
 	type Config struct {
-		Genesis                 *core.Genesis `toml:",omitempty"`
-		NetworkId               uint64
-		SyncMode                downloader.SyncMode
-		EthDiscoveryURLs        []string
-		SnapDiscoveryURLs       []string
-		NoPruning               bool
-		NoPrefetch              bool
-		TxLookupLimit           uint64                 `toml:",omitempty"`
-		Whitelist               map[uint64]common.Hash `toml:"-"`
-		LightServ               int                    `toml:",omitempty"`
-		LightIngress            int                    `toml:",omitempty"`
-		LightEgress             int                    `toml:",omitempty"`
-		LightPeers              int                    `toml:",omitempty"`
-		LightNoPrune            bool                   `toml:",omitempty"`
-		LightNoSyncServe        bool                   `toml:",omitempty"`
-		SyncFromCheckpoint      bool                   `toml:",omitempty"`
-		UltraLightServers       []string               `toml:",omitempty"`
-		UltraLightFraction      int                    `toml:",omitempty"`
-		UltraLightOnlyAnnounce  bool                   `toml:",omitempty"`
-		SkipBcVersionCheck      bool                   `toml:"-"`
-		DatabaseHandles         int                    `toml:"-"`
-		DatabaseCache           int
-		DatabaseFreezer         string
-		TrieCleanCache          int
-		TrieCleanCacheJournal   string        `toml:",omitempty"`
-		TrieCleanCacheRejournal time.Duration `toml:",omitempty"`
-		TrieDirtyCache          int
-		TrieTimeout             time.Duration
-		SnapshotCache           int
-		Preimages               bool
-		Miner                   miner.Config
-		Ethash                  ethash.Config
-		TxPool                  core.TxPoolConfig
-		GPO                     gasprice.Config
-		EnablePreimageRecording bool
-		DocRoot                 string `toml:"-"`
-		RPCGasCap               uint64
-		RPCEVMTimeout           time.Duration
-		RPCTxFeeCap             float64
-		Checkpoint              *params.TrustedCheckpoint      `toml:",omitempty"`
-		CheckpointOracle        *params.CheckpointOracleConfig `toml:",omitempty"`
-		OverrideArrowGlacier    *big.Int                       `toml:",omitempty"`
+		Genesis                     *core.Genesis `toml:",omitempty"`
+		NetworkId                   uint64
+		SyncMode                    downloader.SyncMode
+		EthDiscoveryURLs            []string
+		SnapDiscoveryURLs           []string
+		NoPruning                   bool
+		NoPrefetch                  bool
+		TxLookupLimit               uint64                 `toml:",omitempty"`
+		Whitelist                   map[uint64]common.Hash `toml:"-"`
+		LightServ                   int                    `toml:",omitempty"`
+		LightIngress                int                    `toml:",omitempty"`
+		LightEgress                 int                    `toml:",omitempty"`
+		LightPeers                  int                    `toml:",omitempty"`
+		LightNoPrune                bool                   `toml:",omitempty"`
+		LightNoSyncServe            bool                   `toml:",omitempty"`
+		SyncFromCheckpoint          bool                   `toml:",omitempty"`
+		UltraLightServers           []string               `toml:",omitempty"`
+		UltraLightFraction          int                    `toml:",omitempty"`
+		UltraLightOnlyAnnounce      bool                   `toml:",omitempty"`
+		SkipBcVersionCheck          bool                   `toml:"-"`
+		DatabaseHandles             int                    `toml:"-"`
+		DatabaseCache               int
+		DatabaseFreezer             string
+		TrieCleanCache              int
+		TrieCleanCacheJournal       string        `toml:",omitempty"`
+		TrieCleanCacheRejournal     time.Duration `toml:",omitempty"`
+		TrieDirtyCache              int
+		TrieTimeout                 time.Duration
+		SnapshotCache               int
+		Preimages                   bool
+		Miner                       miner.Config
+		Ethash                      ethash.Config
+		CongressRewardIndex         bool                        `toml:",omitempty"`
+		CongressLeaseFile           string                      `toml:",omitempty"`
+		CongressLeaseTTL            time.Duration               `toml:",omitempty"`
+		CongressLeaseHost           string                      `toml:",omitempty"`
+		CongressConsensusKey        common.Address              `toml:",omitempty"`
+		CongressRemoteSigner        string                      `toml:",omitempty"`
+		CongressRemoteSignerTimeout time.Duration               `toml:",omitempty"`
+		CongressRemoteSignerCert    string                      `toml:",omitempty"`
+		CongressRemoteSignerKey     string                      `toml:",omitempty"`
+		CongressRemoteSignerCA      string                      `toml:",omitempty"`
+		CongressCheckpoint          *congress.TrustedCheckpoint `toml:",omitempty"`
+		TxPool                      core.TxPoolConfig
+		GPO                         gasprice.Config
+		EnablePreimageRecording     bool
+		DocRoot                     string `toml:"-"`
+		RPCGasCap                   uint64
+		RPCEVMTimeout               time.Duration
+		RPCTxFeeCap                 float64
+		Checkpoint                  *params.TrustedCheckpoint      `toml:",omitempty"`
+		CheckpointOracle            *params.CheckpointOracleConfig `toml:",omitempty"`
+		OverrideArrowGlacier        *big.Int                       `toml:",omitempty"`
 	}
 	var enc Config
 	enc.Genesis = c.Genesis
@@ -94,6 +106,17 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Preimages = c.Preimages
 	enc.Miner = c.Miner
 	enc.Ethash = c.Ethash
+	enc.CongressRewardIndex = c.CongressRewardIndex
+	enc.CongressLeaseFile = c.CongressLeaseFile
+	enc.CongressLeaseTTL = c.CongressLeaseTTL
+	enc.CongressLeaseHost = c.CongressLeaseHost
+	enc.CongressConsensusKey = c.CongressConsensusKey
+	enc.CongressRemoteSigner = c.CongressRemoteSigner
+	enc.CongressRemoteSignerTimeout = c.CongressRemoteSignerTimeout
+	enc.CongressRemoteSignerCert = c.CongressRemoteSignerCert
+	enc.CongressRemoteSignerKey = c.CongressRemoteSignerKey
+	enc.CongressRemoteSignerCA = c.CongressRemoteSignerCA
+	enc.CongressCheckpoint = c.CongressCheckpoint
 	enc.TxPool = c.TxPool
 	enc.GPO = c.GPO
 	enc.EnablePreimageRecording = c.EnablePreimageRecording
@@ -110,48 +133,59 @@ func (c Config) MarshalTOML() (interface{}, error) {
 // UnmarshalTOML unmarshals from TOML.
 func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	type Config struct {
-		Genesis                 *core.Genesis `toml:",omitempty"`
-		NetworkId               *uint64
-		SyncMode                *downloader.SyncMode
-		EthDiscoveryURLs        []string
-		SnapDiscoveryURLs       []string
-		NoPruning               *bool
-		NoPrefetch              *bool
-		TxLookupLimit           *uint64                `toml:",omitempty"`
-		Whitelist               map[uint64]common.Hash `toml:"-"`
-		LightServ               *int                   `toml:",omitempty"`
-		LightIngress            *int                   `toml:",omitempty"`
-		LightEgress             *int                   `toml:",omitempty"`
-		LightPeers              *int                   `toml:",omitempty"`
-		LightNoPrune            *bool                  `toml:",omitempty"`
-		LightNoSyncServe        *bool                  `toml:",omitempty"`
-		SyncFromCheckpoint      *bool                  `toml:",omitempty"`
-		UltraLightServers       []string               `toml:",omitempty"`
-		UltraLightFraction      *int                   `toml:",omitempty"`
-		UltraLightOnlyAnnounce  *bool                  `toml:",omitempty"`
-		SkipBcVersionCheck      *bool                  `toml:"-"`
-		DatabaseHandles         *int                   `toml:"-"`
-		DatabaseCache           *int
-		DatabaseFreezer         *string
-		TrieCleanCache          *int
-		TrieCleanCacheJournal   *string        `toml:",omitempty"`
-		TrieCleanCacheRejournal *time.Duration `toml:",omitempty"`
-		TrieDirtyCache          *int
-		TrieTimeout             *time.Duration
-		SnapshotCache           *int
-		Preimages               *bool
-		Miner                   *miner.Config
-		Ethash                  *ethash.Config
-		TxPool                  *core.TxPoolConfig
-		GPO                     *gasprice.Config
-		EnablePreimageRecording *bool
-		DocRoot                 *string `toml:"-"`
-		RPCGasCap               *uint64
-		RPCEVMTimeout           *time.Duration
-		RPCTxFeeCap             *float64
-		Checkpoint              *params.TrustedCheckpoint      `toml:",omitempty"`
-		CheckpointOracle        *params.CheckpointOracleConfig `toml:",omitempty"`
-		OverrideArrowGlacier    *big.Int                       `toml:",omitempty"`
+		Genesis                     *core.Genesis `toml:",omitempty"`
+		NetworkId                   *uint64
+		SyncMode                    *downloader.SyncMode
+		EthDiscoveryURLs            []string
+		SnapDiscoveryURLs           []string
+		NoPruning                   *bool
+		NoPrefetch                  *bool
+		TxLookupLimit               *uint64                `toml:",omitempty"`
+		Whitelist                   map[uint64]common.Hash `toml:"-"`
+		LightServ                   *int                   `toml:",omitempty"`
+		LightIngress                *int                   `toml:",omitempty"`
+		LightEgress                 *int                   `toml:",omitempty"`
+		LightPeers                  *int                   `toml:",omitempty"`
+		LightNoPrune                *bool                  `toml:",omitempty"`
+		LightNoSyncServe            *bool                  `toml:",omitempty"`
+		SyncFromCheckpoint          *bool                  `toml:",omitempty"`
+		UltraLightServers           []string               `toml:",omitempty"`
+		UltraLightFraction          *int                   `toml:",omitempty"`
+		UltraLightOnlyAnnounce      *bool                  `toml:",omitempty"`
+		SkipBcVersionCheck          *bool                  `toml:"-"`
+		DatabaseHandles             *int                   `toml:"-"`
+		DatabaseCache               *int
+		DatabaseFreezer             *string
+		TrieCleanCache              *int
+		TrieCleanCacheJournal       *string        `toml:",omitempty"`
+		TrieCleanCacheRejournal     *time.Duration `toml:",omitempty"`
+		TrieDirtyCache              *int
+		TrieTimeout                 *time.Duration
+		SnapshotCache               *int
+		Preimages                   *bool
+		Miner                       *miner.Config
+		Ethash                      *ethash.Config
+		CongressRewardIndex         *bool                       `toml:",omitempty"`
+		CongressLeaseFile           *string                     `toml:",omitempty"`
+		CongressLeaseTTL            *time.Duration              `toml:",omitempty"`
+		CongressLeaseHost           *string                     `toml:",omitempty"`
+		CongressConsensusKey        *common.Address             `toml:",omitempty"`
+		CongressRemoteSigner        *string                     `toml:",omitempty"`
+		CongressRemoteSignerTimeout *time.Duration              `toml:",omitempty"`
+		CongressRemoteSignerCert    *string                     `toml:",omitempty"`
+		CongressRemoteSignerKey     *string                     `toml:",omitempty"`
+		CongressRemoteSignerCA      *string                     `toml:",omitempty"`
+		CongressCheckpoint          *congress.TrustedCheckpoint `toml:",omitempty"`
+		TxPool                      *core.TxPoolConfig
+		GPO                         *gasprice.Config
+		EnablePreimageRecording     *bool
+		DocRoot                     *string `toml:"-"`
+		RPCGasCap                   *uint64
+		RPCEVMTimeout               *time.Duration
+		RPCTxFeeCap                 *float64
+		Checkpoint                  *params.TrustedCheckpoint      `toml:",omitempty"`
+		CheckpointOracle            *params.CheckpointOracleConfig `toml:",omitempty"`
+		OverrideArrowGlacier        *big.Int                       `toml:",omitempty"`
 	}
 	var dec Config
 	if err := unmarshal(&dec); err != nil {
@@ -253,6 +287,39 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.Ethash != nil {
 		c.Ethash = *dec.Ethash
 	}
+	if dec.CongressRewardIndex != nil {
+		c.CongressRewardIndex = *dec.CongressRewardIndex
+	}
+	if dec.CongressLeaseFile != nil {
+		c.CongressLeaseFile = *dec.CongressLeaseFile
+	}
+	if dec.CongressLeaseTTL != nil {
+		c.CongressLeaseTTL = *dec.CongressLeaseTTL
+	}
+	if dec.CongressLeaseHost != nil {
+		c.CongressLeaseHost = *dec.CongressLeaseHost
+	}
+	if dec.CongressConsensusKey != nil {
+		c.CongressConsensusKey = *dec.CongressConsensusKey
+	}
+	if dec.CongressRemoteSigner != nil {
+		c.CongressRemoteSigner = *dec.CongressRemoteSigner
+	}
+	if dec.CongressRemoteSignerTimeout != nil {
+		c.CongressRemoteSignerTimeout = *dec.CongressRemoteSignerTimeout
+	}
+	if dec.CongressRemoteSignerCert != nil {
+		c.CongressRemoteSignerCert = *dec.CongressRemoteSignerCert
+	}
+	if dec.CongressRemoteSignerKey != nil {
+		c.CongressRemoteSignerKey = *dec.CongressRemoteSignerKey
+	}
+	if dec.CongressRemoteSignerCA != nil {
+		c.CongressRemoteSignerCA = *dec.CongressRemoteSignerCA
+	}
+	if dec.CongressCheckpoint != nil {
+		c.CongressCheckpoint = dec.CongressCheckpoint
+	}
 	if dec.TxPool != nil {
 		c.TxPool = *dec.TxPool
 	}
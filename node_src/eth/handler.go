@@ -45,6 +45,9 @@ const (
 	// txChanSize is the size of channel listening to NewTxsEvent.
 	// The number is referenced from the size of tx pool.
 	txChanSize = 4096
+
+	// voteChanSize is the size of channel listening to NewVoteEvent.
+	voteChanSize = 256
 )
 
 var (
@@ -74,12 +77,24 @@ type txPool interface {
 	SubscribeNewTxsEvent(chan<- core.NewTxsEvent) event.Subscription
 }
 
+// votePool defines the methods needed from a vote pool implementation to
+// support the gossiping of block votes.
+type votePool interface {
+	// AddVote should verify and add the given vote to the pool.
+	AddVote(vote *types.Vote) error
+
+	// SubscribeNewVoteEvent should return an event subscription of
+	// NewVoteEvent and send events to the given channel.
+	SubscribeNewVoteEvent(chan<- core.NewVoteEvent) event.Subscription
+}
+
 // handlerConfig is the collection of initialization parameters to create a full
 // node network handler.
 type handlerConfig struct {
 	Database   ethdb.Database            // Database for direct sync insertions
 	Chain      *core.BlockChain          // Blockchain to serve data from
 	TxPool     txPool                    // Transaction pool to propagate from
+	VotePool   votePool                  // Vote pool to propagate from, nil if votes are not supported
 	Network    uint64                    // Network identifier to adfvertise
 	Sync       downloader.SyncMode       // Whether to fast or full sync
 	BloomCache uint64                    // Megabytes to alloc for fast sync bloom
@@ -101,6 +116,7 @@ type handler struct {
 
 	database ethdb.Database
 	txpool   txPool
+	votePool votePool
 	chain    *core.BlockChain
 	maxPeers int
 
@@ -109,11 +125,14 @@ type handler struct {
 	blockFetcher *fetcher.BlockFetcher
 	txFetcher    *fetcher.TxFetcher
 	peers        *peerSet
+	votePeers    *votePeerSet
 
 	eventMux      *event.TypeMux
 	txsCh         chan core.NewTxsEvent
 	txsSub        event.Subscription
 	minedBlockSub *event.TypeMuxSubscription
+	votesCh       chan core.NewVoteEvent
+	votesSub      event.Subscription
 
 	whitelist map[uint64]common.Hash
 
@@ -137,8 +156,10 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		eventMux:   config.EventMux,
 		database:   config.Database,
 		txpool:     config.TxPool,
+		votePool:   config.VotePool,
 		chain:      config.Chain,
 		peers:      newPeerSet(),
+		votePeers:  newVotePeerSet(),
 		whitelist:  config.Whitelist,
 		quitSync:   make(chan struct{}),
 	}
@@ -406,6 +427,14 @@ func (h *handler) Start(maxPeers int) {
 	h.minedBlockSub = h.eventMux.Subscribe(core.NewMinedBlockEvent{})
 	go h.minedBroadcastLoop()
 
+	// broadcast block votes
+	if h.votePool != nil {
+		h.wg.Add(1)
+		h.votesCh = make(chan core.NewVoteEvent, voteChanSize)
+		h.votesSub = h.votePool.SubscribeNewVoteEvent(h.votesCh)
+		go h.voteBroadcastLoop()
+	}
+
 	// start sync handlers
 	h.wg.Add(1)
 	go h.chainSync.loop()
@@ -414,6 +443,9 @@ func (h *handler) Start(maxPeers int) {
 func (h *handler) Stop() {
 	h.txsSub.Unsubscribe()        // quits txBroadcastLoop
 	h.minedBlockSub.Unsubscribe() // quits blockBroadcastLoop
+	if h.votesSub != nil {
+		h.votesSub.Unsubscribe() // quits voteBroadcastLoop
+	}
 
 	// Quit chainSync and txsync64.
 	// After this is done, no new peers will be accepted.
@@ -0,0 +1,148 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+// Enhanced blockchain implementation by Circle Layer <https://circlelayer.com>
+
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/consensus/congress"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/protocols/vote"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// voteHandler implements the vote.Backend interface to handle the block votes
+// gossiped between the validators.
+type voteHandler handler
+
+// RunPeer is invoked when a peer joins on the `vote` protocol.
+func (h *voteHandler) RunPeer(peer *vote.Peer, hand vote.Handler) error {
+	h.votePeers.register(peer)
+	defer h.votePeers.unregister(peer.ID())
+
+	return hand(peer)
+}
+
+// PeerInfo retrieves all known `vote` information about a peer.
+func (h *voteHandler) PeerInfo(id enode.ID) interface{} {
+	if p := h.votePeers.peer(id.String()); p != nil {
+		return &struct {
+			Version uint `json:"version"`
+		}{p.Version()}
+	}
+	return nil
+}
+
+// Handle is invoked from a peer's message handler when it receives a new remote
+// message that the handler couldn't consume and serve itself.
+func (h *voteHandler) Handle(peer *vote.Peer, packet vote.Packet) error {
+	switch packet := packet.(type) {
+	case *vote.VotesPacket:
+		if h.votePool == nil {
+			return nil
+		}
+		for _, v := range *packet {
+			err := h.votePool.AddVote(v)
+			if errors.Is(err, congress.ErrVoteInvalidSignature) || errors.Is(err, congress.ErrVoteUnauthorized) {
+				// Drop the peer relaying votes nobody in the validator set signed
+				return fmt.Errorf("invalid vote for block %d: %w", v.Data.Number, err)
+			}
+			if err != nil {
+				peer.Log().Trace("Discarded remote vote", "number", v.Data.Number, "hash", v.Data.Hash, "err", err)
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// BroadcastVotes propagates a batch of votes to all `vote` peers which are not
+// known to already have them.
+func (h *handler) BroadcastVotes(votes []*types.Vote) {
+	for _, peer := range h.votePeers.all() {
+		var unknown []*types.Vote
+		for _, v := range votes {
+			if !peer.KnownVote(v.Hash()) {
+				unknown = append(unknown, v)
+			}
+		}
+		if len(unknown) > 0 {
+			peer.AsyncSendVotes(unknown)
+		}
+	}
+}
+
+// voteBroadcastLoop propagates the votes accepted by the local vote pool.
+func (h *handler) voteBroadcastLoop() {
+	defer h.wg.Done()
+	for {
+		select {
+		case event := <-h.votesCh:
+			h.BroadcastVotes([]*types.Vote{event.Vote})
+		case <-h.votesSub.Err():
+			return
+		}
+	}
+}
+
+// votePeerSet is the set of peers participating in the `vote` protocol.
+type votePeerSet struct {
+	peers map[string]*vote.Peer
+	lock  sync.RWMutex
+}
+
+// newVotePeerSet creates a new peer set to track the active vote peers.
+func newVotePeerSet() *votePeerSet {
+	return &votePeerSet{
+		peers: make(map[string]*vote.Peer),
+	}
+}
+
+func (ps *votePeerSet) register(peer *vote.Peer) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	ps.peers[peer.ID()] = peer
+}
+
+func (ps *votePeerSet) unregister(id string) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	delete(ps.peers, id)
+}
+
+func (ps *votePeerSet) peer(id string) *vote.Peer {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	return ps.peers[id]
+}
+
+func (ps *votePeerSet) all() []*vote.Peer {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	list := make([]*vote.Peer, 0, len(ps.peers))
+	for _, p := range ps.peers {
+		list = append(list, p)
+	}
+	return list
+}
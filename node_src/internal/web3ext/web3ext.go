@@ -24,6 +24,7 @@ var Modules = map[string]string{
 	"congress": CongressJs,
 	"ethash":   EthashJs,
 	"debug":    DebugJs,
+	"dev":      DevJs,
 	"eth":      EthJs,
 	"miner":    MinerJs,
 	"net":      NetJs,
@@ -91,6 +92,73 @@ web3._extend({
 });
 `
 
+const DevJs = `
+web3._extend({
+	property: 'dev',
+	methods: [
+		new web3._extend.Method({
+			name: 'mine',
+			call: 'dev_mine',
+			params: 1,
+			inputFormatter: [function(val) { return val === undefined || val === null ? null : web3._extend.utils.fromDecimal(val); }]
+		}),
+		new web3._extend.Method({
+			name: 'setNextBlockTimestamp',
+			call: 'dev_setNextBlockTimestamp',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'increaseTime',
+			call: 'dev_increaseTime',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'snapshot',
+			call: 'dev_snapshot',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'revert',
+			call: 'dev_revert',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'setBalance',
+			call: 'dev_setBalance',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'setCode',
+			call: 'dev_setCode',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'setStorageAt',
+			call: 'dev_setStorageAt',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, null]
+		}),
+		new web3._extend.Method({
+			name: 'impersonateAccount',
+			call: 'dev_impersonateAccount',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'stopImpersonatingAccount',
+			call: 'dev_stopImpersonatingAccount',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
+	]
+});
+`
+
 const CongressJs = `
 web3._extend({
 	property: 'congress',
@@ -117,6 +185,41 @@ web3._extend({
 			call: 'congress_getValidatorsAtHash',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'getDoubleSignEvidences',
+			call: 'congress_getDoubleSignEvidences',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'getRewardBreakdown',
+			call: 'congress_getRewardBreakdown',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'simulateProposal',
+			call: 'congress_simulateProposal',
+			params: 2,
+			inputFormatter: [web3._extend.utils.fromDecimal, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getBlacklist',
+			call: 'congress_getBlacklist',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getEventCheckRules',
+			call: 'congress_getEventCheckRules',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'checkAddress',
+			call: 'congress_checkAddress',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
 	]
 });
 `
@@ -221,6 +324,14 @@ web3._extend({
 			name: 'stopWS',
 			call: 'admin_stopWS'
 		}),
+		new web3._extend.Method({
+			name: 'validatorRole',
+			call: 'admin_validatorRole'
+		}),
+		new web3._extend.Method({
+			name: 'transferValidatorRole',
+			call: 'admin_transferValidatorRole'
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
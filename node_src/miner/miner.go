@@ -206,6 +206,19 @@ func (miner *Miner) PendingBlockAndReceipts() (*types.Block, types.Receipts) {
 	return miner.worker.pendingBlockAndReceipts()
 }
 
+// CommitWork makes the miner start sealing a new block on top of the current head
+// right away.
+func (miner *Miner) CommitWork() {
+	miner.worker.commitWork()
+}
+
+// AddStateOverride modifies the state of the next sealed block with the given
+// function before its transactions run. It is meant for developer chains only,
+// as the nodes importing the block can't reproduce its state.
+func (miner *Miner) AddStateOverride(override func(*state.StateDB)) {
+	miner.worker.addStateOverride(override)
+}
+
 func (miner *Miner) SetEtherbase(addr common.Address) {
 	miner.coinbase = addr
 	miner.worker.setEtherbase(addr)
@@ -78,6 +78,12 @@ const (
 	staleThreshold = 7
 )
 
+// devBlockNonce marks the blocks of developer chains whose state can't be
+// recomputed from the chain: the ones sealed on top of state overrides, or
+// including transactions of impersonated accounts, which carry no signature.
+// They are never broadcast, and fail the nonce check of Congress on import.
+var devBlockNonce = types.BlockNonce{'d', 'e', 'v', 'b', 'l', 'o', 'c', 'k'}
+
 // environment is the worker's current environment and holds all of the current state information.
 type environment struct {
 	signer types.Signer
@@ -94,6 +100,8 @@ type environment struct {
 	receipts []*types.Receipt
 
 	extraValidator types.EvmExtraValidator
+
+	overrides int // Number of state overrides applied before the transactions
 }
 
 // task contains all information for consensus engine sealing and result submitting.
@@ -102,6 +110,7 @@ type task struct {
 	state     *state.StateDB
 	block     *types.Block
 	createdAt time.Time
+	overrides int
 }
 
 const (
@@ -164,10 +173,12 @@ type worker struct {
 	remoteUncles map[common.Hash]*types.Block // A set of side blocks as the possible uncle blocks.
 	unconfirmed  *unconfirmedBlocks           // A set of locally mined blocks pending canonicalness confirmations.
 
-	mu       sync.RWMutex // The lock used to protect the coinbase and extra fields
+	mu       sync.RWMutex // The lock used to protect the coinbase, extra and overrides fields
 	coinbase common.Address
 	extra    []byte
 
+	overrides []func(*state.StateDB) // State overrides to apply to the next sealed block (dev mode)
+
 	pendingMu    sync.RWMutex
 	pendingTasks map[common.Hash]*task
 
@@ -179,6 +190,7 @@ type worker struct {
 	// atomic status counters
 	running int32 // The indicator whether the consensus engine is running or not.
 	newTxs  int32 // New arrival transaction count since last sealing work submitting.
+	reseal  int32 // The indicator whether the next task must be sealed even if it's a duplicate.
 
 	// noempty is the flag used to control whether the feature of pre-seal empty
 	// block is enabled. The default value is false(pre-seal is enabled by default).
@@ -257,6 +269,23 @@ func (w *worker) setEtherbase(addr common.Address) {
 	w.coinbase = addr
 }
 
+// addStateOverride schedules a modification of the state of the next sealed block.
+func (w *worker) addStateOverride(override func(*state.StateDB)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.overrides = append(w.overrides, override)
+}
+
+// commitWork starts a new work cycle on top of the current head right away,
+// sealing it even if the previous task had the same content.
+func (w *worker) commitWork() {
+	atomic.StoreInt32(&w.reseal, 1)
+	select {
+	case w.startCh <- struct{}{}:
+	default: // A new cycle is already requested
+	}
+}
+
 func (w *worker) setGasCeil(ceil uint64) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
@@ -411,7 +440,8 @@ func (w *worker) newWorkLoop(recommit time.Duration) {
 		case <-timer.C:
 			// If mining is running resubmit a new work cycle periodically to pull in
 			// higher priced transactions. Disable this overhead for pending blocks.
-			if w.isRunning() && (w.chainConfig.Clique == nil || w.chainConfig.Clique.Period > 0) {
+			if w.isRunning() && (w.chainConfig.Clique == nil || w.chainConfig.Clique.Period > 0) &&
+				(w.chainConfig.Congress == nil || w.chainConfig.Congress.Period > 0) {
 				// Short circuit if no new transaction arrives.
 				if atomic.LoadInt32(&w.newTxs) == 0 {
 					timer.Reset(recommit)
@@ -541,10 +571,11 @@ func (w *worker) mainLoop() {
 					w.updateSnapshot()
 				}
 			} else {
-				// Special case, if the consensus engine is 0 period clique(dev mode),
+				// Special case, if the consensus engine is 0 period clique or congress(dev mode),
 				// submit mining work here since all empty submission will be rejected
-				// by clique. Of course the advance sealing(empty submission) is disabled.
-				if w.chainConfig.Clique != nil && w.chainConfig.Clique.Period == 0 {
+				// by them. Of course the advance sealing(empty submission) is disabled.
+				if (w.chainConfig.Clique != nil && w.chainConfig.Clique.Period == 0) ||
+					(w.chainConfig.Congress != nil && w.chainConfig.Congress.Period == 0) {
 					w.commitNewWork(nil, true, time.Now().Unix())
 				}
 			}
@@ -587,7 +618,7 @@ func (w *worker) taskLoop() {
 			}
 			// Reject duplicate sealing work due to resubmitting.
 			sealHash := w.engine.SealHash(task.block.Header())
-			if sealHash == prev {
+			if reseal := atomic.SwapInt32(&w.reseal, 0) == 1; sealHash == prev && !reseal {
 				continue
 			}
 			// Interrupt previous sealing operation
@@ -666,6 +697,14 @@ func (w *worker) resultLoop() {
 				}
 				logs = append(logs, receipt.Logs...)
 			}
+			// Drop the state overrides applied to the block before announcing it
+			w.mu.Lock()
+			if task.overrides > len(w.overrides) {
+				task.overrides = len(w.overrides)
+			}
+			w.overrides = w.overrides[task.overrides:]
+			w.mu.Unlock()
+
 			// Commit block and state to database.
 			_, err := w.chain.WriteBlockWithState(block, receipts, logs, task.state, true)
 			if err != nil {
@@ -675,8 +714,11 @@ func (w *worker) resultLoop() {
 			log.Info("Successfully sealed new block", "number", block.Number(), "sealhash", sealhash, "hash", hash,
 				"elapsed", common.PrettyDuration(time.Since(task.createdAt)))
 
-			// Broadcast the block and announce chain insertion event
-			w.mux.Post(core.NewMinedBlockEvent{Block: block})
+			// Broadcast the block and announce chain insertion event, unless it's
+			// one of the dev RPCs which must never leave the node
+			if block.Nonce() != devBlockNonce.Uint64() {
+				w.mux.Post(core.NewMinedBlockEvent{Block: block})
+			}
 
 			// Insert the block into the set of pending ones to resultLoop for confirmations
 			w.unconfirmed.Insert(block.NumberU64(), block.Hash())
@@ -977,6 +1019,11 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64)
 		}
 		env.extraValidator = w.posa.CreateEvmExtraValidator(header, env.state)
 	}
+	// Apply the state overrides before any transaction
+	for _, override := range w.overrides {
+		override(env.state)
+	}
+	env.overrides = len(w.overrides)
 	// Accumulate the uncles for the current block
 	uncles := make([]*types.Header, 0, 2)
 	commitUncles := func(blocks map[common.Hash]*types.Block) {
@@ -1049,6 +1096,9 @@ func (w *worker) commit(uncles []*types.Header, interval func(), update bool, st
 	txs := make([]*types.Transaction, len(w.current.txs))
 	copy(txs, w.current.txs)
 	s := w.current.state.Copy()
+	if w.current.overrides > 0 || hasUnsigned(txs) {
+		w.current.header.Nonce = devBlockNonce
+	}
 	block, receipts, err := w.engine.FinalizeAndAssemble(w.chain, w.current.header, s, txs, uncles, cpyReceipts)
 	if err != nil {
 		return err
@@ -1058,7 +1108,7 @@ func (w *worker) commit(uncles []*types.Header, interval func(), update bool, st
 			interval()
 		}
 		select {
-		case w.taskCh <- &task{receipts: receipts, state: s, block: block, createdAt: time.Now()}:
+		case w.taskCh <- &task{receipts: receipts, state: s, block: block, createdAt: time.Now(), overrides: w.current.overrides}:
 			w.unconfirmed.Shift(block.NumberU64() - 1)
 			log.Info("Commit new mining work", "number", block.Number(), "sealhash", w.engine.SealHash(block.Header()),
 				"uncles", len(uncles), "txs", w.current.tcount,
@@ -1075,6 +1125,17 @@ func (w *worker) commit(uncles []*types.Header, interval func(), update bool, st
 	return nil
 }
 
+// hasUnsigned returns whether any of the transactions carries an empty signature,
+// like the ones of the accounts impersonated on developer chains.
+func hasUnsigned(txs []*types.Transaction) bool {
+	for _, tx := range txs {
+		if _, r, s := tx.RawSignatureValues(); r.Sign() == 0 && s.Sign() == 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // copyReceipts makes a deep copy of the given receipts.
 func copyReceipts(receipts []*types.Receipt) []*types.Receipt {
 	result := make([]*types.Receipt, len(receipts))
@@ -18,11 +18,13 @@
 package params
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -117,18 +119,18 @@ var (
 	//
 	// This configuration is intentionally not using keyed fields to force anyone
 	// adding flags to the config to also have to set these fields.
-	AllEthashProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, new(EthashConfig), nil, nil}
+	AllEthashProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, nil, nil, nil, new(EthashConfig), nil, nil}
 
 	// AllCliqueProtocolChanges contains every protocol change (EIPs) introduced
 	// and accepted by the Ethereum core developers into the Clique consensus.
 	//
 	// This configuration is intentionally not using keyed fields to force anyone
 	// adding flags to the config to also have to set these fields.
-	AllCliqueProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, nil, &CliqueConfig{Period: 0, Epoch: 30000}, nil}
+	AllCliqueProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, nil, nil, nil, nil, nil, &CliqueConfig{Period: 0, Epoch: 30000}, nil}
 
-	AllCongressProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, big.NewInt(2), big.NewInt(3), nil, nil, &CongressConfig{Period: 0, Epoch: 30000}}
+	AllCongressProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, big.NewInt(2), big.NewInt(3), big.NewInt(3), big.NewInt(3), big.NewInt(3), nil, nil, &CongressConfig{Period: 0, Epoch: 30000}}
 
-	TestChainConfig = &ChainConfig{big.NewInt(1), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, new(EthashConfig), nil, nil}
+	TestChainConfig = &ChainConfig{big.NewInt(1), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, nil, nil, nil, new(EthashConfig), nil, nil}
 	TestRules       = TestChainConfig.Rules(new(big.Int))
 )
 
@@ -208,13 +210,18 @@ type ChainConfig struct {
 	BerlinBlock         *big.Int `json:"berlinBlock,omitempty"`         // Berlin switch block (nil = no fork, 0 = already on berlin)
 	LondonBlock         *big.Int `json:"londonBlock,omitempty"`         // London switch block (nil = no fork, 0 = already on london)
 	ArrowGlacierBlock   *big.Int `json:"arrowGlacierBlock,omitempty"`   // Eip-4345 (bomb delay) switch block (nil = no fork, 0 = already activated)
+	CancunBlock         *big.Int `json:"cancunBlock,omitempty"`         // Shanghai and Cancun opcodes (EIP-3855, 3860, 1153, 5656, 6780) switch block (nil = no fork, 0 = already activated)
 
 	// TerminalTotalDifficulty is the amount of total difficulty reached by
 	// the network that triggers the consensus upgrade.
 	TerminalTotalDifficulty *big.Int `json:"terminalTotalDifficulty,omitempty"`
 
-	RedCoastBlock *big.Int `json:"redCoastBlock,omitempty"` // RedCoast switch block (nil = no fork, set value ≥ 2 to activate it)
-	SophonBlock   *big.Int `json:"sophonBlock,omitempty"`   // Sophon switch block (nil = no fork, set > RedCoastBlock to activate it)
+	RedCoastBlock *big.Int `json:"redCoastBlock,omitempty"` // RedCoast switch block (nil = no fork, set value ≥ 1 to activate it)
+	SophonBlock   *big.Int `json:"sophonBlock,omitempty"`   // Sophon switch block (nil = no fork, set ≥ RedCoastBlock to activate it)
+
+	SlashingBlock     *big.Int `json:"slashingBlock,omitempty"`     // Double-sign slashing switch block (nil = no fork, set ≥ SophonBlock to activate it)
+	FeeShareBlock     *big.Int `json:"feeShareBlock,omitempty"`     // Fee share switch block, rewards are shared by receipt gas used (nil = no fork, set ≥ SophonBlock to activate it)
+	CallFeeShareBlock *big.Int `json:"callFeeShareBlock,omitempty"` // Call fee share switch block, fees are shared by the gas used by every executed code (nil = no fork, set ≥ FeeShareBlock to activate it)
 
 	// Various consensus engines
 	Ethash   *EthashConfig   `json:"ethash,omitempty"`
@@ -247,6 +254,237 @@ type CongressConfig struct {
 	Epoch  uint64 `json:"epoch"`  // Epoch length to reset votes and checkpoint
 
 	EnableDevVerification bool `json:"enableDevVerification"` // Enable developer address verification
+
+	// Admin of the governance, address list and validators contracts set up by
+	// the RedCoast fork (nil = built-in admin of the network)
+	Admin *common.Address `json:"admin,omitempty"`
+
+	Upgrades []SysContractUpgrade `json:"upgrades,omitempty"` // Scheduled system contract upgrades, ordered by block
+
+	// Overrides of the validator cap and of the epoch length. The switch block
+	// must be an epoch block under both the old and the new epoch length.
+	ValidatorParamsBlock *big.Int `json:"validatorParamsBlock,omitempty"` // Switch block of the overrides (nil = no fork)
+	MaxValidators        uint64   `json:"maxValidators,omitempty"`        // Max validators allowed to seal from the switch block (0 = unchanged), above CongressContractMaxValidators if the validators contract is upgraded by then
+	EpochOverride        uint64   `json:"epochOverride,omitempty"`        // Epoch length from the switch block (0 = unchanged)
+
+	// Switch block of the recents window defined by RecentsLimit (nil = legacy window)
+	RecentsBlock *big.Int `json:"recentsBlock,omitempty"`
+
+	// Switch block of the consensus keys, signing the blocks on behalf of the
+	// validators (nil = validators sign themselves). Must be an epoch block.
+	ConsensusKeyBlock *big.Int `json:"consensusKeyBlock,omitempty"`
+}
+
+// RecentsLimit returns the length of the recents window at the given block for
+// a set of the given number of validators: a validator that sealed block n may
+// not seal another block before block n+limit.
+//
+// From the recents fork the window is validators/2 + 1 blocks, so a majority
+// of the validators takes part in every window. Before it, the legacy window is
+// 2 blocks for sets of 2 to 21 validators and validators/2 + 1 otherwise.
+func (c *CongressConfig) RecentsLimit(number uint64, validators int) uint64 {
+	if c.IsRecents(number) || validators > 21 || validators == 1 {
+		return uint64(validators/2 + 1)
+	}
+	return 2
+}
+
+// IsRecents returns whether the recents window of RecentsLimit is in effect at
+// the given block.
+func (c *CongressConfig) IsRecents(number uint64) bool {
+	return c.RecentsBlock != nil && c.RecentsBlock.Uint64() <= number
+}
+
+// IsConsensusKey returns whether the blocks are signed by the consensus keys of
+// the validators at the given block.
+func (c *CongressConfig) IsConsensusKey(number uint64) bool {
+	return c.ConsensusKeyBlock != nil && c.ConsensusKeyBlock.Uint64() <= number
+}
+
+// EpochAt returns the epoch length in effect at the given block.
+func (c *CongressConfig) EpochAt(number uint64) uint64 {
+	if c.EpochOverride != 0 && c.IsValidatorParams(number) {
+		return c.EpochOverride
+	}
+	return c.Epoch
+}
+
+// IsValidatorParams returns whether the validator cap and epoch overrides are
+// in effect at the given block.
+func (c *CongressConfig) IsValidatorParams(number uint64) bool {
+	return c.ValidatorParamsBlock != nil && c.ValidatorParamsBlock.Uint64() <= number
+}
+
+// CongressContractMaxValidators is the cap on the active validators enforced by
+// the MaxValidators constant of the built-in validators system contract. The
+// contract never elects more validators, whatever the consensus allows.
+const CongressContractMaxValidators = 21
+
+var (
+	// Addresses of the validators system contract before and after the RedCoast
+	// fork, see systemcontract.GetValidatorAddr.
+	congressValidatorsContract   = common.HexToAddress("0x000000000000000000000000000000000000f000")
+	congressValidatorsV1Contract = common.HexToAddress("0x000000000000000000000000000000000000F005")
+)
+
+// checkValidatorParams verifies that the validator cap and epoch overrides are
+// scheduled on an epoch block of both epoch lengths, and that the cap can be
+// reached by the validators contract, the one at the given address when the
+// overrides switch. A cap above the one of the built-in contract requires an
+// upgrade of the contract scheduled at or before the switch block.
+func (c *CongressConfig) checkValidatorParams(validators common.Address) error {
+	if c.ValidatorParamsBlock == nil {
+		if c.MaxValidators != 0 || c.EpochOverride != 0 {
+			return fmt.Errorf("invalid validator params: maxValidators or epochOverride set without validatorParamsBlock")
+		}
+		return nil
+	}
+	if c.MaxValidators > CongressContractMaxValidators && !c.upgradedBy(validators, c.ValidatorParamsBlock) {
+		return fmt.Errorf("invalid validator params: maxValidators %d above the cap %d of the validators contract, without upgrading it by validatorParamsBlock %v", c.MaxValidators, CongressContractMaxValidators, c.ValidatorParamsBlock)
+	}
+	if c.ValidatorParamsBlock.Sign() <= 0 || !c.ValidatorParamsBlock.IsUint64() {
+		return fmt.Errorf("invalid validator params: unsupported validatorParamsBlock %v", c.ValidatorParamsBlock)
+	}
+	number := c.ValidatorParamsBlock.Uint64()
+	if c.EpochOverride != 0 {
+		if c.Epoch == 0 {
+			return fmt.Errorf("invalid validator params: epochOverride set without epoch")
+		}
+		if number%c.Epoch != 0 || number%c.EpochOverride != 0 {
+			return fmt.Errorf("invalid validator params: validatorParamsBlock %d is not an epoch block of both epoch %d and epochOverride %d", number, c.Epoch, c.EpochOverride)
+		}
+	}
+	return nil
+}
+
+// upgradedBy returns whether an upgrade of the system contract is scheduled at or
+// before the given block.
+func (c *CongressConfig) upgradedBy(addr common.Address, number *big.Int) bool {
+	for _, upgrade := range c.Upgrades {
+		if upgrade.Address == addr && upgrade.Block != nil && upgrade.Block.Cmp(number) <= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// checkRecents verifies that the recents window fork is scheduled after genesis.
+func (c *CongressConfig) checkRecents() error {
+	if c.RecentsBlock != nil && (c.RecentsBlock.Sign() <= 0 || !c.RecentsBlock.IsUint64()) {
+		return fmt.Errorf("invalid recents window: unsupported recentsBlock %v", c.RecentsBlock)
+	}
+	return nil
+}
+
+// checkConsensusKey verifies that the consensus key fork is scheduled on an
+// epoch block, the first checkpoint listing the keys of the validators. The
+// keys are set in the validators contract, the one at the given address at the
+// fork, which must be upgraded to keep them at or before the fork block.
+func (c *CongressConfig) checkConsensusKey(validators common.Address) error {
+	if c.ConsensusKeyBlock == nil {
+		return nil
+	}
+	if c.ConsensusKeyBlock.Sign() <= 0 || !c.ConsensusKeyBlock.IsUint64() {
+		return fmt.Errorf("invalid consensus key fork: unsupported consensusKeyBlock %v", c.ConsensusKeyBlock)
+	}
+	if !c.upgradedBy(validators, c.ConsensusKeyBlock) {
+		return fmt.Errorf("invalid consensus key fork: validators contract not upgraded by consensusKeyBlock %v", c.ConsensusKeyBlock)
+	}
+	number := c.ConsensusKeyBlock.Uint64()
+	if epoch := c.EpochAt(number); epoch == 0 || number%epoch != 0 {
+		return fmt.Errorf("invalid consensus key fork: consensusKeyBlock %d is not an epoch block of epoch %d", number, epoch)
+	}
+	return nil
+}
+
+// checkValidatorParamsCompatible checks that the validator cap and epoch
+// overrides already in effect at head are identical in both configs.
+func checkValidatorParamsCompatible(stored, new *CongressConfig, head *big.Int) *ConfigCompatError {
+	if isForkIncompatible(stored.ValidatorParamsBlock, new.ValidatorParamsBlock, head) {
+		return newCompatError("validator params fork block", stored.ValidatorParamsBlock, new.ValidatorParamsBlock)
+	}
+	if isForked(stored.ValidatorParamsBlock, head) && (stored.MaxValidators != new.MaxValidators || stored.EpochOverride != new.EpochOverride) {
+		return newCompatError("validator params", stored.ValidatorParamsBlock, new.ValidatorParamsBlock)
+	}
+	return nil
+}
+
+// SysContractUpgrade is a scheduled replacement of the code of a system contract.
+// The code is either given inline, or by its hash if it is already stored in the
+// state database, e.g. deployed to another address beforehand.
+type SysContractUpgrade struct {
+	Block    *big.Int       `json:"block"`              // Block number the upgrade is applied at, before any transaction
+	Address  common.Address `json:"address"`            // System contract to upgrade
+	Code     hexutil.Bytes  `json:"code,omitempty"`     // New runtime code
+	CodeHash common.Hash    `json:"codeHash,omitempty"` // Hash of the new runtime code, if not inline
+	InitData hexutil.Bytes  `json:"initData,omitempty"` // ABI-encoded calldata called on the upgraded contract (optional, skipped if the call fails)
+}
+
+// Hash returns the hash of the new code of the upgrade.
+func (u *SysContractUpgrade) Hash() common.Hash {
+	if len(u.Code) == 0 {
+		return u.CodeHash
+	}
+	var h common.Hash
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(u.Code)
+	hasher.Sum(h[:0])
+	return h
+}
+
+// equal returns whether two upgrades replace the same contract with the same
+// code at the same block.
+func (u *SysContractUpgrade) equal(other *SysContractUpgrade) bool {
+	return u.Block.Cmp(other.Block) == 0 && u.Address == other.Address && u.Hash() == other.Hash() && bytes.Equal(u.InitData, other.InitData)
+}
+
+// checkUpgrades verifies that the scheduled system contract upgrades are well
+// formed and ordered by block.
+func (c *CongressConfig) checkUpgrades() error {
+	var last *big.Int
+	for i, upgrade := range c.Upgrades {
+		switch {
+		case upgrade.Block == nil || upgrade.Block.Sign() <= 0:
+			return fmt.Errorf("invalid system contract upgrade %d: missing block", i)
+		case upgrade.Address == (common.Address{}):
+			return fmt.Errorf("invalid system contract upgrade %d: missing address", i)
+		case len(upgrade.Code) == 0 && upgrade.CodeHash == (common.Hash{}):
+			return fmt.Errorf("invalid system contract upgrade %d: missing code", i)
+		case len(upgrade.Code) > 0 && upgrade.CodeHash != (common.Hash{}) && upgrade.CodeHash != upgrade.Hash():
+			return fmt.Errorf("invalid system contract upgrade %d: code hash mismatch", i)
+		case last != nil && last.Cmp(upgrade.Block) > 0:
+			return fmt.Errorf("unsupported system contract upgrade ordering: upgrade %d at block %v after block %v", i, upgrade.Block, last)
+		}
+		last = upgrade.Block
+	}
+	return nil
+}
+
+// checkUpgradesCompatible checks that the upgrades already applied at head are
+// scheduled identically in both configs.
+func checkUpgradesCompatible(stored, new []SysContractUpgrade, head *big.Int) *ConfigCompatError {
+	var applied, scheduled []SysContractUpgrade
+	for _, upgrade := range stored {
+		if isForked(upgrade.Block, head) {
+			applied = append(applied, upgrade)
+		}
+	}
+	for _, upgrade := range new {
+		if isForked(upgrade.Block, head) {
+			scheduled = append(scheduled, upgrade)
+		}
+	}
+	for i := 0; i < len(applied) || i < len(scheduled); i++ {
+		switch {
+		case i >= len(applied):
+			return newCompatError("system contract upgrade", nil, scheduled[i].Block)
+		case i >= len(scheduled):
+			return newCompatError("system contract upgrade", applied[i].Block, nil)
+		case !applied[i].equal(&scheduled[i]):
+			return newCompatError("system contract upgrade", applied[i].Block, scheduled[i].Block)
+		}
+	}
+	return nil
 }
 
 // String implements the stringer interface, returning the consensus engine details.
@@ -267,7 +505,7 @@ func (c *ChainConfig) String() string {
 	default:
 		engine = "unknown"
 	}
-	return fmt.Sprintf("{ChainID: %v Homestead: %v DAO: %v DAOSupport: %v EIP150: %v EIP155: %v EIP158: %v Byzantium: %v Constantinople: %v Petersburg: %v Istanbul: %v, Muir Glacier: %v, RedCoastBlock: %v, Berlin: %v, London: %v, Sophon: %v, Engine: %v}",
+	return fmt.Sprintf("{ChainID: %v Homestead: %v DAO: %v DAOSupport: %v EIP150: %v EIP155: %v EIP158: %v Byzantium: %v Constantinople: %v Petersburg: %v Istanbul: %v, Muir Glacier: %v, RedCoastBlock: %v, Berlin: %v, London: %v, Cancun: %v, Sophon: %v, Slashing: %v, FeeShare: %v, CallFeeShare: %v, Engine: %v}",
 		c.ChainID,
 		c.HomesteadBlock,
 		c.DAOForkBlock,
@@ -283,7 +521,11 @@ func (c *ChainConfig) String() string {
 		c.RedCoastBlock,
 		c.BerlinBlock,
 		c.LondonBlock,
+		c.CancunBlock,
 		c.SophonBlock,
+		c.SlashingBlock,
+		c.FeeShareBlock,
+		c.CallFeeShareBlock,
 		engine,
 	)
 }
@@ -355,6 +597,11 @@ func (c *ChainConfig) IsArrowGlacier(num *big.Int) bool {
 	return isForked(c.ArrowGlacierBlock, num)
 }
 
+// IsCancun returns whether num is either equal to the Cancun fork block or greater.
+func (c *ChainConfig) IsCancun(num *big.Int) bool {
+	return isForked(c.CancunBlock, num)
+}
+
 // IsTerminalPoWBlock returns whether the given block is the last block of PoW stage.
 func (c *ChainConfig) IsTerminalPoWBlock(parentTotalDiff *big.Int, totalDiff *big.Int) bool {
 	if c.TerminalTotalDifficulty == nil {
@@ -373,6 +620,21 @@ func (c *ChainConfig) IsSophon(num *big.Int) bool {
 	return isForked(c.SophonBlock, num)
 }
 
+// IsFeeShare returns whether num represents a block number after the FeeShare fork
+func (c *ChainConfig) IsFeeShare(num *big.Int) bool {
+	return isForked(c.FeeShareBlock, num)
+}
+
+// IsCallFeeShare returns whether num represents a block number after the CallFeeShare fork
+func (c *ChainConfig) IsCallFeeShare(num *big.Int) bool {
+	return isForked(c.CallFeeShareBlock, num)
+}
+
+// IsSlashing returns whether num represents a block number after the Slashing fork
+func (c *ChainConfig) IsSlashing(num *big.Int) bool {
+	return isForked(c.SlashingBlock, num)
+}
+
 // CheckCompatible checks whether scheduled fork transitions have been imported
 // with a mismatching chain configuration.
 func (c *ChainConfig) CheckCompatible(newcfg *ChainConfig, height uint64) *ConfigCompatError {
@@ -414,6 +676,7 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 		{name: "muirGlacierBlock", block: c.MuirGlacierBlock, optional: true},
 		{name: "berlinBlock", block: c.BerlinBlock},
 		{name: "londonBlock", block: c.LondonBlock},
+		{name: "cancunBlock", block: c.CancunBlock, optional: true},
 	} {
 		if lastFork.name != "" {
 			// Next one must be higher or the same number
@@ -436,7 +699,7 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 	// congress fork
 	lastFork = fork{}
 	for _, cur := range []fork{
-		{name: "redCoastBlock", block: c.RedCoastBlock, minValue: big.NewInt(2)},
+		{name: "redCoastBlock", block: c.RedCoastBlock, minValue: big.NewInt(1)},
 		{name: "sophonBlock", block: c.SophonBlock},
 	} {
 		// check minimal fork block
@@ -452,7 +715,7 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 					lastFork.name, cur.name, cur.block)
 			}
 			if lastFork.block != nil && cur.block != nil {
-				if lastFork.block.Cmp(cur.block) >= 0 {
+				if lastFork.block.Cmp(cur.block) > 0 {
 					return fmt.Errorf("unsupported fork ordering: %v enabled at %v, but %v enabled at %v",
 						lastFork.name, lastFork.block, cur.name, cur.block)
 				}
@@ -463,6 +726,49 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 			lastFork = cur
 		}
 	}
+	// congress feature forks, each of them may only be enabled on top of sophon
+	for _, cur := range []fork{
+		{name: "slashingBlock", block: c.SlashingBlock},
+		{name: "feeShareBlock", block: c.FeeShareBlock},
+		{name: "callFeeShareBlock", block: c.CallFeeShareBlock},
+	} {
+		if cur.block == nil {
+			continue
+		}
+		if c.SophonBlock == nil {
+			return fmt.Errorf("unsupported fork ordering: sophonBlock not enabled, but %v enabled at %v", cur.name, cur.block)
+		}
+		if c.SophonBlock.Cmp(cur.block) > 0 {
+			return fmt.Errorf("unsupported fork ordering: sophonBlock enabled at %v, but %v enabled at %v", c.SophonBlock, cur.name, cur.block)
+		}
+	}
+	// the call fee attribution refines the fee sharing by receipt gas used
+	if c.CallFeeShareBlock != nil && (c.FeeShareBlock == nil || c.FeeShareBlock.Cmp(c.CallFeeShareBlock) > 0) {
+		return fmt.Errorf("unsupported fork ordering: feeShareBlock enabled at %v, but callFeeShareBlock enabled at %v", c.FeeShareBlock, c.CallFeeShareBlock)
+	}
+	if c.Congress != nil {
+		validators := congressValidatorsContract
+		if c.Congress.ValidatorParamsBlock != nil && c.IsRedCoast(c.Congress.ValidatorParamsBlock) {
+			validators = congressValidatorsV1Contract
+		}
+		if err := c.Congress.checkValidatorParams(validators); err != nil {
+			return err
+		}
+		if err := c.Congress.checkRecents(); err != nil {
+			return err
+		}
+		// the consensus keys stay in the validators contract of the fork
+		validators = congressValidatorsContract
+		if c.Congress.ConsensusKeyBlock != nil && c.IsRedCoast(c.Congress.ConsensusKeyBlock) {
+			validators = congressValidatorsV1Contract
+		} else if c.Congress.ConsensusKeyBlock != nil && c.RedCoastBlock != nil {
+			return fmt.Errorf("unsupported fork ordering: consensusKeyBlock enabled at %v, but redCoastBlock enabled at %v", c.Congress.ConsensusKeyBlock, c.RedCoastBlock)
+		}
+		if err := c.Congress.checkConsensusKey(validators); err != nil {
+			return err
+		}
+		return c.Congress.checkUpgrades()
+	}
 	return nil
 }
 
@@ -519,6 +825,32 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, head *big.Int) *Confi
 	if isForkIncompatible(c.ArrowGlacierBlock, newcfg.ArrowGlacierBlock, head) {
 		return newCompatError("Arrow Glacier fork block", c.ArrowGlacierBlock, newcfg.ArrowGlacierBlock)
 	}
+	if isForkIncompatible(c.CancunBlock, newcfg.CancunBlock, head) {
+		return newCompatError("Cancun fork block", c.CancunBlock, newcfg.CancunBlock)
+	}
+	if isForkIncompatible(c.SlashingBlock, newcfg.SlashingBlock, head) {
+		return newCompatError("Slashing fork block", c.SlashingBlock, newcfg.SlashingBlock)
+	}
+	if isForkIncompatible(c.FeeShareBlock, newcfg.FeeShareBlock, head) {
+		return newCompatError("FeeShare fork block", c.FeeShareBlock, newcfg.FeeShareBlock)
+	}
+	if isForkIncompatible(c.CallFeeShareBlock, newcfg.CallFeeShareBlock, head) {
+		return newCompatError("CallFeeShare fork block", c.CallFeeShareBlock, newcfg.CallFeeShareBlock)
+	}
+	if c.Congress != nil && newcfg.Congress != nil {
+		if err := checkUpgradesCompatible(c.Congress.Upgrades, newcfg.Congress.Upgrades, head); err != nil {
+			return err
+		}
+		if err := checkValidatorParamsCompatible(c.Congress, newcfg.Congress, head); err != nil {
+			return err
+		}
+		if isForkIncompatible(c.Congress.RecentsBlock, newcfg.Congress.RecentsBlock, head) {
+			return newCompatError("recents fork block", c.Congress.RecentsBlock, newcfg.Congress.RecentsBlock)
+		}
+		if isForkIncompatible(c.Congress.ConsensusKeyBlock, newcfg.Congress.ConsensusKeyBlock, head) {
+			return newCompatError("consensus key fork block", c.Congress.ConsensusKeyBlock, newcfg.Congress.ConsensusKeyBlock)
+		}
+	}
 	return nil
 }
 
@@ -618,7 +950,8 @@ type Rules struct {
 	ChainID                                                 *big.Int
 	IsHomestead, IsEIP150, IsEIP155, IsEIP158               bool
 	IsByzantium, IsConstantinople, IsPetersburg, IsIstanbul bool
-	IsBerlin, IsLondon                                      bool
+	IsBerlin, IsLondon, IsCancun                            bool
+	IsCallFeeShare                                          bool
 }
 
 // Rules ensures c's ChainID is not nil.
@@ -639,5 +972,7 @@ func (c *ChainConfig) Rules(num *big.Int) Rules {
 		IsIstanbul:       c.IsIstanbul(num),
 		IsBerlin:         c.IsBerlin(num),
 		IsLondon:         c.IsLondon(num),
+		IsCancun:         c.IsCancun(num),
+		IsCallFeeShare:   c.IsCallFeeShare(num),
 	}
 }
@@ -21,8 +21,16 @@ import (
 	"math/big"
 	"reflect"
 	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
+// upgrades is a valid system contract upgrade schedule.
+var upgrades = []SysContractUpgrade{
+	{Block: big.NewInt(30), Address: common.HexToAddress("0x000000000000000000000000000000000000f000"), Code: []byte{0x60, 0x00}},
+	{Block: big.NewInt(40), Address: common.HexToAddress("0x000000000000000000000000000000000000f001"), Code: []byte{0x60, 0x01}, InitData: []byte{0x81, 0x29, 0xfc, 0x1c}},
+}
+
 func TestCheckCompatible(t *testing.T) {
 	type test struct {
 		stored, new *ChainConfig
@@ -100,6 +108,129 @@ func TestCheckCompatible(t *testing.T) {
 			head:    uint64(100),
 			wantErr: nil,
 		},
+		{
+			stored: &ChainConfig{SlashingBlock: big.NewInt(30)},
+			new:    &ChainConfig{SlashingBlock: big.NewInt(40)},
+			head:   35,
+			wantErr: &ConfigCompatError{
+				What:         "Slashing fork block",
+				StoredConfig: big.NewInt(30),
+				NewConfig:    big.NewInt(40),
+				RewindTo:     29,
+			},
+		},
+		{
+			stored: &ChainConfig{FeeShareBlock: big.NewInt(30)},
+			new:    &ChainConfig{},
+			head:   35,
+			wantErr: &ConfigCompatError{
+				What:         "FeeShare fork block",
+				StoredConfig: big.NewInt(30),
+				NewConfig:    nil,
+				RewindTo:     29,
+			},
+		},
+		{
+			stored:  &ChainConfig{Congress: &CongressConfig{Upgrades: upgrades[:1]}},
+			new:     &ChainConfig{Congress: &CongressConfig{Upgrades: upgrades}},
+			head:    35,
+			wantErr: nil,
+		},
+		{
+			stored: &ChainConfig{Congress: &CongressConfig{Upgrades: upgrades[:1]}},
+			new:    &ChainConfig{Congress: &CongressConfig{}},
+			head:   35,
+			wantErr: &ConfigCompatError{
+				What:         "system contract upgrade",
+				StoredConfig: big.NewInt(30),
+				NewConfig:    nil,
+				RewindTo:     29,
+			},
+		},
+		{
+			stored: &ChainConfig{Congress: &CongressConfig{Upgrades: upgrades}},
+			new:    &ChainConfig{Congress: &CongressConfig{Upgrades: []SysContractUpgrade{upgrades[0], {Block: big.NewInt(40), Address: upgrades[1].Address, Code: []byte{0x00}}}}},
+			head:   45,
+			wantErr: &ConfigCompatError{
+				What:         "system contract upgrade",
+				StoredConfig: big.NewInt(40),
+				NewConfig:    big.NewInt(40),
+				RewindTo:     39,
+			},
+		},
+		{
+			stored:  &ChainConfig{Congress: &CongressConfig{Upgrades: upgrades[:1]}},
+			new:     &ChainConfig{Congress: &CongressConfig{Upgrades: []SysContractUpgrade{{Block: big.NewInt(30), Address: upgrades[0].Address, CodeHash: upgrades[0].Hash()}}}},
+			head:    35,
+			wantErr: nil,
+		},
+		{
+			stored:  &ChainConfig{LondonBlock: big.NewInt(0), CancunBlock: big.NewInt(30)},
+			new:     &ChainConfig{LondonBlock: big.NewInt(0), CancunBlock: big.NewInt(40)},
+			head:    25,
+			wantErr: nil,
+		},
+		{
+			stored: &ChainConfig{LondonBlock: big.NewInt(0), CancunBlock: big.NewInt(30)},
+			new:    &ChainConfig{LondonBlock: big.NewInt(0), CancunBlock: big.NewInt(40)},
+			head:   35,
+			wantErr: &ConfigCompatError{
+				What:         "Cancun fork block",
+				StoredConfig: big.NewInt(30),
+				NewConfig:    big.NewInt(40),
+				RewindTo:     29,
+			},
+		},
+		{
+			stored:  &ChainConfig{Congress: &CongressConfig{Epoch: 10, ValidatorParamsBlock: big.NewInt(100), MaxValidators: 30}},
+			new:     &ChainConfig{Congress: &CongressConfig{Epoch: 10, ValidatorParamsBlock: big.NewInt(200), MaxValidators: 31}},
+			head:    50,
+			wantErr: nil,
+		},
+		{
+			stored: &ChainConfig{Congress: &CongressConfig{Epoch: 10, ValidatorParamsBlock: big.NewInt(100), MaxValidators: 30}},
+			new:    &ChainConfig{Congress: &CongressConfig{Epoch: 10, ValidatorParamsBlock: big.NewInt(100), MaxValidators: 31}},
+			head:   150,
+			wantErr: &ConfigCompatError{
+				What:         "validator params",
+				StoredConfig: big.NewInt(100),
+				NewConfig:    big.NewInt(100),
+				RewindTo:     99,
+			},
+		},
+		{
+			stored: &ChainConfig{Congress: &CongressConfig{Epoch: 10, ValidatorParamsBlock: big.NewInt(100), EpochOverride: 20}},
+			new:    &ChainConfig{Congress: &CongressConfig{Epoch: 10}},
+			head:   150,
+			wantErr: &ConfigCompatError{
+				What:         "validator params fork block",
+				StoredConfig: big.NewInt(100),
+				NewConfig:    nil,
+				RewindTo:     99,
+			},
+		},
+		{
+			stored: &ChainConfig{Congress: &CongressConfig{Epoch: 10, RecentsBlock: big.NewInt(100)}},
+			new:    &ChainConfig{Congress: &CongressConfig{Epoch: 10, RecentsBlock: big.NewInt(120)}},
+			head:   110,
+			wantErr: &ConfigCompatError{
+				What:         "recents fork block",
+				StoredConfig: big.NewInt(100),
+				NewConfig:    big.NewInt(120),
+				RewindTo:     99,
+			},
+		},
+		{
+			stored: &ChainConfig{Congress: &CongressConfig{Epoch: 10, ConsensusKeyBlock: big.NewInt(100)}},
+			new:    &ChainConfig{Congress: &CongressConfig{Epoch: 10}},
+			head:   100,
+			wantErr: &ConfigCompatError{
+				What:         "consensus key fork block",
+				StoredConfig: big.NewInt(100),
+				NewConfig:    nil,
+				RewindTo:     99,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -123,9 +254,50 @@ func TestCheckConfigForkOrder(t *testing.T) {
 		{new: AllCliqueProtocolChanges},
 		{new: TestChainConfig},
 		{new: &ChainConfig{RedCoastBlock: big.NewInt(0)}, isErr: true},
-		{new: &ChainConfig{RedCoastBlock: big.NewInt(1)}, isErr: true},
+		{new: &ChainConfig{RedCoastBlock: big.NewInt(1)}},
 		{new: &ChainConfig{SophonBlock: big.NewInt(3)}, isErr: true},
-		{new: &ChainConfig{RedCoastBlock: big.NewInt(2), SophonBlock: big.NewInt(2)}, isErr: true},
+		{new: &ChainConfig{RedCoastBlock: big.NewInt(1), SophonBlock: big.NewInt(1)}},
+		{new: &ChainConfig{RedCoastBlock: big.NewInt(3), SophonBlock: big.NewInt(2)}, isErr: true},
+		{new: &ChainConfig{SlashingBlock: big.NewInt(5)}, isErr: true},
+		{new: &ChainConfig{RedCoastBlock: big.NewInt(2), SophonBlock: big.NewInt(5), SlashingBlock: big.NewInt(4)}, isErr: true},
+		{new: &ChainConfig{RedCoastBlock: big.NewInt(2), SophonBlock: big.NewInt(5), SlashingBlock: big.NewInt(5)}},
+		{new: &ChainConfig{RedCoastBlock: big.NewInt(2), SophonBlock: big.NewInt(5), FeeShareBlock: big.NewInt(4)}, isErr: true},
+		{new: &ChainConfig{RedCoastBlock: big.NewInt(2), SophonBlock: big.NewInt(5), FeeShareBlock: big.NewInt(6)}},
+		{new: &ChainConfig{RedCoastBlock: big.NewInt(2), SophonBlock: big.NewInt(5), CallFeeShareBlock: big.NewInt(6)}, isErr: true},
+		{new: &ChainConfig{RedCoastBlock: big.NewInt(2), SophonBlock: big.NewInt(5), FeeShareBlock: big.NewInt(7), CallFeeShareBlock: big.NewInt(6)}, isErr: true},
+		{new: &ChainConfig{RedCoastBlock: big.NewInt(2), SophonBlock: big.NewInt(5), FeeShareBlock: big.NewInt(6), CallFeeShareBlock: big.NewInt(6)}},
+		{new: &ChainConfig{HomesteadBlock: big.NewInt(0), CancunBlock: big.NewInt(10)}, isErr: true},
+		{new: &ChainConfig{ByzantiumBlock: big.NewInt(0), ConstantinopleBlock: big.NewInt(0), PetersburgBlock: big.NewInt(0), IstanbulBlock: big.NewInt(0), BerlinBlock: big.NewInt(0), LondonBlock: big.NewInt(20), CancunBlock: big.NewInt(10)}, isErr: true},
+		{new: &ChainConfig{HomesteadBlock: big.NewInt(0), EIP150Block: big.NewInt(0), EIP155Block: big.NewInt(0), EIP158Block: big.NewInt(0), ByzantiumBlock: big.NewInt(0), ConstantinopleBlock: big.NewInt(0), PetersburgBlock: big.NewInt(0), IstanbulBlock: big.NewInt(0), BerlinBlock: big.NewInt(0), LondonBlock: big.NewInt(0), CancunBlock: big.NewInt(10)}},
+		{new: &ChainConfig{Congress: &CongressConfig{Upgrades: upgrades}}},
+		{new: &ChainConfig{Congress: &CongressConfig{Epoch: 10, MaxValidators: 30}}, isErr: true},
+		{new: &ChainConfig{Congress: &CongressConfig{Epoch: 10, ValidatorParamsBlock: big.NewInt(0), MaxValidators: 30}}, isErr: true},
+		{new: &ChainConfig{Congress: &CongressConfig{Epoch: 10, ValidatorParamsBlock: big.NewInt(15), MaxValidators: 15}}},
+		{new: &ChainConfig{Congress: &CongressConfig{Epoch: 10, ValidatorParamsBlock: big.NewInt(15), MaxValidators: 30}}, isErr: true},
+		{new: &ChainConfig{Congress: &CongressConfig{Epoch: 10, ValidatorParamsBlock: big.NewInt(15), MaxValidators: 30, Upgrades: upgrades}}, isErr: true},
+		{new: &ChainConfig{Congress: &CongressConfig{Epoch: 10, ValidatorParamsBlock: big.NewInt(30), MaxValidators: 30, Upgrades: upgrades}}},
+		{new: &ChainConfig{RedCoastBlock: big.NewInt(2), SophonBlock: big.NewInt(3), Congress: &CongressConfig{Epoch: 10, ValidatorParamsBlock: big.NewInt(30), MaxValidators: 30, Upgrades: upgrades}}, isErr: true},
+		{new: &ChainConfig{Congress: &CongressConfig{Epoch: 10, ValidatorParamsBlock: big.NewInt(15), EpochOverride: 5}}, isErr: true},
+		{new: &ChainConfig{Congress: &CongressConfig{Epoch: 10, ValidatorParamsBlock: big.NewInt(20), EpochOverride: 4}}},
+		{new: &ChainConfig{Congress: &CongressConfig{ValidatorParamsBlock: big.NewInt(20), EpochOverride: 4}}, isErr: true},
+		{new: &ChainConfig{Congress: &CongressConfig{Epoch: 10, RecentsBlock: big.NewInt(0)}}, isErr: true},
+		{new: &ChainConfig{Congress: &CongressConfig{Epoch: 10, RecentsBlock: big.NewInt(7)}}},
+		{new: &ChainConfig{Congress: &CongressConfig{Epoch: 10, ConsensusKeyBlock: big.NewInt(0)}}, isErr: true},
+		{new: &ChainConfig{Congress: &CongressConfig{Epoch: 10, ConsensusKeyBlock: big.NewInt(15)}}, isErr: true},
+		{new: &ChainConfig{Congress: &CongressConfig{Epoch: 10, ConsensusKeyBlock: big.NewInt(20)}}, isErr: true},
+		{new: &ChainConfig{Congress: &CongressConfig{Epoch: 10, ConsensusKeyBlock: big.NewInt(20), Upgrades: upgrades}}, isErr: true},
+		{new: &ChainConfig{Congress: &CongressConfig{Epoch: 10, ConsensusKeyBlock: big.NewInt(30), Upgrades: upgrades}}},
+		{new: &ChainConfig{Congress: &CongressConfig{Epoch: 10, ValidatorParamsBlock: big.NewInt(20), EpochOverride: 4, ConsensusKeyBlock: big.NewInt(30), Upgrades: upgrades}}, isErr: true},
+		{new: &ChainConfig{Congress: &CongressConfig{Epoch: 10, ValidatorParamsBlock: big.NewInt(20), EpochOverride: 4, ConsensusKeyBlock: big.NewInt(32), Upgrades: upgrades}}},
+		{new: &ChainConfig{RedCoastBlock: big.NewInt(2), SophonBlock: big.NewInt(3), Congress: &CongressConfig{Epoch: 10, ConsensusKeyBlock: big.NewInt(30), Upgrades: upgrades}}, isErr: true},
+		{new: &ChainConfig{RedCoastBlock: big.NewInt(2), SophonBlock: big.NewInt(3), Congress: &CongressConfig{Epoch: 10, ConsensusKeyBlock: big.NewInt(30), Upgrades: []SysContractUpgrade{{Block: big.NewInt(30), Address: common.HexToAddress("0x000000000000000000000000000000000000F005"), Code: []byte{0x60, 0x00}}}}}},
+		{new: &ChainConfig{RedCoastBlock: big.NewInt(40), Congress: &CongressConfig{Epoch: 10, ConsensusKeyBlock: big.NewInt(30), Upgrades: upgrades}}, isErr: true},
+		{new: &ChainConfig{Congress: &CongressConfig{Upgrades: []SysContractUpgrade{upgrades[1], upgrades[0]}}}, isErr: true},
+		{new: &ChainConfig{Congress: &CongressConfig{Upgrades: []SysContractUpgrade{{Address: upgrades[0].Address, Code: upgrades[0].Code}}}}, isErr: true},
+		{new: &ChainConfig{Congress: &CongressConfig{Upgrades: []SysContractUpgrade{{Block: big.NewInt(30), Code: upgrades[0].Code}}}}, isErr: true},
+		{new: &ChainConfig{Congress: &CongressConfig{Upgrades: []SysContractUpgrade{{Block: big.NewInt(30), Address: upgrades[0].Address}}}}, isErr: true},
+		{new: &ChainConfig{Congress: &CongressConfig{Upgrades: []SysContractUpgrade{{Block: big.NewInt(30), Address: upgrades[0].Address, CodeHash: upgrades[0].Hash()}}}}},
+		{new: &ChainConfig{Congress: &CongressConfig{Upgrades: []SysContractUpgrade{{Block: big.NewInt(30), Address: upgrades[0].Address, Code: upgrades[0].Code, CodeHash: upgrades[1].Hash()}}}}, isErr: true},
 	}
 	for _, tc := range tests {
 		err := tc.new.CheckConfigForkOrder()
@@ -137,3 +309,29 @@ func TestCheckConfigForkOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestCongressRecentsLimit(t *testing.T) {
+	config := &CongressConfig{Epoch: 10, RecentsBlock: big.NewInt(100)}
+	tests := []struct {
+		number     uint64
+		validators int
+		want       uint64
+	}{
+		{99, 1, 1},
+		{99, 2, 2},
+		{99, 3, 2},
+		{99, 21, 2},
+		{99, 22, 12},
+		{100, 1, 1},
+		{100, 2, 2},
+		{100, 3, 2},
+		{100, 7, 4},
+		{100, 21, 11},
+		{100, 22, 12},
+	}
+	for i, tt := range tests {
+		if have := config.RecentsLimit(tt.number, tt.validators); have != tt.want {
+			t.Errorf("test %d: recents limit mismatch at block %d with %d validators: have %d, want %d", i, tt.number, tt.validators, have, tt.want)
+		}
+	}
+}
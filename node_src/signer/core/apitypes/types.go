@@ -187,12 +187,30 @@ var (
 		accounts.MimetypeClique,
 		0x02,
 	}
+	ApplicationCongress = SigFormat{
+		accounts.MimetypeCongress,
+		0x03,
+	}
+	ApplicationCongressVote = SigFormat{
+		accounts.MimetypeCongressVote,
+		0x04,
+	}
 	TextPlain = SigFormat{
 		accounts.MimetypeTextPlain,
 		0x45,
 	}
 )
 
+// CongressHeader is the summary of a congress header to sign, exposed to the
+// user and to the rules.
+type CongressHeader struct {
+	Number     uint64         `json:"number"`
+	ParentHash common.Hash    `json:"parentHash"`
+	Difficulty uint64         `json:"difficulty"`
+	Coinbase   common.Address `json:"coinbase"`
+	SealHash   common.Hash    `json:"sealHash"`
+}
+
 type ValidatorData struct {
 	Address common.Address
 	Message hexutil.Bytes
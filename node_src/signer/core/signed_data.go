@@ -32,6 +32,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	
 	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/ethereum/go-ethereum/consensus/congress"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -69,6 +70,12 @@ func (api *SignerAPI) sign(req *SignDataRequest, legacyV bool) (hexutil.Bytes, e
 	if err != nil {
 		return nil, err
 	}
+	// Refuse to sign two different congress headers at the same height
+	if req.CongressHeader != nil && api.headers != nil {
+		if err := api.headers.CheckAndRecord(account.Address, req.CongressHeader.Number, req.CongressHeader.SealHash); err != nil {
+			return nil, err
+		}
+	}
 	// Sign the data with the wallet
 	signature, err := wallet.SignDataWithPassphrase(account, pw, req.ContentType, req.Rawdata)
 	if err != nil {
@@ -180,6 +187,84 @@ func (api *SignerAPI) determineSignatureFormat(ctx context.Context, contentType
 		// Clique uses V on the form 0 or 1
 		useEthereumV = false
 		req = &SignDataRequest{ContentType: mediaType, Rawdata: cliqueRlp, Messages: messages, Hash: sighash}
+	case apitypes.ApplicationCongress.Mime:
+		// Congress headers are signed like clique ones, without the seal
+		stringData, ok := data.(string)
+		if !ok {
+			return nil, useEthereumV, fmt.Errorf("input for %v must be an hex-encoded string", apitypes.ApplicationCongress.Mime)
+		}
+		congressData, err := hexutil.Decode(stringData)
+		if err != nil {
+			return nil, useEthereumV, err
+		}
+		header := &types.Header{}
+		if err := rlp.DecodeBytes(congressData, header); err != nil {
+			return nil, useEthereumV, err
+		}
+		// The extradata comes without the seal, add it back for hashing
+		header.Extra = append(header.Extra, make([]byte, crypto.SignatureLength)...)
+		sighash, congressRlp := congress.SealHash(header), congress.CongressRLP(header)
+		info := &apitypes.CongressHeader{
+			Number:     header.Number.Uint64(),
+			ParentHash: header.ParentHash,
+			Difficulty: header.Difficulty.Uint64(),
+			Coinbase:   header.Coinbase,
+			SealHash:   sighash,
+		}
+		messages := []*apitypes.NameValueType{
+			{
+				Name:  "Congress header",
+				Typ:   "congress",
+				Value: fmt.Sprintf("congress header %d [0x%x]", info.Number, sighash),
+			},
+			{
+				Name:  "Height",
+				Typ:   "uint64",
+				Value: fmt.Sprintf("%d", info.Number),
+			},
+			{
+				Name:  "Parent hash",
+				Typ:   "hash",
+				Value: info.ParentHash.Hex(),
+			},
+			{
+				Name:  "Difficulty",
+				Typ:   "uint64",
+				Value: fmt.Sprintf("%d", info.Difficulty),
+			},
+			{
+				Name:  "Coinbase",
+				Typ:   "address",
+				Value: info.Coinbase.Hex(),
+			},
+		}
+		// Congress uses V on the form 0 or 1
+		useEthereumV = false
+		req = &SignDataRequest{ContentType: mediaType, Rawdata: congressRlp, Messages: messages, Hash: sighash.Bytes(), CongressHeader: info}
+	case apitypes.ApplicationCongressVote.Mime:
+		// Congress votes are signed over the hash of the vote, without any prefix
+		stringData, ok := data.(string)
+		if !ok {
+			return nil, useEthereumV, fmt.Errorf("input for %v must be an hex-encoded string", apitypes.ApplicationCongressVote.Mime)
+		}
+		voteData, err := hexutil.Decode(stringData)
+		if err != nil {
+			return nil, useEthereumV, err
+		}
+		vote := new(types.VoteData)
+		if err := rlp.DecodeBytes(voteData, vote); err != nil {
+			return nil, useEthereumV, err
+		}
+		messages := []*apitypes.NameValueType{
+			{
+				Name:  "Congress vote",
+				Typ:   "congress",
+				Value: fmt.Sprintf("congress vote %d [0x%x]", vote.Number, vote.Hash),
+			},
+		}
+		// Votes are verified with V on the form 0 or 1, like the headers
+		useEthereumV = false
+		req = &SignDataRequest{ContentType: mediaType, Rawdata: voteData, Messages: messages, Hash: vote.SigHash().Bytes()}
 	default: // also case TextPlain.Mime:
 		// Calculates an Ethereum ECDSA signature for:
 		// hash = keccak256("\x19${byteVersion}Ethereum Signed Message:\n${message length}${message}")
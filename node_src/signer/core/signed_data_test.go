@@ -21,8 +21,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"path"
 	"strings"
 	"testing"
@@ -31,7 +33,12 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus/congress"
+	"github.com/ethereum/go-ethereum/consensus/congress/slashprotect"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/signer/core"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
@@ -233,6 +240,81 @@ func TestSignData(t *testing.T) {
 	}
 }
 
+func TestSignCongressHeader(t *testing.T) {
+	api, control := setup(t)
+	api.SetSlashingProtection(slashprotect.New(memorydb.New()))
+	createAccount(control, api, t)
+	control.approveCh <- "A"
+	list, err := api.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := common.NewMixedcaseAddress(list[0])
+
+	sign := func(header *types.Header) (hexutil.Bytes, error) {
+		header.Extra = make([]byte, 32+crypto.SignatureLength)
+		control.approveCh <- "Y"
+		control.inputCh <- "a_long_password"
+		return api.SignData(context.Background(), apitypes.ApplicationCongress.Mime, a, hexutil.Encode(congress.CongressRLP(header)))
+	}
+	header := &types.Header{Number: big.NewInt(10), Difficulty: big.NewInt(2), ParentHash: common.HexToHash("0x01")}
+	signature, err := sign(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The header is signed with a 0/1 V, as sealed by congress
+	pubkey, err := crypto.SigToPub(congress.SealHash(header).Bytes(), signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer := crypto.PubkeyToAddress(*pubkey); signer != list[0] {
+		t.Errorf("signer mismatch: have %x, want %x", signer, list[0])
+	}
+	// The same header can be signed again, another one at the same height can't
+	if _, err := sign(header); err != nil {
+		t.Errorf("failed to sign same header: %v", err)
+	}
+	header.ParentHash = common.HexToHash("0x02")
+	if _, err := sign(header); !errors.Is(err, slashprotect.ErrDoubleSign) {
+		t.Errorf("error mismatch: have %v, want %v", err, slashprotect.ErrDoubleSign)
+	}
+	header.Number = big.NewInt(11)
+	if _, err := sign(header); err != nil {
+		t.Errorf("failed to sign next header: %v", err)
+	}
+}
+
+func TestSignCongressVote(t *testing.T) {
+	api, control := setup(t)
+	createAccount(control, api, t)
+	control.approveCh <- "A"
+	list, err := api.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := common.NewMixedcaseAddress(list[0])
+
+	vote := types.VoteData{Number: 10, Hash: common.HexToHash("0x01")}
+	data, err := rlp.EncodeToBytes(&vote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	control.approveCh <- "Y"
+	control.inputCh <- "a_long_password"
+	signature, err := api.SignData(context.Background(), apitypes.ApplicationCongressVote.Mime, a, hexutil.Encode(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The vote is signed over its raw hash, as verified by the vote pool
+	pubkey, err := crypto.Ecrecover(vote.SigHash().Bytes(), signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer := common.BytesToAddress(crypto.Keccak256(pubkey[1:])[12:]); signer != list[0] {
+		t.Errorf("signer mismatch: have %x, want %x", signer, list[0])
+	}
+}
+
 func TestDomainChainId(t *testing.T) {
 	withoutChainID := apitypes.TypedData{
 		Types: apitypes.Types{
@@ -627,3 +627,41 @@ function ApproveSignData(r){
 		t.Fatalf("Expected approved")
 	}
 }
+
+func TestSignCongressHeader(t *testing.T) {
+	js := `function ApproveSignData(r){
+    var h = r.congress_header
+    if (h && h.number > 100 && h.difficulty == 2 && h.parentHash == "0x0000000000000000000000000000000000000000000000000000000000000001") {
+        return "Approve"
+    }
+    return "Reject"
+}`
+	r, err := initRuleEngine(js)
+	if err != nil {
+		t.Fatalf("Couldn't create evaluator %v", err)
+	}
+	addr, _ := mixAddr("0x694267f14675d7e1b9494fd8d72fefe1755710fa")
+
+	approve := func(number uint64) bool {
+		resp, err := r.ApproveSignData(&core.SignDataRequest{
+			ContentType: apitypes.ApplicationCongress.Mime,
+			Address:     *addr,
+			Meta:        core.Metadata{Remote: "remoteip", Local: "localip", Scheme: "inproc"},
+			CongressHeader: &apitypes.CongressHeader{
+				Number:     number,
+				ParentHash: common.HexToHash("0x01"),
+				Difficulty: 2,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		return resp.Approved
+	}
+	if !approve(101) {
+		t.Errorf("Expected approved")
+	}
+	if approve(100) {
+		t.Errorf("Expected rejected")
+	}
+}